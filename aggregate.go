@@ -0,0 +1,146 @@
+package querycraft
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// AggregateBuilder - несколько агрегатов в одном запросе:
+// SELECT COUNT(*) AS total, SUM(amount) AS revenue, ... FROM ...
+type AggregateBuilder interface {
+	Count(alias string) AggregateBuilder
+	Sum(column, alias string) AggregateBuilder
+	Avg(column, alias string) AggregateBuilder
+	Min(column, alias string) AggregateBuilder
+	Max(column, alias string) AggregateBuilder
+
+	Exec() (AggregateResult, error)
+}
+
+// AggregateResult - значения агрегатов по алиасам
+type AggregateResult struct {
+	values map[string]any
+}
+
+type aggregateBuilder struct {
+	query   *selectBuilder
+	columns []string
+}
+
+// Aggregates возвращает билдер для выполнения нескольких агрегатов одним запросом
+func (s *selectBuilder) Aggregates() AggregateBuilder {
+	return &aggregateBuilder{query: s}
+}
+
+func (a *aggregateBuilder) Count(alias string) AggregateBuilder {
+	return a.add("COUNT", "*", alias)
+}
+
+func (a *aggregateBuilder) Sum(column, alias string) AggregateBuilder {
+	return a.add("SUM", column, alias)
+}
+
+func (a *aggregateBuilder) Avg(column, alias string) AggregateBuilder {
+	return a.add("AVG", column, alias)
+}
+
+func (a *aggregateBuilder) Min(column, alias string) AggregateBuilder {
+	return a.add("MIN", column, alias)
+}
+
+func (a *aggregateBuilder) Max(column, alias string) AggregateBuilder {
+	return a.add("MAX", column, alias)
+}
+
+func (a *aggregateBuilder) add(fn, column, alias string) AggregateBuilder {
+	d := a.query.dialect
+	a.columns = append(a.columns, fmt.Sprintf("%s(%s) AS %s", fn, d.QuoteIdentifier(column), d.QuoteIdentifier(alias)))
+	return a
+}
+
+func (a *aggregateBuilder) Exec() (AggregateResult, error) {
+	if len(a.columns) == 0 {
+		return AggregateResult{}, fmt.Errorf("no aggregates specified")
+	}
+
+	// Сортировка и пагинация на агрегаты не влияют
+	query := a.query.Clone().(*selectBuilder)
+	query.logger = a.query.logger
+	query.printSQL = a.query.printSQL
+	query.prettySQL = a.query.prettySQL
+	query.SetColumns(a.columns...)
+	query.orders = nil
+	query.limit = nil
+	query.offset = nil
+
+	row, err := query.Row()
+	if err != nil {
+		return AggregateResult{}, err
+	}
+
+	return AggregateResult{values: row}, nil
+}
+
+// Get возвращает значение агрегата как есть (nil для SUM/AVG/MIN/MAX по пустой выборке)
+func (r AggregateResult) Get(alias string) any {
+	return r.values[alias]
+}
+
+// Int64 возвращает значение агрегата как int64, 0 если значения нет
+func (r AggregateResult) Int64(alias string) int64 {
+	switch v := r.values[alias].(type) {
+	case int64:
+		return v
+	case int:
+		return int64(v)
+	case float64:
+		return int64(v)
+	case string:
+		return parseAggregateInt(v)
+	case []byte:
+		return parseAggregateInt(string(v))
+	}
+	return 0
+}
+
+// parseAggregateInt - драйверы MySQL возвращают DECIMAL/BIGINT строкой
+func parseAggregateInt(s string) int64 {
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return i
+	}
+	f, _ := strconv.ParseFloat(s, 64)
+	return int64(f)
+}
+
+// Float64 возвращает значение агрегата как float64, 0 если значения нет
+func (r AggregateResult) Float64(alias string) float64 {
+	switch v := r.values[alias].(type) {
+	case float64:
+		return v
+	case float32:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case int:
+		return float64(v)
+	case string:
+		f, _ := strconv.ParseFloat(v, 64)
+		return f
+	case []byte:
+		f, _ := strconv.ParseFloat(string(v), 64)
+		return f
+	}
+	return 0
+}
+
+// String возвращает значение агрегата строкой, "" если значения нет
+func (r AggregateResult) String(alias string) string {
+	v := r.values[alias]
+	if v == nil {
+		return ""
+	}
+	if b, ok := v.([]byte); ok {
+		return string(b)
+	}
+	return fmt.Sprintf("%v", v)
+}
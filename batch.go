@@ -0,0 +1,76 @@
+package querycraft
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// RawQuery - один SQL запрос с аргументами для пакетного выполнения
+type RawQuery struct {
+	SQL  string
+	Args []any
+}
+
+// BatchBuilder - последовательное выполнение нескольких запросов
+type BatchBuilder struct {
+	db      SQLXExecutor
+	queries []RawQuery
+	logger  Logger
+}
+
+func NewBatch(db SQLXExecutor) *BatchBuilder {
+	return &BatchBuilder{
+		db: db,
+	}
+}
+
+// Add добавляет запрос в пакет
+func (b *BatchBuilder) Add(sql string, args ...any) *BatchBuilder {
+	b.queries = append(b.queries, RawQuery{SQL: sql, Args: args})
+	return b
+}
+
+// Exec выполняет запросы по порядку и останавливается на первой ошибке.
+// Возвращает результаты успешно выполненных запросов
+func (b *BatchBuilder) Exec(ctx context.Context) ([]sql.Result, error) {
+	results := make([]sql.Result, 0, len(b.queries))
+
+	for i, q := range b.queries {
+		var start time.Time
+		if b.logger != nil {
+			logQueryStart(b.logger, ctx, q.SQL, q.Args)
+			start = time.Now()
+		}
+
+		result, err := b.db.ExecContext(ctx, q.SQL, q.Args...)
+
+		if b.logger != nil {
+			duration := time.Since(start)
+			logQuery(b.logger, ctx, q.SQL, q.Args, duration, err)
+		}
+
+		if err != nil {
+			return results, fmt.Errorf("batch query %d: %w", i, err)
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+func (b *BatchBuilder) setLogger(logger Logger) {
+	b.logger = logger
+}
+
+// batchExec выполняет готовый список запросов
+func batchExec(ctx context.Context, db SQLXExecutor, logger Logger, queries []RawQuery) error {
+	batch := NewBatch(db)
+	batch.queries = queries
+	if logger != nil {
+		batch.setLogger(logger)
+	}
+	_, err := batch.Exec(ctx)
+	return err
+}
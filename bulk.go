@@ -17,6 +17,7 @@ type BulkBuilder interface {
 	BulkInsert(table string, data any, opts ...BulkOption) error
 	BulkUpdate(table string, data any, opts ...BulkOption) error
 	BulkDelete(table string, conditions []map[string]any) error
+	BulkDeleteBySlice(table string, column string, values []any) error
 	BulkUpsert(table string, data any, conflictColumns []string, opts ...BulkOption) error
 
 	// Bulk Update
@@ -155,6 +156,7 @@ func (b *bulkBuilder) BulkInsert(table string, data any, opts ...BulkOption) err
 		// Log query if logger is set
 		var start time.Time
 		if b.logger != nil {
+			logQueryStart(b.logger, b.ctx, query, values)
 			start = time.Now()
 		}
 
@@ -164,7 +166,7 @@ func (b *bulkBuilder) BulkInsert(table string, data any, opts ...BulkOption) err
 		// Log query execution
 		if b.logger != nil {
 			duration := time.Since(start)
-			b.logger.LogQuery(b.ctx, query, values, duration, err)
+			logQuery(b.logger, b.ctx, query, values, duration, err)
 		}
 
 		if err != nil && !config.IgnoreErrors {
@@ -234,6 +236,7 @@ func (b *bulkBuilder) BulkUpdate(table string, data any, opts ...BulkOption) err
 			// Log query if logger is set
 			var start time.Time
 			if b.logger != nil {
+				logQueryStart(b.logger, b.ctx, query, values)
 				start = time.Now()
 			}
 
@@ -243,7 +246,7 @@ func (b *bulkBuilder) BulkUpdate(table string, data any, opts ...BulkOption) err
 			// Log query execution
 			if b.logger != nil {
 				duration := time.Since(start)
-				b.logger.LogQuery(b.ctx, query, values, duration, err)
+				logQuery(b.logger, b.ctx, query, values, duration, err)
 			}
 
 			if err != nil && !config.IgnoreErrors {
@@ -263,7 +266,28 @@ func (b *bulkBuilder) BulkDelete(table string, conditions []map[string]any) erro
 
 	// Generate SQL
 	query, args := b.dialect.BulkDelete(table, conditions)
+	return b.execDelete(query, args)
+}
+
+// BulkDeleteBySlice удаляет строки по списку значений: DELETE FROM table WHERE column IN (...)
+func (b *bulkBuilder) BulkDeleteBySlice(table string, column string, values []any) error {
+	if len(values) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, len(values))
+	for i := range values {
+		placeholders[i] = b.dialect.PlaceholderFormat()
+	}
+	query := fmt.Sprintf("DELETE FROM %s WHERE %s IN (%s)",
+		b.dialect.QuoteIdentifier(table),
+		b.dialect.QuoteIdentifier(column),
+		strings.Join(placeholders, ", "))
+
+	return b.execDelete(b.dialect.Rebind(query), values)
+}
 
+func (b *bulkBuilder) execDelete(query string, args []any) error {
 	// Print SQL if logger is set or printSQL is true
 	if b.logger != nil {
 		// Simple placeholder replacement for debugging
@@ -277,6 +301,7 @@ func (b *bulkBuilder) BulkDelete(table string, conditions []map[string]any) erro
 	// Log query if logger is set
 	var start time.Time
 	if b.logger != nil {
+		logQueryStart(b.logger, b.ctx, query, args)
 		start = time.Now()
 	}
 
@@ -286,7 +311,7 @@ func (b *bulkBuilder) BulkDelete(table string, conditions []map[string]any) erro
 	// Log query execution
 	if b.logger != nil {
 		duration := time.Since(start)
-		b.logger.LogQuery(b.ctx, query, args, duration, err)
+		logQuery(b.logger, b.ctx, query, args, duration, err)
 	}
 
 	return err
@@ -368,6 +393,7 @@ func (b *bulkBuilder) BulkUpsert(table string, data any, conflictColumns []strin
 		// Log query if logger is set
 		var start time.Time
 		if b.logger != nil {
+			logQueryStart(b.logger, b.ctx, query, values)
 			start = time.Now()
 		}
 
@@ -377,7 +403,7 @@ func (b *bulkBuilder) BulkUpsert(table string, data any, conflictColumns []strin
 		// Log query execution
 		if b.logger != nil {
 			duration := time.Since(start)
-			b.logger.LogQuery(b.ctx, query, values, duration, err)
+			logQuery(b.logger, b.ctx, query, values, duration, err)
 		}
 
 		if err != nil && !config.IgnoreErrors {
@@ -450,6 +476,7 @@ func (b *bulkBuilder) BulkUpdateByKey(table string, data any, keyColumn string)
 	// Log query if logger is set
 	var start time.Time
 	if b.logger != nil {
+		logQueryStart(b.logger, b.ctx, query, values)
 		start = time.Now()
 	}
 
@@ -459,7 +486,7 @@ func (b *bulkBuilder) BulkUpdateByKey(table string, data any, keyColumn string)
 	// Log query execution
 	if b.logger != nil {
 		duration := time.Since(start)
-		b.logger.LogQuery(b.ctx, query, values, duration, err)
+		logQuery(b.logger, b.ctx, query, values, duration, err)
 	}
 
 	return err
@@ -718,10 +745,10 @@ func (b *bulkBuilder) generateBulkInsertSQL(table string, columns []string, rowC
 		allPlaceholders = append(allPlaceholders, rowPlaceholder)
 	}
 
-	return fmt.Sprintf("INSERT INTO %s (%s) VALUES %s",
+	return b.dialect.Rebind(fmt.Sprintf("INSERT INTO %s (%s) VALUES %s",
 		b.dialect.QuoteIdentifier(table),
 		strings.Join(quotedColumns, ", "),
-		strings.Join(allPlaceholders, ", "))
+		strings.Join(allPlaceholders, ", ")))
 }
 
 func (b *bulkBuilder) generateSingleUpdateSQL(table string, columns []string) string {
@@ -736,15 +763,15 @@ func (b *bulkBuilder) generateSingleUpdateSQL(table string, columns []string) st
 		setParts = append(setParts, fmt.Sprintf("%s = %s", col, b.dialect.PlaceholderFormat()))
 	}
 
-	return fmt.Sprintf("UPDATE %s SET %s",
+	return b.dialect.Rebind(fmt.Sprintf("UPDATE %s SET %s",
 		b.dialect.QuoteIdentifier(table),
-		strings.Join(setParts, ", "))
+		strings.Join(setParts, ", ")))
 }
 
 func (b *bulkBuilder) generateBulkUpsertSQL(table string, columns []string, conflictColumns []string, rowCount int) string {
 	insertSQL := b.generateBulkInsertSQL(table, columns, rowCount)
 
-	// For MySQL, we'll use ON DUPLICATE KEY UPDATE
+	// ON DUPLICATE KEY UPDATE (MySQL) или ON CONFLICT ... DO UPDATE (PostgreSQL)
 	var updates []string
 	for _, col := range columns {
 		isConflictColumn := false
@@ -755,13 +782,12 @@ func (b *bulkBuilder) generateBulkUpsertSQL(table string, columns []string, conf
 			}
 		}
 		if !isConflictColumn {
-			quotedCol := b.dialect.QuoteIdentifier(col)
-			updates = append(updates, fmt.Sprintf("%s = VALUES(%s)", quotedCol, quotedCol))
+			updates = append(updates, col)
 		}
 	}
 
 	if len(updates) > 0 {
-		insertSQL = fmt.Sprintf("%s ON DUPLICATE KEY UPDATE %s", insertSQL, strings.Join(updates, ", "))
+		insertSQL = fmt.Sprintf("%s %s", insertSQL, b.dialect.InsertOnConflict(conflictColumns, updates, nil))
 	}
 
 	return insertSQL
@@ -784,10 +810,10 @@ func (b *bulkBuilder) generateBulkUpdateByKeySQL(table string, columns []string,
 	// Create WHERE clause for key
 	whereClause := fmt.Sprintf("%s = %s", quotedKeyColumn, b.dialect.PlaceholderFormat())
 
-	return fmt.Sprintf("UPDATE %s SET %s WHERE %s",
+	return b.dialect.Rebind(fmt.Sprintf("UPDATE %s SET %s WHERE %s",
 		b.dialect.QuoteIdentifier(table),
 		strings.Join(setParts, ", "),
-		whereClause)
+		whereClause))
 }
 
 // BulkOption functions
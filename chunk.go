@@ -0,0 +1,69 @@
+package querycraft
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ChunkById обходит результат порциями по size строк, используя условие
+// idColumn > последний id вместо OFFSET, поэтому стоимость запроса не растет
+// с номером порции. Существующая сортировка билдера заменяется на idColumn
+func (s *selectBuilder) ChunkById(size int, idColumn string, fn func([]map[string]any) error) error {
+	if size <= 0 {
+		return errors.New("chunk size must be greater than 0")
+	}
+	if idColumn == "" {
+		return errors.New("chunk id column is required")
+	}
+	// ORDER BY после UNION относится ко всему результату, а WHERE - только к первому запросу
+	if len(s.setOps) > 0 {
+		return fmt.Errorf("ChunkById: cannot order set operation by %s", idColumn)
+	}
+
+	field := resultColumnName(idColumn)
+	var lastID any
+
+	for {
+		chunk := s.Clone().(*selectBuilder)
+		chunk.logger = s.logger
+		chunk.orders = nil
+		chunk.offset = nil
+		if lastID != nil {
+			// Условия билдера берутся в скобки, иначе OrWhere обойдет условие по id:
+			// a = ? OR b = ? AND id > ? вместо (a = ? OR b = ?) AND id > ?
+			if len(chunk.wheres) > 0 {
+				chunk.wheres = []string{joinMergeConditions(chunk.wheres)}
+			}
+			chunk.Where(idColumn, ">", lastID)
+		}
+		chunk.OrderBy(idColumn)
+		// Лишняя строка показывает, есть ли следующая порция
+		chunk.Limit(size + 1)
+
+		rows, err := chunk.Rows()
+		if err != nil {
+			return err
+		}
+
+		hasMore := len(rows) > size
+		if hasMore {
+			rows = rows[:size]
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+
+		if err := fn(rows); err != nil {
+			return err
+		}
+		if !hasMore {
+			return nil
+		}
+
+		id, ok := rows[len(rows)-1][field]
+		if !ok || id == nil {
+			return fmt.Errorf("ChunkById: column %s not found in result", idColumn)
+		}
+		lastID = id
+	}
+}
@@ -3,12 +3,14 @@ package querycraft
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"regexp"
 	"strings"
 	"time"
 
 	"github.com/antibomberman/querycraft/dialect"
+	"github.com/jmoiron/sqlx"
 )
 
 type DeleteBuilder interface {
@@ -16,22 +18,63 @@ type DeleteBuilder interface {
 	Where(column, operator string, value any) DeleteBuilder
 	WhereEq(column string, value any) DeleteBuilder
 	WhereIn(column string, values ...any) DeleteBuilder
+	WhereNotIn(column string, values ...any) DeleteBuilder
+	WhereNull(column ...string) DeleteBuilder
+	WhereNotNull(column ...string) DeleteBuilder
+	WhereBetween(column string, from, to any) DeleteBuilder
+	WhereNotBetween(column string, from, to any) DeleteBuilder
 	WhereRaw(condition string, args ...any) DeleteBuilder
+	WhereLike(column, pattern string) DeleteBuilder
+	WhereNotLike(column, pattern string) DeleteBuilder
+	WhereILike(column, pattern string) DeleteBuilder
+	OrWhereLike(column, pattern string) DeleteBuilder
+	WhereColumn(first, operator, second string) DeleteBuilder
+	OrWhereColumn(first, operator, second string) DeleteBuilder
+
+	// OR WHERE условия
+	OrWhere(column, operator string, value any) DeleteBuilder
+	OrWhereEq(column string, value any) DeleteBuilder
+	OrWhereIn(column string, values ...any) DeleteBuilder
+	OrWhereNull(column ...string) DeleteBuilder
+	OrWhereRaw(condition string, args ...any) DeleteBuilder
+
+	// WHERE группировка
+	WhereGroup(fn func(DeleteBuilder) DeleteBuilder) DeleteBuilder
+	OrWhereGroup(fn func(DeleteBuilder) DeleteBuilder) DeleteBuilder
+
+	// Условное добавление WHERE
+	When(condition bool, column, operator string, value any) DeleteBuilder
+	WhenFunc(condition bool, fn func(DeleteBuilder) DeleteBuilder) DeleteBuilder
 
 	// JOIN операции
 	Join(table, condition string) DeleteBuilder
+	InnerJoin(table, condition string) DeleteBuilder
+	LeftJoin(table, condition string) DeleteBuilder
+	RightJoin(table, condition string) DeleteBuilder
+	CrossJoin(table string) DeleteBuilder
+	Using(table, condition string) DeleteBuilder
 
 	// Ограничения
 	Limit(limit int) DeleteBuilder
 	OrderBy(column string) DeleteBuilder
 
+	// Архивирование удаляемых строк
+	WithReturningIntoTable(archiveTable string, columns ...string) DeleteBuilder
+
+	// RETURNING (PostgreSQL), в MySQL не добавляется
+	Returning(columns ...string) DeleteBuilder
+
 	// Выполнение
 	Exec() (sql.Result, error)
+	ExecRowsAffected() (int64, error)
+	ExecArchive() (int64, error)
+	ExecReturning(dest any) error
 
 	// Утилиты
 	WithContext(ctx context.Context) DeleteBuilder
 	ToSQL() (string, []any)
 	PrintSQL() DeleteBuilder
+	PrettyPrint() DeleteBuilder
 	Clone() DeleteBuilder
 }
 
@@ -47,9 +90,19 @@ type deleteBuilder struct {
 	whereArgs []any
 	orders    []string
 	limit     *int
+	returning []string
+
+	// DELETE ... USING
+	usingTable     string
+	usingCondition string
+
+	// Таблица-архив для ExecArchive
+	archiveTable   string
+	archiveColumns []string
 
 	// Print SQL flag
-	printSQL bool
+	printSQL  bool
+	prettySQL bool
 }
 
 func NewDeleteBuilder(db SQLXExecutor, dialect dialect.Dialect, table string) DeleteBuilder {
@@ -81,12 +134,177 @@ func (d *deleteBuilder) WhereIn(column string, values ...any) DeleteBuilder {
 	return d
 }
 
+func (d *deleteBuilder) WhereNotIn(column string, values ...any) DeleteBuilder {
+	placeholders := make([]string, len(values))
+	for i := range values {
+		placeholders[i] = d.dialect.PlaceholderFormat()
+	}
+	d.wheres = append(d.wheres, fmt.Sprintf("%s NOT IN (%s)", d.dialect.QuoteIdentifier(column), strings.Join(placeholders, ", ")))
+	d.whereArgs = append(d.whereArgs, values...)
+	return d
+}
+
+func (d *deleteBuilder) WhereNull(columns ...string) DeleteBuilder {
+	for _, column := range columns {
+		d.wheres = append(d.wheres, fmt.Sprintf("%s IS NULL", d.dialect.QuoteIdentifier(column)))
+	}
+	return d
+}
+
+func (d *deleteBuilder) WhereNotNull(columns ...string) DeleteBuilder {
+	for _, column := range columns {
+		d.wheres = append(d.wheres, fmt.Sprintf("%s IS NOT NULL", d.dialect.QuoteIdentifier(column)))
+	}
+	return d
+}
+
+func (d *deleteBuilder) WhereBetween(column string, from, to any) DeleteBuilder {
+	d.wheres = append(d.wheres, fmt.Sprintf("%s BETWEEN %s AND %s",
+		d.dialect.QuoteIdentifier(column),
+		d.dialect.PlaceholderFormat(),
+		d.dialect.PlaceholderFormat()))
+	d.whereArgs = append(d.whereArgs, from, to)
+	return d
+}
+
+func (d *deleteBuilder) WhereNotBetween(column string, from, to any) DeleteBuilder {
+	d.wheres = append(d.wheres, fmt.Sprintf("%s NOT BETWEEN %s AND %s",
+		d.dialect.QuoteIdentifier(column),
+		d.dialect.PlaceholderFormat(),
+		d.dialect.PlaceholderFormat()))
+	d.whereArgs = append(d.whereArgs, from, to)
+	return d
+}
+
 func (d *deleteBuilder) WhereRaw(condition string, args ...any) DeleteBuilder {
 	d.wheres = append(d.wheres, condition)
 	d.whereArgs = append(d.whereArgs, args...)
 	return d
 }
 
+func (d *deleteBuilder) OrWhere(column, operator string, value any) DeleteBuilder {
+	d.wheres = append(d.wheres, fmt.Sprintf("OR %s %s %s", d.dialect.QuoteIdentifier(column), operator, d.dialect.PlaceholderFormat()))
+	d.whereArgs = append(d.whereArgs, value)
+	return d
+}
+
+func (d *deleteBuilder) OrWhereEq(column string, value any) DeleteBuilder {
+	return d.OrWhere(column, "=", value)
+}
+
+func (d *deleteBuilder) OrWhereIn(column string, values ...any) DeleteBuilder {
+	placeholders := make([]string, len(values))
+	for i := range values {
+		placeholders[i] = d.dialect.PlaceholderFormat()
+	}
+	d.wheres = append(d.wheres, fmt.Sprintf("OR %s IN (%s)", d.dialect.QuoteIdentifier(column), strings.Join(placeholders, ", ")))
+	d.whereArgs = append(d.whereArgs, values...)
+	return d
+}
+
+func (d *deleteBuilder) OrWhereNull(columns ...string) DeleteBuilder {
+	for _, column := range columns {
+		d.wheres = append(d.wheres, fmt.Sprintf("OR %s IS NULL", d.dialect.QuoteIdentifier(column)))
+	}
+	return d
+}
+
+func (d *deleteBuilder) OrWhereRaw(condition string, args ...any) DeleteBuilder {
+	d.wheres = append(d.wheres, "OR "+condition)
+	d.whereArgs = append(d.whereArgs, args...)
+	return d
+}
+
+// When добавляет условие WHERE только если condition == true
+func (d *deleteBuilder) When(condition bool, column, operator string, value any) DeleteBuilder {
+	if condition {
+		return d.Where(column, operator, value)
+	}
+	return d
+}
+
+func (d *deleteBuilder) WhenFunc(condition bool, fn func(DeleteBuilder) DeleteBuilder) DeleteBuilder {
+	if condition {
+		return fn(d)
+	}
+	return d
+}
+
+func (d *deleteBuilder) WhereLike(column, pattern string) DeleteBuilder {
+	return d.Where(column, "LIKE", pattern)
+}
+
+func (d *deleteBuilder) WhereNotLike(column, pattern string) DeleteBuilder {
+	return d.Where(column, "NOT LIKE", pattern)
+}
+
+// WhereILike - регистронезависимый LIKE (ILIKE в PostgreSQL)
+func (d *deleteBuilder) WhereILike(column, pattern string) DeleteBuilder {
+	return d.Where(column, d.dialect.ILikeOperator(), pattern)
+}
+
+func (d *deleteBuilder) OrWhereLike(column, pattern string) DeleteBuilder {
+	return d.OrWhere(column, "LIKE", pattern)
+}
+
+// WhereColumn сравнивает две колонки, например `users`.`id` = `orders`.`user_id`
+func (d *deleteBuilder) WhereColumn(first, operator, second string) DeleteBuilder {
+	d.wheres = append(d.wheres, fmt.Sprintf("%s %s %s", d.dialect.QuoteIdentifier(first), operator, d.dialect.QuoteIdentifier(second)))
+	return d
+}
+
+func (d *deleteBuilder) OrWhereColumn(first, operator, second string) DeleteBuilder {
+	d.wheres = append(d.wheres, fmt.Sprintf("OR %s %s %s", d.dialect.QuoteIdentifier(first), operator, d.dialect.QuoteIdentifier(second)))
+	return d
+}
+
+func (d *deleteBuilder) WhereGroup(fn func(DeleteBuilder) DeleteBuilder) DeleteBuilder {
+	return d.whereGroup(fn, "AND")
+}
+
+func (d *deleteBuilder) OrWhereGroup(fn func(DeleteBuilder) DeleteBuilder) DeleteBuilder {
+	return d.whereGroup(fn, "OR")
+}
+
+// whereGroup собирает условия fn во временном билдере и добавляет их в скобках
+func (d *deleteBuilder) whereGroup(fn func(DeleteBuilder) DeleteBuilder, prefix string) DeleteBuilder {
+	// Временный билдер без таблицы, лимита и сортировки - только для WHERE
+	groupBuilder := &deleteBuilder{
+		db:        d.db,
+		dialect:   d.dialect,
+		ctx:       d.ctx,
+		wheres:    make([]string, 0),
+		whereArgs: make([]any, 0),
+	}
+
+	builder := fn(groupBuilder)
+
+	if db, ok := builder.(*deleteBuilder); ok && len(db.wheres) > 0 {
+		// Объединяем условия с правильными AND между ними
+		var whereParts []string
+		for i, where := range db.wheres {
+			if i == 0 {
+				// Первое условие группы может прийти из OrWhere*, префикс внутри скобок не нужен
+				whereParts = append(whereParts, strings.TrimPrefix(strings.TrimPrefix(where, "AND "), "OR "))
+			} else if strings.HasPrefix(where, "AND ") || strings.HasPrefix(where, "OR ") || strings.HasPrefix(where, "(") {
+				whereParts = append(whereParts, where)
+			} else {
+				whereParts = append(whereParts, "AND "+where)
+			}
+		}
+
+		// Префикс AND/OR только если у нас уже есть условия
+		if len(d.wheres) > 0 {
+			d.wheres = append(d.wheres, fmt.Sprintf("%s (%s)", prefix, strings.Join(whereParts, " ")))
+		} else {
+			d.wheres = append(d.wheres, fmt.Sprintf("(%s)", strings.Join(whereParts, " ")))
+		}
+		d.whereArgs = append(d.whereArgs, db.whereArgs...)
+	}
+
+	return d
+}
+
 func (d *deleteBuilder) quoteTableNameWithAlias(tableName string) string {
 	re := regexp.MustCompile(`(?i)^(.+?)\s+(as\s+)?(.+?)$`)
 	matches := re.FindStringSubmatch(tableName)
@@ -116,26 +334,73 @@ func (d *deleteBuilder) Join(table, condition string) DeleteBuilder {
 	return d
 }
 
+func (d *deleteBuilder) InnerJoin(table, condition string) DeleteBuilder {
+	d.joins = append(d.joins, fmt.Sprintf("INNER JOIN %s ON %s", d.quoteTableNameWithAlias(table), d.quoteJoinCondition(condition)))
+	return d
+}
+
+func (d *deleteBuilder) LeftJoin(table, condition string) DeleteBuilder {
+	d.joins = append(d.joins, fmt.Sprintf("LEFT JOIN %s ON %s", d.quoteTableNameWithAlias(table), d.quoteJoinCondition(condition)))
+	return d
+}
+
+func (d *deleteBuilder) RightJoin(table, condition string) DeleteBuilder {
+	d.joins = append(d.joins, fmt.Sprintf("RIGHT JOIN %s ON %s", d.quoteTableNameWithAlias(table), d.quoteJoinCondition(condition)))
+	return d
+}
+
+func (d *deleteBuilder) CrossJoin(table string) DeleteBuilder {
+	d.joins = append(d.joins, fmt.Sprintf("CROSS JOIN %s", d.quoteTableNameWithAlias(table)))
+	return d
+}
+
+// Using удаляет строки основной таблицы, связанные с table по condition.
+// PostgreSQL: DELETE FROM t1 USING t2 WHERE condition, MySQL: DELETE t1 FROM t1 JOIN t2.
+// Не сочетается с Join: в PostgreSQL JOIN после USING ... WHERE недопустим
+func (d *deleteBuilder) Using(table, condition string) DeleteBuilder {
+	d.usingTable = table
+	d.usingCondition = condition
+	return d
+}
+
 func (d *deleteBuilder) Limit(limit int) DeleteBuilder {
 	d.limit = &limit
 	return d
 }
 
 func (d *deleteBuilder) OrderBy(column string) DeleteBuilder {
-	d.orders = append(d.orders, d.dialect.SelectOrderBy(column, false))
+	d.orders = append(d.orders, orderExpression(d.dialect.SelectOrderBy(column, false)))
 	return d
 }
 
 func (d *deleteBuilder) buildSQL() (string, []any) {
+	query, args := d.buildDeleteSQL()
+	if returning := returningClause(d.dialect, d.returning); returning != "" {
+		query += " " + returning
+	}
+	return query, args
+}
+
+// buildDeleteSQL строит DELETE без RETURNING, используется и архивированием
+func (d *deleteBuilder) buildDeleteSQL() (string, []any) {
 	var queryParts []string
 	var args []any
 
 	// DELETE
-	queryParts = append(queryParts, "DELETE FROM", d.dialect.QuoteIdentifier(d.table))
-
-	// JOIN
-	if len(d.joins) > 0 {
-		queryParts = append(queryParts, strings.Join(d.joins, " "))
+	usingWhere := false
+	if d.usingTable != "" {
+		head := d.dialect.DeleteUsing(
+			d.dialect.QuoteIdentifier(d.table),
+			d.quoteTableNameWithAlias(d.usingTable),
+			d.quoteJoinCondition(d.usingCondition))
+		// Диалект может перенести условие USING в WHERE
+		usingWhere = strings.Contains(strings.ToUpper(head), " WHERE ")
+		queryParts = append(queryParts, head)
+	} else if len(d.joins) > 0 {
+		// JOIN возможен только в многотабличном DELETE t FROM t JOIN ... (MySQL)
+		queryParts = append(queryParts, d.dialect.DeleteJoin(d.dialect.QuoteIdentifier(d.table)), strings.Join(d.joins, " "))
+	} else {
+		queryParts = append(queryParts, "DELETE FROM", d.dialect.QuoteIdentifier(d.table))
 	}
 
 	// WHERE
@@ -155,21 +420,28 @@ func (d *deleteBuilder) buildSQL() (string, []any) {
 		whereClause := strings.Join(whereParts, " ")
 		whereClause = strings.TrimPrefix(whereClause, "AND ")
 		whereClause = strings.TrimPrefix(whereClause, "OR ")
-		queryParts = append(queryParts, "WHERE", whereClause)
+		if usingWhere {
+			queryParts = append(queryParts, "AND", fmt.Sprintf("(%s)", whereClause))
+		} else {
+			queryParts = append(queryParts, "WHERE", whereClause)
+		}
 		args = append(args, d.whereArgs...)
 	}
 
 	// ORDER BY
 	if len(d.orders) > 0 {
-		queryParts = append(queryParts, strings.Join(d.orders, " "))
+		queryParts = append(queryParts, "ORDER BY "+strings.Join(d.orders, ", "))
 	}
 
-	// LIMIT
+	// LIMIT (PostgreSQL не поддерживает, диалект возвращает пустую строку, а Exec - ErrNotSupported)
 	if d.limit != nil {
-		queryParts = append(queryParts, d.dialect.DeleteLimit(*d.limit))
+		if limitClause := d.dialect.DeleteLimit(*d.limit); limitClause != "" {
+			queryParts = append(queryParts, limitClause)
+		}
 	}
 
-	return strings.Join(queryParts, " "), args
+	// Плейсхолдеры в формате драйвера ($1, $2... для PostgreSQL)
+	return d.dialect.Rebind(strings.Join(queryParts, " ")), args
 }
 
 func (d *deleteBuilder) ToSQL() (string, []any) {
@@ -181,40 +453,237 @@ func (d *deleteBuilder) PrintSQL() DeleteBuilder {
 	return d
 }
 
+// PrettyPrint печатает SQL в многострочном виде (см. FormatSQL)
+func (d *deleteBuilder) PrettyPrint() DeleteBuilder {
+	d.printSQL = true
+	d.prettySQL = true
+	return d
+}
+
 func (d *deleteBuilder) setLogger(logger Logger) {
 	d.logger = logger
 }
 
+// errUsingWithJoin - Using и Join нельзя совместить в одном DELETE
+var errUsingWithJoin = errors.New("delete: Using cannot be combined with Join")
+
+// validate проверяет сочетания, для которых нельзя построить корректный DELETE
+func (d *deleteBuilder) validate() error {
+	if d.usingTable != "" && len(d.joins) > 0 {
+		return errUsingWithJoin
+	}
+	if len(d.joins) > 0 && d.dialect.DeleteJoin(d.table) == "" {
+		return fmt.Errorf("DELETE with Join: %w", ErrNotSupported)
+	}
+	// Многотабличный DELETE в MySQL не допускает ORDER BY и LIMIT
+	if (len(d.joins) > 0 || d.usingTable != "") && (d.limit != nil || len(d.orders) > 0) {
+		return fmt.Errorf("DELETE with Join/Using and Limit/OrderBy: %w", ErrNotSupported)
+	}
+	// Без DELETE ... LIMIT (PostgreSQL) лимит нельзя пропустить: удалились бы все подходящие строки
+	if (d.limit != nil || len(d.orders) > 0) && d.dialect.DeleteLimit(1) == "" {
+		return fmt.Errorf("DELETE with Limit/OrderBy: %w", ErrNotSupported)
+	}
+	return nil
+}
+
 func (d *deleteBuilder) Exec() (sql.Result, error) {
+	if err := d.validate(); err != nil {
+		return nil, err
+	}
 	sql, args := d.buildSQL()
+	return d.exec(d.db, sql, args)
+}
 
+// exec выполняет запрос через db с печатью и логированием
+func (d *deleteBuilder) exec(db SQLXExecutor, query string, args []any) (sql.Result, error) {
 	// Print SQL if needed
 	if d.printSQL {
 		// Simple placeholder replacement for debugging
-		formattedSQL := sql
+		formattedSQL := query
 		for _, arg := range args {
 			formattedSQL = strings.Replace(formattedSQL, d.dialect.PlaceholderFormat(), fmt.Sprintf("'%v'", arg), 1)
 		}
+		if d.prettySQL {
+			formattedSQL = FormatSQL(formattedSQL)
+		}
 		fmt.Println(formattedSQL)
 	}
 
 	// Log query if logger is set
 	var start time.Time
 	if d.logger != nil {
+		logQueryStart(d.logger, d.ctx, query, args)
 		start = time.Now()
 	}
 
-	result, err := d.db.ExecContext(d.ctx, sql, args...)
+	result, err := db.ExecContext(d.ctx, query, args...)
 
 	// Log query execution
 	if d.logger != nil {
 		duration := time.Since(start)
-		d.logger.LogQuery(d.ctx, sql, args, duration, err)
+		logQuery(d.logger, d.ctx, query, args, duration, err)
 	}
 
 	return result, err
 }
 
+// Returning задает колонки RETURNING, "*" - все колонки
+func (d *deleteBuilder) Returning(columns ...string) DeleteBuilder {
+	d.returning = columns
+	return d
+}
+
+// ExecReturning выполняет DELETE ... RETURNING и сканирует удаленные строки в dest
+func (d *deleteBuilder) ExecReturning(dest any) error {
+	if err := d.validate(); err != nil {
+		return err
+	}
+	if len(d.returning) == 0 {
+		d.returning = []string{"*"}
+	}
+	sql, args := d.buildSQL()
+	return queryReturning(d.ctx, d.db, d.logger, d.dialect, dest, sql, args)
+}
+
+// ExecRowsAffected выполняет запрос и возвращает количество затронутых строк
+func (d *deleteBuilder) ExecRowsAffected() (int64, error) {
+	result, err := d.Exec()
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+// WithReturningIntoTable копирует удаляемые строки в archiveTable при ExecArchive.
+// columns - колонки, общие для обеих таблиц; без них копируются все колонки
+func (d *deleteBuilder) WithReturningIntoTable(archiveTable string, columns ...string) DeleteBuilder {
+	d.archiveTable = archiveTable
+	d.archiveColumns = columns
+	return d
+}
+
+// ExecArchive удаляет строки и переносит их в таблицу-архив, возвращает
+// количество заархивированных строк. PostgreSQL: WITH deleted AS (DELETE ...
+// RETURNING *) INSERT INTO archive SELECT ... FROM deleted. Без RETURNING (MySQL):
+// SELECT ... FOR UPDATE, INSERT INTO archive SELECT ... и DELETE в одной транзакции.
+// Нужен *sqlx.DB (транзакция открывается здесь) или *sqlx.Tx; с Limit обязателен
+// OrderBy, иначе INSERT и DELETE могут выбрать разные строки
+func (d *deleteBuilder) ExecArchive() (int64, error) {
+	if d.archiveTable == "" {
+		return 0, fmt.Errorf("archive table is not set, use WithReturningIntoTable")
+	}
+	if err := d.validate(); err != nil {
+		return 0, err
+	}
+
+	if d.dialect.SupportsReturning() {
+		query, args := d.buildArchiveCTESQL()
+		result, err := d.exec(d.db, query, args)
+		if err != nil {
+			return 0, err
+		}
+		return result.RowsAffected()
+	}
+
+	if d.limit != nil && len(d.orders) == 0 {
+		return 0, errors.New("ExecArchive with Limit requires OrderBy")
+	}
+
+	var pool *sqlx.DB
+	switch db := d.db.(type) {
+	case *sqlx.Tx:
+		// Билдер уже работает внутри транзакции, откат при ошибке - за вызывающим
+		return d.execArchive(db)
+	case *sqlx.DB:
+		pool = db
+	default:
+		return 0, fmt.Errorf("ExecArchive cannot open a transaction on %T", d.db)
+	}
+
+	tx, err := pool.BeginTxx(d.ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	count, err := d.execArchive(tx)
+	if err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (d *deleteBuilder) execArchive(db SQLXExecutor) (int64, error) {
+	deleteSQL, args := d.buildDeleteSQL()
+
+	// INSERT ... SELECT с теми же FROM/JOIN/WHERE/LIMIT, что и DELETE;
+	// MySQL с Using начинается с DELETE t1 FROM, поэтому берем все после первого FROM
+	source := deleteSQL[strings.Index(deleteSQL, " FROM ")+len(" FROM "):]
+	insertSQL := fmt.Sprintf("INSERT INTO %s SELECT %s FROM %s", d.archiveTarget(), d.archiveSelectColumns(d.table), source)
+
+	// Блокируем удаляемые строки, чтобы их не изменили между INSERT и DELETE
+	if lock := d.dialect.SelectLock(dialect.LockForUpdate); lock != "" {
+		if _, err := d.exec(db, fmt.Sprintf("SELECT 1 FROM %s %s", source, lock), args); err != nil {
+			return 0, err
+		}
+	}
+
+	result, err := d.exec(db, insertSQL, args)
+	if err != nil {
+		return 0, err
+	}
+	archived, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	result, err = d.exec(db, deleteSQL, args)
+	if err != nil {
+		return 0, err
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	// Строка, вставленная между INSERT и DELETE, была бы удалена без архива
+	if deleted != archived {
+		return 0, fmt.Errorf("ExecArchive: archived %d rows but deleted %d", archived, deleted)
+	}
+	return archived, nil
+}
+
+func (d *deleteBuilder) buildArchiveCTESQL() (string, []any) {
+	deleteSQL, args := d.buildDeleteSQL()
+	query := fmt.Sprintf("WITH %s AS (%s RETURNING %s.*) INSERT INTO %s SELECT %s FROM %s",
+		d.dialect.QuoteIdentifier("deleted"),
+		deleteSQL,
+		d.dialect.QuoteIdentifier(d.table),
+		d.archiveTarget(),
+		d.archiveSelectColumns("deleted"),
+		d.dialect.QuoteIdentifier("deleted"))
+	return d.dialect.Rebind(query), args
+}
+
+// archiveTarget - archive или archive (col1, col2)
+func (d *deleteBuilder) archiveTarget() string {
+	target := d.dialect.QuoteIdentifier(d.archiveTable)
+	if len(d.archiveColumns) > 0 {
+		target += fmt.Sprintf(" (%s)", strings.Join(quoteIdentifiers(d.dialect, d.archiveColumns), ", "))
+	}
+	return target
+}
+
+// archiveSelectColumns - выбранные колонки или все колонки источника (source.*)
+func (d *deleteBuilder) archiveSelectColumns(source string) string {
+	if len(d.archiveColumns) > 0 {
+		return strings.Join(quoteIdentifiers(d.dialect, d.archiveColumns), ", ")
+	}
+	return d.dialect.QuoteIdentifier(source) + ".*"
+}
+
 func (d *deleteBuilder) WithContext(ctx context.Context) DeleteBuilder {
 	d.ctx = ctx
 	return d
@@ -231,15 +700,22 @@ func (d *deleteBuilder) Clone() DeleteBuilder {
 		wheres:  make([]string, len(d.wheres)),
 		orders:  make([]string, len(d.orders)),
 		limit:   d.limit,
+
+		usingTable:     d.usingTable,
+		usingCondition: d.usingCondition,
+
+		archiveTable:   d.archiveTable,
+		archiveColumns: d.archiveColumns,
 	}
 
 	copy(clone.joins, d.joins)
 	copy(clone.wheres, d.wheres)
 	copy(clone.orders, d.orders)
+	clone.returning = append([]string(nil), d.returning...)
 
 	// Copy args slices
 	clone.whereArgs = make([]any, len(d.whereArgs))
 	copy(clone.whereArgs, d.whereArgs)
 
 	return clone
-}
\ No newline at end of file
+}
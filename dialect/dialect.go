@@ -1,5 +1,54 @@
 package dialect
 
+import (
+	"sort"
+	"strings"
+)
+
+// CTEOption controls materialization of a common table expression
+type CTEOption int
+
+const (
+	CTEDefault CTEOption = iota
+	CTEMaterialize
+	CTENotMaterialize
+)
+
+// Kinds of APPLY joins for FormatApply
+const (
+	ApplyCross = "CROSS"
+	ApplyOuter = "OUTER"
+)
+
+// Row locking modes for SelectLock
+const (
+	LockForUpdate = "FOR UPDATE"
+	LockForShare  = "FOR SHARE"
+)
+
+// Kinds of table index hints for IndexHint
+const (
+	IndexHintUse    = "USE"
+	IndexHintForce  = "FORCE"
+	IndexHintIgnore = "IGNORE"
+)
+
+// Output formats for ExplainPrefix, empty string means the server default
+const (
+	ExplainFormatJSON = "JSON"
+	ExplainFormatTree = "TREE"
+)
+
+// Date/time parts for DatePart
+const (
+	DatePartDate  = "DATE"
+	DatePartTime  = "TIME"
+	DatePartYear  = "YEAR"
+	DatePartMonth = "MONTH"
+	DatePartDay   = "DAY"
+	DatePartHour  = "HOUR"
+)
+
 // Dialect interface defines methods for generating SQL for different databases
 type Dialect interface {
 	// Placeholders
@@ -10,6 +59,31 @@ type Dialect interface {
 	SelectLimit(limit int) string
 	SelectOffset(offset int) string
 	SelectOrderBy(column string, desc bool) string
+	FormatCTE(name string, sql string, opt CTEOption) string
+	// SelectLock renders a Lock* mode, empty string if row locks are not supported
+	SelectLock(mode string) string
+	// IndexHint renders an IndexHint* hint placed after the table name, empty string if unsupported
+	IndexHint(kind string, indexes []string) string
+	// PartitionSelection renders explicit partition selection placed right after
+	// the table name, empty string if unsupported
+	PartitionSelection(partitions []string) string
+	// ExplainPrefix returns the EXPLAIN keyword sequence placed before the query
+	ExplainPrefix(analyze bool, format string) string
+	// SupportOptimizerHints reports whether /*+ ... */ hints after SELECT are emitted
+	SupportOptimizerHints() bool
+	// SupportsIntersectExcept reports whether INTERSECT / EXCEPT [ALL] are available
+	SupportsIntersectExcept() bool
+	// SupportsDistinctOn reports whether SELECT DISTINCT ON (...) is available
+	SupportsDistinctOn() bool
+	// ILikeOperator returns the case-insensitive LIKE operator
+	ILikeOperator() string
+	// FormatApply renders CROSS / OUTER APPLY of a correlated subquery,
+	// empty string means the dialect has no equivalent
+	FormatApply(kind string, subSQL, alias string) string
+	// InlineValues renders rows as a derived table usable in FROM / JOIN
+	InlineValues(rows []map[string]any, alias string) (string, []any)
+	// DatePart extracts a DatePart* component from an already quoted column
+	DatePart(part, column string) string
 
 	// INSERT
 	InsertIgnore() string
@@ -19,9 +93,28 @@ type Dialect interface {
 
 	// UPDATE
 	UpdateLimit(limit int) string
+	// JSON expressions for SET, path and value are bound as parameters
+	JsonSet(column, path string) string
+	JsonInsert(column, path string) string
+	JsonReplace(column, path string) string
+	JsonRemove(column, path string) string
+	// JSON predicates for WHERE, return the expression and its args in placeholder order;
+	// value is a JSON document, an empty path means the whole column
+	JsonContains(column, path, value string) (string, []any)
+	JsonExtract(column, path string) (string, []any)
 
 	// DELETE
 	DeleteLimit(limit int) string
+	DeleteUsing(mainTable, usingTable, condition string) string
+	// DeleteJoin returns the head of a multi-table DELETE that JOIN clauses can follow,
+	// empty if the dialect has no such form
+	DeleteJoin(mainTable string) string
+
+	// MERGE
+	SupportsMerge() bool
+
+	// RETURNING, including data-modifying statements inside WITH
+	SupportsReturning() bool
 
 	// UPSERT
 	Upsert(columns []string, values []any, conflictColumns []string, updateColumns []string) (string, []any)
@@ -33,12 +126,72 @@ type Dialect interface {
 
 	// SCHEMA
 	HasTableQuery(name string) string
+	HasViewQuery(name string) string
+	// CreateViewSQL returns the statements that create the view, orReplace replaces an existing one
+	CreateViewSQL(name, query string, orReplace bool) []string
 	HasColumnQuery(table, column string) string
 	HasIndexQuery(table, index string) string
 	GetTablesQuery() string
 	GetColumnsQuery(table string) string
 	GetIndexesQuery(table string) string
+	// GetConstraintsQuery returns one row per constraint column ordered by constraint name and position:
+	// name, type (PRIMARY KEY, UNIQUE, FOREIGN KEY, CHECK), column, referenced table, referenced column, check clause
+	GetConstraintsQuery(table string) string
+	// GetForeignKeysQuery returns one row per foreign key column ordered by constraint name and position:
+	// name, column, referenced table, referenced column, on delete rule, on update rule
+	GetForeignKeysQuery(table string) string
 	GetIDColumnType() string
+	// CreateIndexSQL builds a standalone CREATE INDEX, method is BTREE/HASH/GIN/GIST ("" for default),
+	// where is a partial index condition (only when SupportsPartialIndex)
+	CreateIndexSQL(table, name string, columns []string, unique bool, method, where string) string
+	DropIndexSQL(table, name string) string
+	// InlineIndex renders a KEY/UNIQUE clause for CREATE TABLE, "" when the index
+	// is created by a separate CREATE INDEX
+	InlineIndex(name string, columns []string, unique bool) string
+	SupportsPartialIndex() bool
+	// CheckConstraint returns CONSTRAINT name CHECK (expression) for CREATE TABLE,
+	// AddCheck and DropCheck the ALTER TABLE parts; each returns "" if unsupported
+	CheckConstraint(name, expression string) string
+	AddCheck(name, expression string) string
+	DropCheck(name string) string
+	// SupportsDDLTransactions reports whether CREATE/ALTER/DROP can be rolled back
+	SupportsDDLTransactions() bool
+	// CreateTablePrefix returns CREATE TABLE [IF NOT EXISTS]
+	CreateTablePrefix(ifNotExists bool) string
+	// ColumnType maps a MySQL type name (SMALLINT, TINYINT, MEDIUMINT, FLOAT, DOUBLE, CHAR, BINARY, BLOB, UUID)
+	// to the native type, length 0 means no length
+	ColumnType(name string, length int) string
+	// EnumColumnType returns the type for ENUM (set=false) or SET (set=true) columns,
+	// values are already quoted string literals
+	EnumColumnType(column string, values []string, set bool) string
+	// GeneratedColumn returns the GENERATED ALWAYS AS clause, stored=false asks for a VIRTUAL column
+	GeneratedColumn(expression string, stored bool) string
+	// RenameColumnSQL returns a standalone statement, PostgreSQL cannot combine RENAME with other ALTER actions
+	RenameColumnSQL(table, from, to string) string
+	// ModifyColumn returns ALTER TABLE parts that change an existing column,
+	// dataType "" keeps the type, modifiers are NULL, NOT NULL, DEFAULT ... etc.
+	// ok is false when the dialect cannot express the change
+	ModifyColumn(column, dataType string, modifiers []string) (parts []string, ok bool)
+	// Comments: inline clauses for CREATE/ALTER TABLE (MySQL) or a separate
+	// COMMENT ON statement (PostgreSQL), column "" comments the table; "" if unsupported
+	InlineColumnComment(comment string) string
+	InlineTableComment(comment string) string
+	CommentOnSQL(table, column, comment string) string
+	DropTableSQL(name string, cascade bool) string
+	// ForeignKeyChecksSQL toggles FK checks for the session, "" if the dialect uses CASCADE instead
+	ForeignKeyChecksSQL(enabled bool) string
+	DependentTablesQuery(table string) string
+	// Spatial types: geomType is POINT, LINESTRING, POLYGON or GEOMETRY, srid 0 means no SRID
+	SpatialColumnType(geomType string, srid int) string
+	// SpatialIndex renders the inline CREATE/ALTER TABLE clause, CreateSpatialIndexSQL a standalone
+	// statement run after the table; each returns "" when the dialect uses the other form
+	SpatialIndex(name string, columns []string) string
+	CreateSpatialIndexSQL(table, name string, columns []string) string
+	TableSizeQuery(table string) string
+	DatabaseSizeQuery() string
+	// IndexSizeQuery returns a single row with the index size in bytes
+	IndexSizeQuery(table, index string) string
+	TopTablesBySizeQuery(limit int) string
 
 	// QUOTES
 	QuoteIdentifier(name string) string
@@ -46,3 +199,34 @@ type Dialect interface {
 	// TABLE OPERATIONS
 	TruncateTableSQL(table string) string
 }
+
+// quoteIdentifiers quotes each name with the dialect's identifier quotes
+func quoteIdentifiers(d Dialect, names []string) []string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = d.QuoteIdentifier(name)
+	}
+	return quoted
+}
+
+// quoteString returns a single-quoted SQL string literal
+func quoteString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// inlineValuesColumns returns the sorted union of row keys so that
+// InlineValues produces the same SQL regardless of map iteration order
+func inlineValuesColumns(rows []map[string]any) []string {
+	seen := make(map[string]bool)
+	var columns []string
+	for _, row := range rows {
+		for column := range row {
+			if !seen[column] {
+				seen[column] = true
+				columns = append(columns, column)
+			}
+		}
+	}
+	sort.Strings(columns)
+	return columns
+}
@@ -3,11 +3,16 @@ package dialect
 import (
 	"fmt"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 )
 
-type MySQLDialect struct{}
+type MySQLDialect struct {
+	// LegacyChecks marks servers before 8.0.16 that parse CHECK but never enforce it,
+	// check constraints are then rejected instead of being silently ignored
+	LegacyChecks bool
+}
 
 func (d *MySQLDialect) PlaceholderFormat() string {
 	return "?"
@@ -32,6 +37,16 @@ func (d *MySQLDialect) SelectOrderBy(column string, desc bool) string {
 	return fmt.Sprintf("ORDER BY %s", d.QuoteIdentifier(column))
 }
 
+// FormatCTE - MySQL has no MATERIALIZED, the option is ignored
+func (d *MySQLDialect) FormatCTE(name string, sql string, opt CTEOption) string {
+	return fmt.Sprintf("%s AS (%s)", d.QuoteIdentifier(name), sql)
+}
+
+// SupportOptimizerHints - MySQL 8+ optimizer hints
+func (d *MySQLDialect) SupportOptimizerHints() bool {
+	return true
+}
+
 func (d *MySQLDialect) InsertIgnore() string {
 	return "INSERT IGNORE"
 }
@@ -67,10 +82,145 @@ func (d *MySQLDialect) UpdateLimit(limit int) string {
 	return fmt.Sprintf("LIMIT %d", limit)
 }
 
+func (d *MySQLDialect) JsonSet(column, path string) string {
+	return fmt.Sprintf("JSON_SET(%s, ?, ?)", column)
+}
+
+func (d *MySQLDialect) JsonInsert(column, path string) string {
+	return fmt.Sprintf("JSON_INSERT(%s, ?, ?)", column)
+}
+
+func (d *MySQLDialect) JsonReplace(column, path string) string {
+	return fmt.Sprintf("JSON_REPLACE(%s, ?, ?)", column)
+}
+
+func (d *MySQLDialect) JsonRemove(column, path string) string {
+	return fmt.Sprintf("JSON_REMOVE(%s, ?)", column)
+}
+
+func (d *MySQLDialect) JsonContains(column, path, value string) (string, []any) {
+	if path == "" {
+		return fmt.Sprintf("JSON_CONTAINS(%s, ?)", column), []any{value}
+	}
+	return fmt.Sprintf("JSON_CONTAINS(%s, ?, ?)", column), []any{value, path}
+}
+
+func (d *MySQLDialect) JsonExtract(column, path string) (string, []any) {
+	return fmt.Sprintf("JSON_EXTRACT(%s, ?)", column), []any{path}
+}
+
+// SupportsIntersectExcept - INTERSECT and EXCEPT appeared only in MySQL 8.0.31,
+// the builder treats them as unavailable
+func (d *MySQLDialect) SupportsIntersectExcept() bool {
+	return false
+}
+
+// SupportsDistinctOn - MySQL has no DISTINCT ON, use GROUP BY or ROW_NUMBER() instead
+func (d *MySQLDialect) SupportsDistinctOn() bool {
+	return false
+}
+
+// ILikeOperator - LIKE is case-insensitive under the default collations
+func (d *MySQLDialect) ILikeOperator() string {
+	return "LIKE"
+}
+
+// FormatApply - no APPLY support; use a derived table JOIN or window functions
+// (ROW_NUMBER() OVER (PARTITION BY ...)) for "top N per group" queries
+func (d *MySQLDialect) FormatApply(kind string, subSQL, alias string) string {
+	return ""
+}
+
+// IndexHint - USE INDEX (`idx`), FORCE INDEX (...), IGNORE INDEX (...)
+func (d *MySQLDialect) IndexHint(kind string, indexes []string) string {
+	quoted := make([]string, len(indexes))
+	for i, index := range indexes {
+		quoted[i] = d.QuoteIdentifier(index)
+	}
+	return fmt.Sprintf("%s INDEX (%s)", kind, strings.Join(quoted, ", "))
+}
+
+// PartitionSelection - PARTITION (`p1`, `p2`)
+func (d *MySQLDialect) PartitionSelection(partitions []string) string {
+	quoted := make([]string, len(partitions))
+	for i, partition := range partitions {
+		quoted[i] = d.QuoteIdentifier(partition)
+	}
+	return fmt.Sprintf("PARTITION (%s)", strings.Join(quoted, ", "))
+}
+
+// ExplainPrefix - EXPLAIN [ANALYZE] [FORMAT=...], ANALYZE defaults to FORMAT=JSON (MySQL 8+)
+func (d *MySQLDialect) ExplainPrefix(analyze bool, format string) string {
+	prefix := "EXPLAIN"
+	if analyze {
+		prefix += " ANALYZE"
+		if format == "" {
+			format = ExplainFormatJSON
+		}
+	}
+	if format != "" {
+		prefix += " FORMAT=" + format
+	}
+	return prefix
+}
+
+// DatePart - DATE(col), YEAR(col), HOUR(col) ...
+func (d *MySQLDialect) DatePart(part, column string) string {
+	return fmt.Sprintf("%s(%s)", part, column)
+}
+
+// InlineValues - MySQL 8 VALUES ROW() cannot name columns, so rows are built
+// as SELECT ? AS col ... UNION ALL SELECT ?, ... Missing keys become NULL
+func (d *MySQLDialect) InlineValues(rows []map[string]any, alias string) (string, []any) {
+	columns := inlineValuesColumns(rows)
+	var selects []string
+	var args []any
+	for i, row := range rows {
+		fields := make([]string, len(columns))
+		for j, column := range columns {
+			fields[j] = "?"
+			if i == 0 {
+				fields[j] += " AS " + d.QuoteIdentifier(column)
+			}
+			args = append(args, row[column])
+		}
+		selects = append(selects, "SELECT "+strings.Join(fields, ", "))
+	}
+	return fmt.Sprintf("(%s) AS %s", strings.Join(selects, " UNION ALL "), d.QuoteIdentifier(alias)), args
+}
+
+// SelectLock - shared locks use the LOCK IN SHARE MODE syntax understood by 5.7 and 8.0
+func (d *MySQLDialect) SelectLock(mode string) string {
+	if mode == LockForShare {
+		return "LOCK IN SHARE MODE"
+	}
+	return mode
+}
+
+// SupportsMerge - MySQL has no MERGE, use Upsert (ON DUPLICATE KEY UPDATE) instead
+func (d *MySQLDialect) SupportsMerge() bool {
+	return false
+}
+
+// SupportsReturning - MySQL has no RETURNING
+func (d *MySQLDialect) SupportsReturning() bool {
+	return false
+}
+
 func (d *MySQLDialect) DeleteLimit(limit int) string {
 	return fmt.Sprintf("LIMIT %d", limit)
 }
 
+// DeleteUsing - MySQL expresses it as a multi-table DELETE t1 FROM t1 JOIN t2 ON ...
+func (d *MySQLDialect) DeleteUsing(mainTable, usingTable, condition string) string {
+	return fmt.Sprintf("DELETE %s FROM %s JOIN %s ON %s", mainTable, mainTable, usingTable, condition)
+}
+
+// DeleteJoin - multi-table DELETE t FROM t, the JOIN clauses follow it
+func (d *MySQLDialect) DeleteJoin(mainTable string) string {
+	return fmt.Sprintf("DELETE %s FROM %s", mainTable, mainTable)
+}
+
 func (d *MySQLDialect) Upsert(columns []string, values []any, conflictColumns []string, updateColumns []string) (string, []any) {
 	// For MySQL, this is implemented as INSERT ... ON DUPLICATE KEY UPDATE
 	// This will be handled in the UpsertBuilder implementation
@@ -97,10 +247,17 @@ func (d *MySQLDialect) BulkDelete(table string, conditions []map[string]any) (st
 	var args []any
 
 	for _, condition := range conditions {
+		// Sort the columns so the SQL does not depend on map iteration order
+		columns := make([]string, 0, len(condition))
+		for column := range condition {
+			columns = append(columns, column)
+		}
+		sort.Strings(columns)
+
 		var parts []string
-		for column, value := range condition {
+		for _, column := range columns {
 			parts = append(parts, fmt.Sprintf("%s = %s", d.QuoteIdentifier(column), d.PlaceholderFormat()))
-			args = append(args, value)
+			args = append(args, condition[column])
 		}
 		whereClauses = append(whereClauses, fmt.Sprintf("(%s)", strings.Join(parts, " AND ")))
 	}
@@ -154,6 +311,17 @@ func (d *MySQLDialect) HasTableQuery(name string) string {
 	return fmt.Sprintf("SELECT COUNT(*) > 0 FROM information_schema.tables WHERE table_schema = DATABASE() AND table_name = '%s'", name)
 }
 
+func (d *MySQLDialect) HasViewQuery(name string) string {
+	return fmt.Sprintf("SELECT COUNT(*) > 0 FROM information_schema.views WHERE table_schema = DATABASE() AND table_name = '%s'", name)
+}
+
+func (d *MySQLDialect) CreateViewSQL(name, query string, orReplace bool) []string {
+	if orReplace {
+		return []string{fmt.Sprintf("CREATE OR REPLACE VIEW %s AS %s", d.QuoteIdentifier(name), query)}
+	}
+	return []string{fmt.Sprintf("CREATE VIEW %s AS %s", d.QuoteIdentifier(name), query)}
+}
+
 func (d *MySQLDialect) HasColumnQuery(table, column string) string {
 	return fmt.Sprintf("SELECT COUNT(*) > 0 FROM information_schema.columns WHERE table_schema = DATABASE() AND table_name = '%s' AND column_name = '%s'", table, column)
 }
@@ -174,6 +342,203 @@ func (d *MySQLDialect) GetIndexesQuery(table string) string {
 	return fmt.Sprintf("SELECT index_name as Name, GROUP_CONCAT(column_name) as Columns FROM information_schema.statistics WHERE table_schema = DATABASE() AND table_name = '%s' GROUP BY index_name", table)
 }
 
+func (d *MySQLDialect) GetConstraintsQuery(table string) string {
+	return fmt.Sprintf("SELECT tc.constraint_name, tc.constraint_type, kcu.column_name, kcu.referenced_table_name, kcu.referenced_column_name, cc.check_clause "+
+		"FROM information_schema.table_constraints tc "+
+		"LEFT JOIN information_schema.key_column_usage kcu ON kcu.constraint_schema = tc.constraint_schema AND kcu.constraint_name = tc.constraint_name AND kcu.table_name = tc.table_name "+
+		"LEFT JOIN information_schema.check_constraints cc ON cc.constraint_schema = tc.constraint_schema AND cc.constraint_name = tc.constraint_name "+
+		"WHERE tc.table_schema = DATABASE() AND tc.table_name = '%s' "+
+		"ORDER BY tc.constraint_name, kcu.ordinal_position", table)
+}
+
+func (d *MySQLDialect) GetForeignKeysQuery(table string) string {
+	return fmt.Sprintf("SELECT kcu.constraint_name, kcu.column_name, kcu.referenced_table_name, kcu.referenced_column_name, rc.delete_rule, rc.update_rule "+
+		"FROM information_schema.key_column_usage kcu "+
+		"JOIN information_schema.referential_constraints rc ON rc.constraint_schema = kcu.constraint_schema AND rc.constraint_name = kcu.constraint_name AND rc.table_name = kcu.table_name "+
+		"WHERE kcu.table_schema = DATABASE() AND kcu.table_name = '%s' AND kcu.referenced_table_name IS NOT NULL "+
+		"ORDER BY kcu.constraint_name, kcu.ordinal_position", table)
+}
+
 func (d *MySQLDialect) GetIDColumnType() string {
 	return "BIGINT UNSIGNED"
-}
\ No newline at end of file
+}
+
+// ColumnType - MySQL has no native UUID type, UUIDs are stored as CHAR(36)
+func (d *MySQLDialect) ColumnType(name string, length int) string {
+	if name == "UUID" {
+		return "CHAR(36)"
+	}
+	if length > 0 {
+		return fmt.Sprintf("%s(%d)", name, length)
+	}
+	return name
+}
+
+func (d *MySQLDialect) GeneratedColumn(expression string, stored bool) string {
+	if stored {
+		return fmt.Sprintf("GENERATED ALWAYS AS (%s) STORED", expression)
+	}
+	return fmt.Sprintf("GENERATED ALWAYS AS (%s) VIRTUAL", expression)
+}
+
+// RenameColumnSQL - RENAME COLUMN needs MySQL 8.0+, older servers only have
+// CHANGE COLUMN which requires the full column definition
+func (d *MySQLDialect) RenameColumnSQL(table, from, to string) string {
+	return fmt.Sprintf("ALTER TABLE %s RENAME COLUMN %s TO %s", d.QuoteIdentifier(table), d.QuoteIdentifier(from), d.QuoteIdentifier(to))
+}
+
+// ModifyColumn - MODIFY COLUMN redefines the whole column, so dataType is required
+func (d *MySQLDialect) ModifyColumn(column, dataType string, modifiers []string) ([]string, bool) {
+	if dataType == "" {
+		return nil, false
+	}
+	def := "MODIFY COLUMN " + d.QuoteIdentifier(column) + " " + dataType
+	if len(modifiers) > 0 {
+		def += " " + strings.Join(modifiers, " ")
+	}
+	return []string{def}, true
+}
+
+func (d *MySQLDialect) InlineColumnComment(comment string) string {
+	return "COMMENT " + quoteString(comment)
+}
+
+func (d *MySQLDialect) InlineTableComment(comment string) string {
+	return "COMMENT = " + quoteString(comment)
+}
+
+func (d *MySQLDialect) CommentOnSQL(table, column, comment string) string {
+	return ""
+}
+
+func (d *MySQLDialect) EnumColumnType(column string, values []string, set bool) string {
+	if set {
+		return fmt.Sprintf("SET(%s)", strings.Join(values, ", "))
+	}
+	return fmt.Sprintf("ENUM(%s)", strings.Join(values, ", "))
+}
+
+// CreateIndexSQL - MySQL has no partial indexes, where is ignored
+func (d *MySQLDialect) CreateIndexSQL(table, name string, columns []string, unique bool, method, where string) string {
+	query := "CREATE INDEX "
+	if unique {
+		query = "CREATE UNIQUE INDEX "
+	}
+	query += fmt.Sprintf("%s ON %s (%s)", d.QuoteIdentifier(name), d.QuoteIdentifier(table), strings.Join(quoteIdentifiers(d, columns), ", "))
+	if method != "" {
+		query += " USING " + method
+	}
+	return query
+}
+
+func (d *MySQLDialect) DropIndexSQL(table, name string) string {
+	return fmt.Sprintf("ALTER TABLE %s DROP INDEX %s", d.QuoteIdentifier(table), d.QuoteIdentifier(name))
+}
+
+// InlineIndex - MySQL declares both unique and plain indexes inside CREATE TABLE
+func (d *MySQLDialect) InlineIndex(name string, columns []string, unique bool) string {
+	keyword := "KEY"
+	if unique {
+		keyword = "UNIQUE KEY"
+	}
+	return fmt.Sprintf("%s %s (%s)", keyword, d.QuoteIdentifier(name), strings.Join(quoteIdentifiers(d, columns), ", "))
+}
+
+// SupportsPartialIndex - MySQL has no partial indexes
+func (d *MySQLDialect) SupportsPartialIndex() bool {
+	return false
+}
+
+// CheckConstraint - enforced since MySQL 8.0.16, older servers parse and ignore CHECK
+func (d *MySQLDialect) CheckConstraint(name, expression string) string {
+	if d.LegacyChecks {
+		return ""
+	}
+	return fmt.Sprintf("CONSTRAINT %s CHECK (%s)", d.QuoteIdentifier(name), expression)
+}
+
+func (d *MySQLDialect) AddCheck(name, expression string) string {
+	if d.LegacyChecks {
+		return ""
+	}
+	return "ADD " + d.CheckConstraint(name, expression)
+}
+
+func (d *MySQLDialect) DropCheck(name string) string {
+	if d.LegacyChecks {
+		return ""
+	}
+	return "DROP CHECK " + d.QuoteIdentifier(name)
+}
+
+// SupportsDDLTransactions - DDL in MySQL causes an implicit commit
+func (d *MySQLDialect) SupportsDDLTransactions() bool {
+	return false
+}
+
+func (d *MySQLDialect) CreateTablePrefix(ifNotExists bool) string {
+	if ifNotExists {
+		return "CREATE TABLE IF NOT EXISTS"
+	}
+	return "CREATE TABLE"
+}
+
+// DropTableSQL - MySQL ignores CASCADE/RESTRICT, cascading drop is done with FOREIGN_KEY_CHECKS = 0
+func (d *MySQLDialect) DropTableSQL(name string, cascade bool) string {
+	if cascade {
+		return fmt.Sprintf("DROP TABLE %s", d.QuoteIdentifier(name))
+	}
+	return fmt.Sprintf("DROP TABLE %s RESTRICT", d.QuoteIdentifier(name))
+}
+
+func (d *MySQLDialect) ForeignKeyChecksSQL(enabled bool) string {
+	if enabled {
+		return "SET FOREIGN_KEY_CHECKS = 1"
+	}
+	return "SET FOREIGN_KEY_CHECKS = 0"
+}
+
+func (d *MySQLDialect) DependentTablesQuery(table string) string {
+	return fmt.Sprintf("SELECT DISTINCT table_name FROM information_schema.KEY_COLUMN_USAGE WHERE referenced_table_schema = DATABASE() AND referenced_table_name = %s AND table_name <> %s", quoteString(table), quoteString(table))
+}
+
+func (d *MySQLDialect) SpatialColumnType(geomType string, srid int) string {
+	if srid > 0 {
+		return fmt.Sprintf("%s SRID %d", geomType, srid)
+	}
+	return geomType
+}
+
+func (d *MySQLDialect) SpatialIndex(name string, columns []string) string {
+	quoted := make([]string, len(columns))
+	for i, col := range columns {
+		quoted[i] = d.QuoteIdentifier(col)
+	}
+	return fmt.Sprintf("SPATIAL INDEX %s (%s)", d.QuoteIdentifier(name), strings.Join(quoted, ", "))
+}
+
+// CreateSpatialIndexSQL - spatial indexes are declared inline, see SpatialIndex
+func (d *MySQLDialect) CreateSpatialIndexSQL(table, name string, columns []string) string {
+	return ""
+}
+
+// mysqlTableSizeColumns - columns name, data_bytes, index_bytes, total_bytes, row_count
+const mysqlTableSizeColumns = "table_name AS name, COALESCE(data_length, 0) AS data_bytes, COALESCE(index_length, 0) AS index_bytes, COALESCE(data_length, 0) + COALESCE(index_length, 0) AS total_bytes, COALESCE(table_rows, 0) AS row_count"
+
+func (d *MySQLDialect) TableSizeQuery(table string) string {
+	return fmt.Sprintf("SELECT %s FROM information_schema.TABLES WHERE table_schema = DATABASE() AND table_name = '%s'", mysqlTableSizeColumns, table)
+}
+
+func (d *MySQLDialect) DatabaseSizeQuery() string {
+	return "SELECT COALESCE(SUM(data_length + index_length), 0) FROM information_schema.TABLES WHERE table_schema = DATABASE()"
+}
+
+// IndexSizeQuery - InnoDB keeps index size in pages in mysql.innodb_index_stats
+func (d *MySQLDialect) IndexSizeQuery(table, index string) string {
+	return fmt.Sprintf("SELECT COALESCE(SUM(stat_value), 0) * @@innodb_page_size FROM mysql.innodb_index_stats "+
+		"WHERE database_name = DATABASE() AND table_name = '%s' AND index_name = '%s' AND stat_name = 'size'", table, index)
+}
+
+func (d *MySQLDialect) TopTablesBySizeQuery(limit int) string {
+	return fmt.Sprintf("SELECT %s FROM information_schema.TABLES WHERE table_schema = DATABASE() ORDER BY total_bytes DESC LIMIT %d", mysqlTableSizeColumns, limit)
+}
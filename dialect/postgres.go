@@ -0,0 +1,625 @@
+package dialect
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// PostgresDialect generates SQL for PostgreSQL (drivers postgres, pgx, pq).
+// Builders write ? bindvars, Rebind renumbers them to $1..$N before execution
+type PostgresDialect struct{}
+
+func (d *PostgresDialect) PlaceholderFormat() string {
+	return "?"
+}
+
+// Rebind renumbers every ? and $N bindvar in order of appearance to $1..$N,
+// so SQL of nested subqueries can be rebound again as part of the outer query.
+// String literals and quoted identifiers are left untouched
+func (d *PostgresDialect) Rebind(sql string) string {
+	var b strings.Builder
+	b.Grow(len(sql) + 8)
+
+	n := 0
+	for i := 0; i < len(sql); i++ {
+		c := sql[i]
+		switch {
+		case c == '\'' || c == '"':
+			// Copy quoted section as is, '' and "" are escapes
+			j := i + 1
+			for j < len(sql) {
+				if sql[j] == c {
+					if j+1 < len(sql) && sql[j+1] == c {
+						j += 2
+						continue
+					}
+					break
+				}
+				j++
+			}
+			if j >= len(sql) {
+				j = len(sql) - 1
+			}
+			b.WriteString(sql[i : j+1])
+			i = j
+		case c == '?':
+			n++
+			b.WriteString("$" + strconv.Itoa(n))
+		case c == '$' && i+1 < len(sql) && sql[i+1] >= '0' && sql[i+1] <= '9':
+			j := i + 1
+			for j < len(sql) && sql[j] >= '0' && sql[j] <= '9' {
+				j++
+			}
+			n++
+			b.WriteString("$" + strconv.Itoa(n))
+			i = j - 1
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	return b.String()
+}
+
+func (d *PostgresDialect) SelectLimit(limit int) string {
+	return fmt.Sprintf("LIMIT %d", limit)
+}
+
+func (d *PostgresDialect) SelectOffset(offset int) string {
+	return fmt.Sprintf("OFFSET %d", offset)
+}
+
+func (d *PostgresDialect) SelectOrderBy(column string, desc bool) string {
+	if desc {
+		return fmt.Sprintf("ORDER BY %s DESC", d.QuoteIdentifier(column))
+	}
+	return fmt.Sprintf("ORDER BY %s", d.QuoteIdentifier(column))
+}
+
+// FormatCTE supports [NOT] MATERIALIZED (PostgreSQL 12+)
+func (d *PostgresDialect) FormatCTE(name string, sql string, opt CTEOption) string {
+	switch opt {
+	case CTEMaterialize:
+		return fmt.Sprintf("%s AS MATERIALIZED (%s)", d.QuoteIdentifier(name), sql)
+	case CTENotMaterialize:
+		return fmt.Sprintf("%s AS NOT MATERIALIZED (%s)", d.QuoteIdentifier(name), sql)
+	}
+	return fmt.Sprintf("%s AS (%s)", d.QuoteIdentifier(name), sql)
+}
+
+// SupportOptimizerHints - PostgreSQL has no built-in optimizer hints
+func (d *PostgresDialect) SupportOptimizerHints() bool {
+	return false
+}
+
+// InsertIgnore - no INSERT IGNORE, the builder appends ON CONFLICT DO NOTHING instead
+func (d *PostgresDialect) InsertIgnore() string {
+	return "INSERT INTO"
+}
+
+// InsertReplace - no REPLACE, use InsertOnConflict with DO UPDATE
+func (d *PostgresDialect) InsertReplace() string {
+	return "INSERT INTO"
+}
+
+// InsertOnConflict renders ON CONFLICT (cols) DO UPDATE SET col = EXCLUDED.col.
+// Without columns to update it falls back to DO NOTHING
+func (d *PostgresDialect) InsertOnConflict(columns []string, updateColumns []string, updateExcluded []string) string {
+	target := ""
+	if len(columns) > 0 {
+		quoted := make([]string, len(columns))
+		for i, col := range columns {
+			quoted[i] = d.QuoteIdentifier(col)
+		}
+		target = fmt.Sprintf(" (%s)", strings.Join(quoted, ", "))
+	}
+
+	var updates []string
+	for _, col := range append(append([]string(nil), updateColumns...), updateExcluded...) {
+		updates = append(updates, fmt.Sprintf("%s = EXCLUDED.%s", d.QuoteIdentifier(col), d.QuoteIdentifier(col)))
+	}
+	if len(updates) == 0 {
+		return fmt.Sprintf("ON CONFLICT%s DO NOTHING", target)
+	}
+
+	return fmt.Sprintf("ON CONFLICT%s DO UPDATE SET %s", target, strings.Join(updates, ", "))
+}
+
+func (d *PostgresDialect) InsertOnConflictDoNothing() string {
+	return "ON CONFLICT DO NOTHING"
+}
+
+// UpdateLimit - PostgreSQL has no UPDATE ... LIMIT
+func (d *PostgresDialect) UpdateLimit(limit int) string {
+	return ""
+}
+
+// JSON functions work with jsonb, path is a text[] literal like '{a,b}'
+func (d *PostgresDialect) JsonSet(column, path string) string {
+	return fmt.Sprintf("jsonb_set(%s, ?::text[], ?::jsonb)", column)
+}
+
+func (d *PostgresDialect) JsonInsert(column, path string) string {
+	return fmt.Sprintf("jsonb_insert(%s, ?::text[], ?::jsonb)", column)
+}
+
+func (d *PostgresDialect) JsonReplace(column, path string) string {
+	return fmt.Sprintf("jsonb_set(%s, ?::text[], ?::jsonb, false)", column)
+}
+
+func (d *PostgresDialect) JsonRemove(column, path string) string {
+	return fmt.Sprintf("%s #- ?::text[]", column)
+}
+
+// JsonContains uses jsonb containment, path is a text array literal like {a,b}
+func (d *PostgresDialect) JsonContains(column, path, value string) (string, []any) {
+	if path == "" {
+		return fmt.Sprintf("%s @> ?::jsonb", column), []any{value}
+	}
+	return fmt.Sprintf("%s #> ?::text[] @> ?::jsonb", column), []any{path, value}
+}
+
+// JsonExtract returns the value at path as text
+func (d *PostgresDialect) JsonExtract(column, path string) (string, []any) {
+	return fmt.Sprintf("%s #>> ?::text[]", column), []any{path}
+}
+
+// SupportsIntersectExcept - INTERSECT / EXCEPT including the ALL variants
+func (d *PostgresDialect) SupportsIntersectExcept() bool {
+	return true
+}
+
+func (d *PostgresDialect) SupportsDistinctOn() bool {
+	return true
+}
+
+func (d *PostgresDialect) ILikeOperator() string {
+	return "ILIKE"
+}
+
+// FormatApply maps CROSS APPLY to JOIN LATERAL and OUTER APPLY to LEFT JOIN LATERAL
+func (d *PostgresDialect) FormatApply(kind string, subSQL, alias string) string {
+	join := "JOIN LATERAL"
+	if kind == ApplyOuter {
+		join = "LEFT JOIN LATERAL"
+	}
+	return fmt.Sprintf("%s (%s) AS %s ON TRUE", join, subSQL, d.QuoteIdentifier(alias))
+}
+
+// IndexHint - the planner has no index hints, the call is a no-op
+func (d *PostgresDialect) IndexHint(kind string, indexes []string) string {
+	return ""
+}
+
+// PartitionSelection - partitions are separate tables, query them by name instead
+func (d *PostgresDialect) PartitionSelection(partitions []string) string {
+	return ""
+}
+
+// ExplainPrefix - EXPLAIN ANALYZE or the option list form EXPLAIN (ANALYZE, FORMAT JSON)
+func (d *PostgresDialect) ExplainPrefix(analyze bool, format string) string {
+	if format == "" {
+		if analyze {
+			return "EXPLAIN ANALYZE"
+		}
+		return "EXPLAIN"
+	}
+	var options []string
+	if analyze {
+		options = append(options, "ANALYZE")
+	}
+	options = append(options, "FORMAT "+format)
+	return fmt.Sprintf("EXPLAIN (%s)", strings.Join(options, ", "))
+}
+
+// DatePart casts to date / time and uses EXTRACT for numeric parts
+func (d *PostgresDialect) DatePart(part, column string) string {
+	switch part {
+	case DatePartDate:
+		return column + "::date"
+	case DatePartTime:
+		return column + "::time"
+	}
+	return fmt.Sprintf("EXTRACT(%s FROM %s)", part, column)
+}
+
+// InlineValues renders (VALUES (?, ?), ...) AS "alias"("col", ...). Missing keys become NULL
+func (d *PostgresDialect) InlineValues(rows []map[string]any, alias string) (string, []any) {
+	columns := inlineValuesColumns(rows)
+	quoted := make([]string, len(columns))
+	for i, column := range columns {
+		quoted[i] = d.QuoteIdentifier(column)
+	}
+
+	var values []string
+	var args []any
+	for _, row := range rows {
+		placeholders := make([]string, len(columns))
+		for i, column := range columns {
+			placeholders[i] = "?"
+			args = append(args, row[column])
+		}
+		values = append(values, "("+strings.Join(placeholders, ", ")+")")
+	}
+	return fmt.Sprintf("(VALUES %s) AS %s(%s)", strings.Join(values, ", "), d.QuoteIdentifier(alias), strings.Join(quoted, ", ")), args
+}
+
+// SelectLock - FOR UPDATE and FOR SHARE are native, the mode is used as is
+func (d *PostgresDialect) SelectLock(mode string) string {
+	return mode
+}
+
+// SupportsMerge - MERGE is available since PostgreSQL 15
+func (d *PostgresDialect) SupportsMerge() bool {
+	return true
+}
+
+func (d *PostgresDialect) SupportsReturning() bool {
+	return true
+}
+
+// DeleteLimit - PostgreSQL has no DELETE ... LIMIT
+func (d *PostgresDialect) DeleteLimit(limit int) string {
+	return ""
+}
+
+func (d *PostgresDialect) DeleteUsing(mainTable, usingTable, condition string) string {
+	return fmt.Sprintf("DELETE FROM %s USING %s WHERE %s", mainTable, usingTable, condition)
+}
+
+// DeleteJoin - PostgreSQL only joins through DELETE ... USING
+func (d *PostgresDialect) DeleteJoin(mainTable string) string {
+	return ""
+}
+
+func (d *PostgresDialect) Upsert(columns []string, values []any, conflictColumns []string, updateColumns []string) (string, []any) {
+	// This will be handled in the UpsertBuilder implementation
+	return "", nil
+}
+
+func (d *PostgresDialect) BulkInsert(table string, columns []string, values []any, batchSize int) (string, []any) {
+	// This will be handled in the BulkBuilder implementation
+	return "", nil
+}
+
+func (d *PostgresDialect) BulkUpdate(table string, columns []string, values []any, keyColumn string) (string, []any) {
+	// This will be handled in the BulkBuilder implementation
+	return "", nil
+}
+
+func (d *PostgresDialect) BulkDelete(table string, conditions []map[string]any) (string, []any) {
+	if len(conditions) == 0 {
+		return "", nil
+	}
+
+	var whereClauses []string
+	var args []any
+
+	for _, condition := range conditions {
+		columns := make([]string, 0, len(condition))
+		for column := range condition {
+			columns = append(columns, column)
+		}
+		sort.Strings(columns)
+
+		var parts []string
+		for _, column := range columns {
+			parts = append(parts, fmt.Sprintf("%s = %s", d.QuoteIdentifier(column), d.PlaceholderFormat()))
+			args = append(args, condition[column])
+		}
+		whereClauses = append(whereClauses, fmt.Sprintf("(%s)", strings.Join(parts, " AND ")))
+	}
+
+	query := fmt.Sprintf("DELETE FROM %s WHERE %s", d.QuoteIdentifier(table), strings.Join(whereClauses, " OR "))
+	return d.Rebind(query), args
+}
+
+func (d *PostgresDialect) QuoteIdentifier(name string) string {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return ""
+	}
+
+	// Don't quote if it's a function call, asterisk, or already quoted
+	if name == "*" || strings.Contains(name, "(") || strings.Contains(name, ")") || (strings.HasPrefix(name, `"`) && strings.HasSuffix(name, `"`)) {
+		return name
+	}
+
+	// a regex to find ' as ' case-insensitively
+	re := regexp.MustCompile(`(?i)\s+as\s+`)
+	if re.MatchString(name) {
+		parts := re.Split(name, 2)
+		return d.QuoteIdentifier(parts[0]) + " AS " + d.QuoteIdentifier(parts[1])
+	}
+
+	if strings.Contains(name, ".") {
+		parts := strings.Split(name, ".")
+		quotedParts := make([]string, len(parts))
+		for i, part := range parts {
+			quotedParts[i] = d.QuoteIdentifier(part)
+		}
+		return strings.Join(quotedParts, ".")
+	}
+
+	// Don't quote if it's a number
+	if _, err := strconv.Atoi(name); err == nil {
+		return name
+	}
+
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+func (d *PostgresDialect) TruncateTableSQL(table string) string {
+	return fmt.Sprintf("TRUNCATE TABLE %s", d.QuoteIdentifier(table))
+}
+
+func (d *PostgresDialect) HasTableQuery(name string) string {
+	return fmt.Sprintf("SELECT COUNT(*) > 0 FROM information_schema.tables WHERE table_schema = current_schema() AND table_name = '%s'", name)
+}
+
+func (d *PostgresDialect) HasViewQuery(name string) string {
+	return fmt.Sprintf("SELECT COUNT(*) > 0 FROM information_schema.views WHERE table_schema = current_schema() AND table_name = '%s'", name)
+}
+
+func (d *PostgresDialect) CreateViewSQL(name, query string, orReplace bool) []string {
+	if orReplace {
+		return []string{fmt.Sprintf("CREATE OR REPLACE VIEW %s AS %s", d.QuoteIdentifier(name), query)}
+	}
+	return []string{fmt.Sprintf("CREATE VIEW %s AS %s", d.QuoteIdentifier(name), query)}
+}
+
+func (d *PostgresDialect) HasColumnQuery(table, column string) string {
+	return fmt.Sprintf("SELECT COUNT(*) > 0 FROM information_schema.columns WHERE table_schema = current_schema() AND table_name = '%s' AND column_name = '%s'", table, column)
+}
+
+func (d *PostgresDialect) HasIndexQuery(table, index string) string {
+	return fmt.Sprintf("SELECT COUNT(*) > 0 FROM pg_indexes WHERE schemaname = current_schema() AND tablename = '%s' AND indexname = '%s'", table, index)
+}
+
+func (d *PostgresDialect) GetTablesQuery() string {
+	return "SELECT table_name AS Name FROM information_schema.tables WHERE table_schema = current_schema()"
+}
+
+func (d *PostgresDialect) GetColumnsQuery(table string) string {
+	return fmt.Sprintf("SELECT column_name AS Name, data_type AS Type FROM information_schema.columns WHERE table_schema = current_schema() AND table_name = '%s'", table)
+}
+
+func (d *PostgresDialect) GetIndexesQuery(table string) string {
+	return fmt.Sprintf("SELECT i.relname AS Name, string_agg(a.attname, ',') AS Columns FROM pg_index x JOIN pg_class t ON t.oid = x.indrelid JOIN pg_class i ON i.oid = x.indexrelid JOIN pg_attribute a ON a.attrelid = t.oid AND a.attnum = ANY(x.indkey) WHERE t.relname = '%s' GROUP BY i.relname", table)
+}
+
+func (d *PostgresDialect) GetConstraintsQuery(table string) string {
+	return fmt.Sprintf("SELECT c.conname, "+
+		"CASE c.contype WHEN 'p' THEN 'PRIMARY KEY' WHEN 'u' THEN 'UNIQUE' WHEN 'f' THEN 'FOREIGN KEY' ELSE 'CHECK' END, "+
+		"a.attname, rt.relname, ra.attname, CASE WHEN c.contype = 'c' THEN pg_get_constraintdef(c.oid) END "+
+		"FROM pg_constraint c "+
+		"JOIN pg_class t ON t.oid = c.conrelid "+
+		"JOIN pg_namespace n ON n.oid = t.relnamespace "+
+		"LEFT JOIN LATERAL unnest(c.conkey, c.confkey) WITH ORDINALITY AS k(attnum, fattnum, ord) ON true "+
+		"LEFT JOIN pg_attribute a ON a.attrelid = c.conrelid AND a.attnum = k.attnum "+
+		"LEFT JOIN pg_class rt ON rt.oid = c.confrelid "+
+		"LEFT JOIN pg_attribute ra ON ra.attrelid = c.confrelid AND ra.attnum = k.fattnum "+
+		"WHERE n.nspname = current_schema() AND t.relname = '%s' AND c.contype IN ('p', 'u', 'f', 'c') "+
+		"ORDER BY c.conname, k.ord", table)
+}
+
+func (d *PostgresDialect) GetForeignKeysQuery(table string) string {
+	return fmt.Sprintf("SELECT c.conname, a.attname, rt.relname, ra.attname, "+
+		pgReferentialAction("c.confdeltype")+", "+pgReferentialAction("c.confupdtype")+" "+
+		"FROM pg_constraint c "+
+		"JOIN pg_class t ON t.oid = c.conrelid "+
+		"JOIN pg_namespace n ON n.oid = t.relnamespace "+
+		"CROSS JOIN LATERAL unnest(c.conkey, c.confkey) WITH ORDINALITY AS k(attnum, fattnum, ord) "+
+		"JOIN pg_attribute a ON a.attrelid = c.conrelid AND a.attnum = k.attnum "+
+		"JOIN pg_class rt ON rt.oid = c.confrelid "+
+		"JOIN pg_attribute ra ON ra.attrelid = c.confrelid AND ra.attnum = k.fattnum "+
+		"WHERE n.nspname = current_schema() AND t.relname = '%s' AND c.contype = 'f' "+
+		"ORDER BY c.conname, k.ord", table)
+}
+
+// pgReferentialAction decodes pg_constraint.confdeltype/confupdtype into the SQL action name
+func pgReferentialAction(column string) string {
+	return "CASE " + column + " WHEN 'c' THEN 'CASCADE' WHEN 'n' THEN 'SET NULL' WHEN 'd' THEN 'SET DEFAULT' " +
+		"WHEN 'r' THEN 'RESTRICT' ELSE 'NO ACTION' END"
+}
+
+// GetIDColumnType - BIGSERIAL creates the sequence, no AUTO_INCREMENT needed
+func (d *PostgresDialect) GetIDColumnType() string {
+	return "BIGSERIAL"
+}
+
+// ColumnType - PostgreSQL has no TINYINT/MEDIUMINT, binary data is stored in BYTEA
+func (d *PostgresDialect) ColumnType(name string, length int) string {
+	switch name {
+	case "TINYINT":
+		return "SMALLINT"
+	case "MEDIUMINT":
+		return "INTEGER"
+	case "FLOAT":
+		return "REAL"
+	case "DOUBLE":
+		return "DOUBLE PRECISION"
+	case "BINARY", "BLOB":
+		return "BYTEA"
+	}
+	if length > 0 {
+		return fmt.Sprintf("%s(%d)", name, length)
+	}
+	return name
+}
+
+// GeneratedColumn - PostgreSQL only supports STORED generated columns
+func (d *PostgresDialect) GeneratedColumn(expression string, stored bool) string {
+	return fmt.Sprintf("GENERATED ALWAYS AS (%s) STORED", expression)
+}
+
+func (d *PostgresDialect) RenameColumnSQL(table, from, to string) string {
+	return fmt.Sprintf("ALTER TABLE %s RENAME COLUMN %s TO %s", d.QuoteIdentifier(table), d.QuoteIdentifier(from), d.QuoteIdentifier(to))
+}
+
+// ModifyColumn - PostgreSQL changes type, nullability and default with separate ALTER COLUMN actions
+func (d *PostgresDialect) ModifyColumn(column, dataType string, modifiers []string) ([]string, bool) {
+	col := "ALTER COLUMN " + d.QuoteIdentifier(column)
+	var parts []string
+	if dataType != "" {
+		parts = append(parts, col+" TYPE "+dataType)
+	}
+	for _, modifier := range modifiers {
+		switch {
+		case modifier == "NOT NULL":
+			parts = append(parts, col+" SET NOT NULL")
+		case modifier == "NULL":
+			parts = append(parts, col+" DROP NOT NULL")
+		case strings.HasPrefix(modifier, "DEFAULT "):
+			parts = append(parts, col+" SET "+modifier)
+		}
+	}
+	return parts, true
+}
+
+func (d *PostgresDialect) InlineColumnComment(comment string) string {
+	return ""
+}
+
+func (d *PostgresDialect) InlineTableComment(comment string) string {
+	return ""
+}
+
+// CommentOnSQL - PostgreSQL stores comments with a separate COMMENT ON statement
+func (d *PostgresDialect) CommentOnSQL(table, column, comment string) string {
+	if column == "" {
+		return fmt.Sprintf("COMMENT ON TABLE %s IS %s", d.QuoteIdentifier(table), quoteString(comment))
+	}
+	return fmt.Sprintf("COMMENT ON COLUMN %s.%s IS %s", d.QuoteIdentifier(table), d.QuoteIdentifier(column), quoteString(comment))
+}
+
+// EnumColumnType - PostgreSQL enums need CREATE TYPE, so ENUM becomes VARCHAR with a CHECK
+// and SET becomes a TEXT[] restricted to the allowed values
+func (d *PostgresDialect) EnumColumnType(column string, values []string, set bool) string {
+	list := strings.Join(values, ", ")
+	if set {
+		return fmt.Sprintf("TEXT[] CHECK (%s <@ ARRAY[%s]::TEXT[])", d.QuoteIdentifier(column), list)
+	}
+	return fmt.Sprintf("VARCHAR(255) CHECK (%s IN (%s))", d.QuoteIdentifier(column), list)
+}
+
+// CreateIndexSQL - PostgreSQL puts USING before the column list
+func (d *PostgresDialect) CreateIndexSQL(table, name string, columns []string, unique bool, method, where string) string {
+	query := "CREATE INDEX "
+	if unique {
+		query = "CREATE UNIQUE INDEX "
+	}
+	query += fmt.Sprintf("%s ON %s", d.QuoteIdentifier(name), d.QuoteIdentifier(table))
+	if method != "" {
+		query += " USING " + method
+	}
+	query += fmt.Sprintf(" (%s)", strings.Join(quoteIdentifiers(d, columns), ", "))
+	if where != "" {
+		query += " WHERE " + where
+	}
+	return query
+}
+
+// DropIndexSQL - index names are schema-wide in PostgreSQL, the table is not needed
+func (d *PostgresDialect) DropIndexSQL(table, name string) string {
+	return fmt.Sprintf("DROP INDEX %s", d.QuoteIdentifier(name))
+}
+
+// InlineIndex - PostgreSQL only has UNIQUE constraints, plain indexes need CREATE INDEX
+func (d *PostgresDialect) InlineIndex(name string, columns []string, unique bool) string {
+	if !unique {
+		return ""
+	}
+	return fmt.Sprintf("CONSTRAINT %s UNIQUE (%s)", d.QuoteIdentifier(name), strings.Join(quoteIdentifiers(d, columns), ", "))
+}
+
+func (d *PostgresDialect) SupportsPartialIndex() bool {
+	return true
+}
+
+func (d *PostgresDialect) CheckConstraint(name, expression string) string {
+	return fmt.Sprintf("CONSTRAINT %s CHECK (%s)", d.QuoteIdentifier(name), expression)
+}
+
+func (d *PostgresDialect) AddCheck(name, expression string) string {
+	return "ADD " + d.CheckConstraint(name, expression)
+}
+
+func (d *PostgresDialect) DropCheck(name string) string {
+	return "DROP CONSTRAINT " + d.QuoteIdentifier(name)
+}
+
+func (d *PostgresDialect) SupportsDDLTransactions() bool {
+	return true
+}
+
+func (d *PostgresDialect) CreateTablePrefix(ifNotExists bool) string {
+	if ifNotExists {
+		return "CREATE TABLE IF NOT EXISTS"
+	}
+	return "CREATE TABLE"
+}
+
+func (d *PostgresDialect) DropTableSQL(name string, cascade bool) string {
+	if cascade {
+		return fmt.Sprintf("DROP TABLE %s CASCADE", d.QuoteIdentifier(name))
+	}
+	return fmt.Sprintf("DROP TABLE %s RESTRICT", d.QuoteIdentifier(name))
+}
+
+// ForeignKeyChecksSQL - PostgreSQL drops dependents with CASCADE
+func (d *PostgresDialect) ForeignKeyChecksSQL(enabled bool) string {
+	return ""
+}
+
+func (d *PostgresDialect) DependentTablesQuery(table string) string {
+	return fmt.Sprintf("SELECT DISTINCT c.conrelid::regclass::text FROM pg_constraint c WHERE c.contype = 'f' AND c.confrelid = %s::regclass AND c.conrelid <> c.confrelid", quoteString(table))
+}
+
+// SpatialColumnType - PostGIS: GEOMETRY(POINT,4326)
+func (d *PostgresDialect) SpatialColumnType(geomType string, srid int) string {
+	if geomType == "GEOMETRY" && srid <= 0 {
+		return "GEOMETRY"
+	}
+	if srid > 0 {
+		return fmt.Sprintf("GEOMETRY(%s,%d)", geomType, srid)
+	}
+	return fmt.Sprintf("GEOMETRY(%s)", geomType)
+}
+
+// SpatialIndex - PostgreSQL has no inline indexes, the GiST index is created separately
+func (d *PostgresDialect) SpatialIndex(name string, columns []string) string {
+	return ""
+}
+
+func (d *PostgresDialect) CreateSpatialIndexSQL(table, name string, columns []string) string {
+	return d.CreateIndexSQL(table, name, columns, false, "GIST", "")
+}
+
+// postgresTableSizeColumns - name, data_bytes, index_bytes, total_bytes, row_count
+const postgresTableSizeColumns = "c.relname AS name, pg_table_size(c.oid) AS data_bytes, pg_indexes_size(c.oid) AS index_bytes, pg_total_relation_size(c.oid) AS total_bytes, GREATEST(c.reltuples, 0)::bigint AS row_count"
+
+const postgresTablesFrom = "FROM pg_class c JOIN pg_namespace n ON n.oid = c.relnamespace WHERE c.relkind IN ('r', 'p') AND n.nspname = current_schema()"
+
+func (d *PostgresDialect) TableSizeQuery(table string) string {
+	return fmt.Sprintf("SELECT %s %s AND c.relname = '%s'", postgresTableSizeColumns, postgresTablesFrom, table)
+}
+
+func (d *PostgresDialect) DatabaseSizeQuery() string {
+	return "SELECT pg_database_size(current_database())"
+}
+
+func (d *PostgresDialect) IndexSizeQuery(table, index string) string {
+	return fmt.Sprintf("SELECT COALESCE(SUM(pg_relation_size(i.indexrelid)), 0) FROM pg_index i "+
+		"JOIN pg_class ic ON ic.oid = i.indexrelid "+
+		"JOIN pg_class t ON t.oid = i.indrelid "+
+		"JOIN pg_namespace n ON n.oid = t.relnamespace "+
+		"WHERE n.nspname = current_schema() AND t.relname = '%s' AND ic.relname = '%s'", table, index)
+}
+
+func (d *PostgresDialect) TopTablesBySizeQuery(limit int) string {
+	return fmt.Sprintf("SELECT %s %s ORDER BY total_bytes DESC LIMIT %d", postgresTableSizeColumns, postgresTablesFrom, limit)
+}
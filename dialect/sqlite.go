@@ -0,0 +1,529 @@
+package dialect
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type SQLiteDialect struct{}
+
+func (d *SQLiteDialect) PlaceholderFormat() string {
+	return "?"
+}
+
+func (d *SQLiteDialect) Rebind(sql string) string {
+	return sql // SQLite uses ? placeholders, no rebinding needed
+}
+
+func (d *SQLiteDialect) SelectLimit(limit int) string {
+	return fmt.Sprintf("LIMIT %d", limit)
+}
+
+func (d *SQLiteDialect) SelectOffset(offset int) string {
+	return fmt.Sprintf("OFFSET %d", offset)
+}
+
+func (d *SQLiteDialect) SelectOrderBy(column string, desc bool) string {
+	if desc {
+		return fmt.Sprintf("ORDER BY %s DESC", d.QuoteIdentifier(column))
+	}
+	return fmt.Sprintf("ORDER BY %s", d.QuoteIdentifier(column))
+}
+
+// FormatCTE supports [NOT] MATERIALIZED (SQLite 3.35+)
+func (d *SQLiteDialect) FormatCTE(name string, sql string, opt CTEOption) string {
+	switch opt {
+	case CTEMaterialize:
+		return fmt.Sprintf("%s AS MATERIALIZED (%s)", d.QuoteIdentifier(name), sql)
+	case CTENotMaterialize:
+		return fmt.Sprintf("%s AS NOT MATERIALIZED (%s)", d.QuoteIdentifier(name), sql)
+	default:
+		return fmt.Sprintf("%s AS (%s)", d.QuoteIdentifier(name), sql)
+	}
+}
+
+// SupportOptimizerHints - SQLite has no optimizer hints
+func (d *SQLiteDialect) SupportOptimizerHints() bool {
+	return false
+}
+
+// SupportsIntersectExcept - SQLite has INTERSECT / EXCEPT but neither the ALL
+// variants nor parenthesized operands, which the builder emits
+func (d *SQLiteDialect) SupportsIntersectExcept() bool {
+	return false
+}
+
+// SupportsDistinctOn - SQLite has no DISTINCT ON
+func (d *SQLiteDialect) SupportsDistinctOn() bool {
+	return false
+}
+
+// ILikeOperator - LIKE is case-insensitive for ASCII in SQLite
+func (d *SQLiteDialect) ILikeOperator() string {
+	return "LIKE"
+}
+
+// FormatApply - SQLite has no LATERAL joins
+func (d *SQLiteDialect) FormatApply(kind string, subSQL, alias string) string {
+	return ""
+}
+
+// IndexHint - INDEXED BY fails the query when the index is unusable, so hints are not emitted
+func (d *SQLiteDialect) IndexHint(kind string, indexes []string) string {
+	return ""
+}
+
+// PartitionSelection - SQLite has no table partitioning
+func (d *SQLiteDialect) PartitionSelection(partitions []string) string {
+	return ""
+}
+
+// ExplainPrefix - SQLite only has EXPLAIN QUERY PLAN, ANALYZE and formats are not available
+func (d *SQLiteDialect) ExplainPrefix(analyze bool, format string) string {
+	return "EXPLAIN QUERY PLAN"
+}
+
+// DatePart uses DATE()/TIME() and strftime() cast to INTEGER for numeric parts
+func (d *SQLiteDialect) DatePart(part, column string) string {
+	formats := map[string]string{
+		DatePartYear:  "%Y",
+		DatePartMonth: "%m",
+		DatePartDay:   "%d",
+		DatePartHour:  "%H",
+	}
+	if format, ok := formats[part]; ok {
+		return fmt.Sprintf("CAST(strftime('%s', %s) AS INTEGER)", format, column)
+	}
+	return fmt.Sprintf("%s(%s)", part, column)
+}
+
+// InlineValues - VALUES in SQLite names columns column1, column2..., so rows are
+// built as SELECT ? AS col ... UNION ALL SELECT ?, ... Missing keys become NULL
+func (d *SQLiteDialect) InlineValues(rows []map[string]any, alias string) (string, []any) {
+	columns := inlineValuesColumns(rows)
+	var selects []string
+	var args []any
+	for i, row := range rows {
+		fields := make([]string, len(columns))
+		for j, column := range columns {
+			fields[j] = "?"
+			if i == 0 {
+				fields[j] += " AS " + d.QuoteIdentifier(column)
+			}
+			args = append(args, row[column])
+		}
+		selects = append(selects, "SELECT "+strings.Join(fields, ", "))
+	}
+	return fmt.Sprintf("(%s) AS %s", strings.Join(selects, " UNION ALL "), d.QuoteIdentifier(alias)), args
+}
+
+// SelectLock - SQLite locks the whole database file, there are no row locks
+func (d *SQLiteDialect) SelectLock(mode string) string {
+	return ""
+}
+
+func (d *SQLiteDialect) InsertIgnore() string {
+	return "INSERT OR IGNORE INTO"
+}
+
+func (d *SQLiteDialect) InsertReplace() string {
+	return "INSERT OR REPLACE INTO"
+}
+
+// InsertOnConflict renders the upsert clause (SQLite 3.24+):
+// ON CONFLICT (cols) DO UPDATE SET col = excluded.col. Without columns to update it falls back to DO NOTHING
+func (d *SQLiteDialect) InsertOnConflict(columns []string, updateColumns []string, updateExcluded []string) string {
+	target := ""
+	if len(columns) > 0 {
+		quoted := make([]string, len(columns))
+		for i, col := range columns {
+			quoted[i] = d.QuoteIdentifier(col)
+		}
+		target = fmt.Sprintf(" (%s)", strings.Join(quoted, ", "))
+	}
+
+	var updates []string
+	for _, col := range append(append([]string(nil), updateColumns...), updateExcluded...) {
+		updates = append(updates, fmt.Sprintf("%s = excluded.%s", d.QuoteIdentifier(col), d.QuoteIdentifier(col)))
+	}
+	if len(updates) == 0 {
+		return fmt.Sprintf("ON CONFLICT%s DO NOTHING", target)
+	}
+
+	return fmt.Sprintf("ON CONFLICT%s DO UPDATE SET %s", target, strings.Join(updates, ", "))
+}
+
+func (d *SQLiteDialect) InsertOnConflictDoNothing() string {
+	return "ON CONFLICT DO NOTHING"
+}
+
+// UpdateLimit - UPDATE ... LIMIT requires SQLITE_ENABLE_UPDATE_DELETE_LIMIT, not available by default.
+// The empty clause makes the builder reject Limit/OrderBy with ErrNotSupported
+func (d *SQLiteDialect) UpdateLimit(limit int) string {
+	return ""
+}
+
+// JSON functions of the built-in JSON1 extension, paths use the same '$.a.b' syntax as MySQL
+func (d *SQLiteDialect) JsonSet(column, path string) string {
+	return fmt.Sprintf("json_set(%s, ?, ?)", column)
+}
+
+func (d *SQLiteDialect) JsonInsert(column, path string) string {
+	return fmt.Sprintf("json_insert(%s, ?, ?)", column)
+}
+
+func (d *SQLiteDialect) JsonReplace(column, path string) string {
+	return fmt.Sprintf("json_replace(%s, ?, ?)", column)
+}
+
+func (d *SQLiteDialect) JsonRemove(column, path string) string {
+	return fmt.Sprintf("json_remove(%s, ?)", column)
+}
+
+// JsonContains checks that the array (or object values) at path holds the scalar value
+func (d *SQLiteDialect) JsonContains(column, path, value string) (string, []any) {
+	if path == "" {
+		path = "$"
+	}
+	return fmt.Sprintf("EXISTS (SELECT 1 FROM json_each(%s, ?) WHERE json_each.value = json_extract(?, '$'))", column), []any{path, value}
+}
+
+func (d *SQLiteDialect) JsonExtract(column, path string) (string, []any) {
+	return fmt.Sprintf("json_extract(%s, ?)", column), []any{path}
+}
+
+// SupportsMerge - SQLite has no MERGE, use Upsert (ON CONFLICT) instead
+func (d *SQLiteDialect) SupportsMerge() bool {
+	return false
+}
+
+// SupportsReturning - SQLite 3.35+ has RETURNING, but not for statements inside WITH
+func (d *SQLiteDialect) SupportsReturning() bool {
+	return false
+}
+
+// DeleteLimit - see UpdateLimit
+func (d *SQLiteDialect) DeleteLimit(limit int) string {
+	return ""
+}
+
+// DeleteUsing - SQLite has neither DELETE ... USING nor JOIN, the condition goes into EXISTS
+func (d *SQLiteDialect) DeleteUsing(mainTable, usingTable, condition string) string {
+	return fmt.Sprintf("DELETE FROM %s WHERE EXISTS (SELECT 1 FROM %s WHERE %s)", mainTable, usingTable, condition)
+}
+
+// DeleteJoin - SQLite DELETE works on a single table only
+func (d *SQLiteDialect) DeleteJoin(mainTable string) string {
+	return ""
+}
+
+func (d *SQLiteDialect) Upsert(columns []string, values []any, conflictColumns []string, updateColumns []string) (string, []any) {
+	// This will be handled in the UpsertBuilder implementation
+	return "", nil
+}
+
+func (d *SQLiteDialect) BulkInsert(table string, columns []string, values []any, batchSize int) (string, []any) {
+	// This will be handled in the BulkBuilder implementation
+	return "", nil
+}
+
+func (d *SQLiteDialect) BulkUpdate(table string, columns []string, values []any, keyColumn string) (string, []any) {
+	// This will be handled in the BulkBuilder implementation
+	return "", nil
+}
+
+func (d *SQLiteDialect) BulkDelete(table string, conditions []map[string]any) (string, []any) {
+	if len(conditions) == 0 {
+		return "", nil
+	}
+
+	var whereClauses []string
+	var args []any
+
+	for _, condition := range conditions {
+		columns := make([]string, 0, len(condition))
+		for column := range condition {
+			columns = append(columns, column)
+		}
+		sort.Strings(columns)
+
+		var parts []string
+		for _, column := range columns {
+			parts = append(parts, fmt.Sprintf("%s = %s", d.QuoteIdentifier(column), d.PlaceholderFormat()))
+			args = append(args, condition[column])
+		}
+		whereClauses = append(whereClauses, fmt.Sprintf("(%s)", strings.Join(parts, " AND ")))
+	}
+
+	query := fmt.Sprintf("DELETE FROM %s WHERE %s", d.QuoteIdentifier(table), strings.Join(whereClauses, " OR "))
+	return query, args
+}
+
+func (d *SQLiteDialect) QuoteIdentifier(name string) string {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return ""
+	}
+
+	// Don't quote if it's a function call, asterisk, or already quoted
+	if name == "*" || strings.Contains(name, "(") || strings.Contains(name, ")") || (strings.HasPrefix(name, `"`) && strings.HasSuffix(name, `"`)) {
+		return name
+	}
+
+	// a regex to find ' as ' case-insensitively
+	re := regexp.MustCompile(`(?i)\s+as\s+`)
+	if re.MatchString(name) {
+		parts := re.Split(name, 2)
+		return d.QuoteIdentifier(parts[0]) + " AS " + d.QuoteIdentifier(parts[1])
+	}
+
+	if strings.Contains(name, ".") {
+		parts := strings.Split(name, ".")
+		quotedParts := make([]string, len(parts))
+		for i, part := range parts {
+			quotedParts[i] = d.QuoteIdentifier(part)
+		}
+		return strings.Join(quotedParts, ".")
+	}
+
+	// Don't quote if it's a number
+	if _, err := strconv.Atoi(name); err == nil {
+		return name
+	}
+
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// TruncateTableSQL - SQLite has no TRUNCATE, DELETE without WHERE uses the truncate optimization
+func (d *SQLiteDialect) TruncateTableSQL(table string) string {
+	return fmt.Sprintf("DELETE FROM %s", d.QuoteIdentifier(table))
+}
+
+func (d *SQLiteDialect) HasTableQuery(name string) string {
+	return fmt.Sprintf("SELECT COUNT(*) > 0 FROM sqlite_master WHERE type = 'table' AND name = '%s'", name)
+}
+
+func (d *SQLiteDialect) HasViewQuery(name string) string {
+	return fmt.Sprintf("SELECT COUNT(*) > 0 FROM sqlite_master WHERE type = 'view' AND name = '%s'", name)
+}
+
+// CreateViewSQL - SQLite has no CREATE OR REPLACE VIEW, the old view is dropped first
+func (d *SQLiteDialect) CreateViewSQL(name, query string, orReplace bool) []string {
+	create := fmt.Sprintf("CREATE VIEW %s AS %s", d.QuoteIdentifier(name), query)
+	if orReplace {
+		return []string{fmt.Sprintf("DROP VIEW IF EXISTS %s", d.QuoteIdentifier(name)), create}
+	}
+	return []string{create}
+}
+
+func (d *SQLiteDialect) HasColumnQuery(table, column string) string {
+	return fmt.Sprintf("SELECT COUNT(*) > 0 FROM pragma_table_info('%s') WHERE name = '%s'", table, column)
+}
+
+func (d *SQLiteDialect) HasIndexQuery(table, index string) string {
+	return fmt.Sprintf("SELECT COUNT(*) > 0 FROM sqlite_master WHERE type = 'index' AND tbl_name = '%s' AND name = '%s'", table, index)
+}
+
+func (d *SQLiteDialect) GetTablesQuery() string {
+	return "SELECT name AS Name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%'"
+}
+
+func (d *SQLiteDialect) GetColumnsQuery(table string) string {
+	return fmt.Sprintf("SELECT name AS Name, type AS Type FROM pragma_table_info('%s')", table)
+}
+
+func (d *SQLiteDialect) GetIndexesQuery(table string) string {
+	return fmt.Sprintf("SELECT il.name AS Name, group_concat(ii.name) AS Columns FROM pragma_index_list('%s') il JOIN pragma_index_info(il.name) ii GROUP BY il.name", table)
+}
+
+// GetConstraintsQuery collects PRIMARY KEY, UNIQUE and FOREIGN KEY from pragmas.
+// CHECK constraints are not exposed by SQLite and are not returned
+func (d *SQLiteDialect) GetConstraintsQuery(table string) string {
+	return fmt.Sprintf("SELECT name, type, column_name, ref_table, ref_column, check_clause FROM ("+
+		"SELECT 'PRIMARY KEY' AS name, 'PRIMARY KEY' AS type, name AS column_name, NULL AS ref_table, NULL AS ref_column, NULL AS check_clause, pk AS ord "+
+		"FROM pragma_table_info('%[1]s') WHERE pk > 0 "+
+		"UNION ALL SELECT il.name, 'UNIQUE', ii.name, NULL, NULL, NULL, ii.seqno "+
+		"FROM pragma_index_list('%[1]s') il JOIN pragma_index_info(il.name) ii WHERE il.origin = 'u' "+
+		"UNION ALL SELECT 'fk_%[1]s_' || id, 'FOREIGN KEY', \"from\", \"table\", \"to\", NULL, seq "+
+		"FROM pragma_foreign_key_list('%[1]s')"+
+		") ORDER BY name, ord", table)
+}
+
+// GetForeignKeysQuery - SQLite foreign keys are unnamed, names follow GetConstraintsQuery (fk_table_id)
+func (d *SQLiteDialect) GetForeignKeysQuery(table string) string {
+	return fmt.Sprintf("SELECT 'fk_%[1]s_' || id, \"from\", \"table\", \"to\", on_delete, on_update "+
+		"FROM pragma_foreign_key_list('%[1]s') ORDER BY id, seq", table)
+}
+
+// GetIDColumnType - INTEGER PRIMARY KEY is an alias for rowid and auto-increments
+func (d *SQLiteDialect) GetIDColumnType() string {
+	return "INTEGER"
+}
+
+// ColumnType - SQLite uses type affinity: INTEGER, REAL, TEXT and BLOB
+func (d *SQLiteDialect) ColumnType(name string, length int) string {
+	switch name {
+	case "SMALLINT", "TINYINT", "MEDIUMINT":
+		return "INTEGER"
+	case "FLOAT", "DOUBLE":
+		return "REAL"
+	case "BINARY", "BLOB":
+		return "BLOB"
+	case "UUID":
+		return "CHAR(36)"
+	}
+	if length > 0 {
+		return fmt.Sprintf("%s(%d)", name, length)
+	}
+	return name
+}
+
+func (d *SQLiteDialect) GeneratedColumn(expression string, stored bool) string {
+	if stored {
+		return fmt.Sprintf("GENERATED ALWAYS AS (%s) STORED", expression)
+	}
+	return fmt.Sprintf("GENERATED ALWAYS AS (%s) VIRTUAL", expression)
+}
+
+// RenameColumnSQL - supported since SQLite 3.25
+func (d *SQLiteDialect) RenameColumnSQL(table, from, to string) string {
+	return fmt.Sprintf("ALTER TABLE %s RENAME COLUMN %s TO %s", d.QuoteIdentifier(table), d.QuoteIdentifier(from), d.QuoteIdentifier(to))
+}
+
+// ModifyColumn - SQLite cannot alter existing columns, the table has to be rebuilt
+func (d *SQLiteDialect) ModifyColumn(column, dataType string, modifiers []string) ([]string, bool) {
+	return nil, false
+}
+
+// Comments - SQLite has no comment support, DDL comments are dropped
+func (d *SQLiteDialect) InlineColumnComment(comment string) string {
+	return ""
+}
+
+func (d *SQLiteDialect) InlineTableComment(comment string) string {
+	return ""
+}
+
+func (d *SQLiteDialect) CommentOnSQL(table, column, comment string) string {
+	return ""
+}
+
+// EnumColumnType - ENUM is TEXT with a CHECK, SET is stored as comma-separated TEXT without a check
+func (d *SQLiteDialect) EnumColumnType(column string, values []string, set bool) string {
+	if set {
+		return "TEXT"
+	}
+	return fmt.Sprintf("TEXT CHECK (%s IN (%s))", d.QuoteIdentifier(column), strings.Join(values, ", "))
+}
+
+// CreateIndexSQL - SQLite only has B-tree indexes, method is ignored
+func (d *SQLiteDialect) CreateIndexSQL(table, name string, columns []string, unique bool, method, where string) string {
+	query := "CREATE INDEX "
+	if unique {
+		query = "CREATE UNIQUE INDEX "
+	}
+	query += fmt.Sprintf("%s ON %s (%s)", d.QuoteIdentifier(name), d.QuoteIdentifier(table), strings.Join(quoteIdentifiers(d, columns), ", "))
+	if where != "" {
+		query += " WHERE " + where
+	}
+	return query
+}
+
+func (d *SQLiteDialect) DropIndexSQL(table, name string) string {
+	return fmt.Sprintf("DROP INDEX %s", d.QuoteIdentifier(name))
+}
+
+// InlineIndex - SQLite only has UNIQUE constraints, plain indexes need CREATE INDEX
+func (d *SQLiteDialect) InlineIndex(name string, columns []string, unique bool) string {
+	if !unique {
+		return ""
+	}
+	return fmt.Sprintf("CONSTRAINT %s UNIQUE (%s)", d.QuoteIdentifier(name), strings.Join(quoteIdentifiers(d, columns), ", "))
+}
+
+func (d *SQLiteDialect) SupportsPartialIndex() bool {
+	return true
+}
+
+// CheckConstraint - SQLite only accepts CHECK in CREATE TABLE, ALTER TABLE cannot add or drop it
+func (d *SQLiteDialect) CheckConstraint(name, expression string) string {
+	return fmt.Sprintf("CONSTRAINT %s CHECK (%s)", d.QuoteIdentifier(name), expression)
+}
+
+func (d *SQLiteDialect) AddCheck(name, expression string) string {
+	return ""
+}
+
+func (d *SQLiteDialect) DropCheck(name string) string {
+	return ""
+}
+
+func (d *SQLiteDialect) SupportsDDLTransactions() bool {
+	return true
+}
+
+func (d *SQLiteDialect) CreateTablePrefix(ifNotExists bool) string {
+	if ifNotExists {
+		return "CREATE TABLE IF NOT EXISTS"
+	}
+	return "CREATE TABLE"
+}
+
+// DropTableSQL - SQLite has no CASCADE/RESTRICT, cascading drop is done with PRAGMA foreign_keys = OFF
+func (d *SQLiteDialect) DropTableSQL(name string, cascade bool) string {
+	return fmt.Sprintf("DROP TABLE %s", d.QuoteIdentifier(name))
+}
+
+func (d *SQLiteDialect) ForeignKeyChecksSQL(enabled bool) string {
+	if enabled {
+		return "PRAGMA foreign_keys = ON"
+	}
+	return "PRAGMA foreign_keys = OFF"
+}
+
+func (d *SQLiteDialect) DependentTablesQuery(table string) string {
+	return fmt.Sprintf("SELECT DISTINCT m.name FROM sqlite_master m JOIN pragma_foreign_key_list(m.name) fk WHERE m.type = 'table' AND fk.\"table\" = %s AND m.name <> %s", quoteString(table), quoteString(table))
+}
+
+// SpatialColumnType - SQLite accepts any type name, SpatiaLite registers geometry separately
+func (d *SQLiteDialect) SpatialColumnType(geomType string, srid int) string {
+	return geomType
+}
+
+// SpatialIndex - SpatiaLite creates spatial indexes with CreateSpatialIndex(), not in DDL
+func (d *SQLiteDialect) SpatialIndex(name string, columns []string) string {
+	return ""
+}
+
+func (d *SQLiteDialect) CreateSpatialIndexSQL(table, name string, columns []string) string {
+	return ""
+}
+
+// sqliteTableSizeColumns - name, data_bytes, index_bytes, total_bytes, row_count.
+// Sizes come from the dbstat virtual table (SQLITE_ENABLE_DBSTAT_VTAB), row count is not tracked
+const sqliteTableSizeColumns = "m.name AS name, " +
+	"COALESCE((SELECT SUM(pgsize) FROM dbstat WHERE dbstat.name = m.name), 0) AS data_bytes, " +
+	"COALESCE((SELECT SUM(pgsize) FROM dbstat WHERE dbstat.name IN (SELECT i.name FROM sqlite_master i WHERE i.type = 'index' AND i.tbl_name = m.name)), 0) AS index_bytes, " +
+	"COALESCE((SELECT SUM(pgsize) FROM dbstat WHERE dbstat.name = m.name OR dbstat.name IN (SELECT i.name FROM sqlite_master i WHERE i.type = 'index' AND i.tbl_name = m.name)), 0) AS total_bytes, " +
+	"0 AS row_count"
+
+func (d *SQLiteDialect) TableSizeQuery(table string) string {
+	return fmt.Sprintf("SELECT %s FROM sqlite_master m WHERE m.type = 'table' AND m.name = '%s'", sqliteTableSizeColumns, table)
+}
+
+func (d *SQLiteDialect) DatabaseSizeQuery() string {
+	return "SELECT page_count * page_size FROM pragma_page_count(), pragma_page_size()"
+}
+
+// IndexSizeQuery - index pages from dbstat, index names are database-wide so table only narrows the match
+func (d *SQLiteDialect) IndexSizeQuery(table, index string) string {
+	return fmt.Sprintf("SELECT COALESCE(SUM(d.pgsize), 0) FROM dbstat d JOIN sqlite_master m ON m.name = d.name "+
+		"WHERE m.type = 'index' AND m.tbl_name = '%s' AND m.name = '%s'", table, index)
+}
+
+func (d *SQLiteDialect) TopTablesBySizeQuery(limit int) string {
+	return fmt.Sprintf("SELECT %s FROM sqlite_master m WHERE m.type = 'table' AND m.name NOT LIKE 'sqlite_%%' ORDER BY total_bytes DESC LIMIT %d", sqliteTableSizeColumns, limit)
+}
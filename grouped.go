@@ -0,0 +1,116 @@
+package querycraft
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ForEachGrouped загружает все строки и вызывает fn для каждой группы строк
+// с одинаковым значением keyColumn. Группы идут в порядке первого появления ключа
+func (s *selectBuilder) ForEachGrouped(keyColumn string, fn func(key any, rows []map[string]any) error) error {
+	rows, err := s.Rows()
+	if err != nil {
+		return err
+	}
+
+	field := resultColumnName(keyColumn)
+	var order []string
+	keys := make(map[string]any)
+	groups := make(map[string][]map[string]any)
+
+	for _, row := range rows {
+		key := row[field]
+		// Значения []byte несравнимы, поэтому группируем по строковому представлению
+		id := fmt.Sprintf("%v", key)
+		if _, ok := groups[id]; !ok {
+			order = append(order, id)
+			keys[id] = key
+		}
+		groups[id] = append(groups[id], row)
+	}
+
+	for _, id := range order {
+		if err := fn(keys[id], groups[id]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ForEachGroupedChunk - потоковый вариант ForEachGrouped: строки читаются
+// порциями по chunkSize с сортировкой по keyColumn, в памяти держится только
+// текущая группа. Существующая сортировка билдера заменяется на keyColumn, idColumn.
+// idColumn должен быть уникальным (обычно первичный ключ): без него порядок строк
+// внутри группы не определен и OFFSET может пропустить или повторить строки
+func (s *selectBuilder) ForEachGroupedChunk(keyColumn, idColumn string, chunkSize int, fn func(key any, rows []map[string]any) error) error {
+	if chunkSize <= 0 {
+		return errors.New("chunk size must be greater than 0")
+	}
+	if idColumn == "" {
+		return errors.New("chunk id column is required")
+	}
+	// Limit и Offset билдера заменились бы порционным чтением
+	if s.limit != nil || s.offset != nil {
+		return errors.New("ForEachGroupedChunk: builder Limit/Offset cannot be combined with chunking")
+	}
+
+	field := resultColumnName(keyColumn)
+	var currentKey any
+	var currentID string
+	var current []map[string]any
+
+	for offset := 0; ; offset += chunkSize {
+		chunk := s.Clone().(*selectBuilder)
+		chunk.logger = s.logger
+		chunk.orders = nil
+		chunk.OrderBy(keyColumn)
+		chunk.OrderBy(idColumn)
+		chunk.Limit(chunkSize)
+		if offset > 0 {
+			chunk.Offset(offset)
+		}
+
+		rows, err := chunk.Rows()
+		if err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			key := row[field]
+			id := fmt.Sprintf("%v", key)
+			if current != nil && id != currentID {
+				if err := fn(currentKey, current); err != nil {
+					return err
+				}
+				current = nil
+			}
+			currentKey, currentID = key, id
+			current = append(current, row)
+		}
+
+		if len(rows) < chunkSize {
+			break
+		}
+	}
+
+	if current != nil {
+		return fn(currentKey, current)
+	}
+	return nil
+}
+
+var resultColumnAliasRe = regexp.MustCompile(`(?i)\s+as\s+`)
+
+// resultColumnName - имя колонки в результате: "o.user_id" -> "user_id", "x AS y" -> "y"
+func resultColumnName(column string) string {
+	if parts := resultColumnAliasRe.Split(column, 2); len(parts) == 2 {
+		column = parts[1]
+	}
+	if idx := strings.LastIndex(column, "."); idx >= 0 {
+		column = column[idx+1:]
+	}
+	return strings.Trim(strings.TrimSpace(column), "`\"")
+}
@@ -3,8 +3,13 @@ package querycraft
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"github.com/antibomberman/querycraft/dialect"
 	"github.com/jmoiron/sqlx"
+	"hash/fnv"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 	"unicode/utf8"
@@ -26,17 +31,248 @@ type SQLXExecutor interface {
 	Rebind(query string) string
 }
 
+// connExecutor - SQLXExecutor поверх одного соединения пула, нужен для
+// сессионных настроек (SET FOREIGN_KEY_CHECKS и т.п.)
+type connExecutor struct {
+	*sqlx.Conn
+	driverName string
+}
+
+func (c *connExecutor) DriverName() string {
+	return c.driverName
+}
+
 // Logger - интерфейс для логирования запросов
 type Logger interface {
 	LogQuery(ctx context.Context, query string, args []any, duration time.Duration, err error)
 }
 
+// QueryStartLogger - необязательное расширение Logger. Если логгер его реализует,
+// LogQueryStart вызывается непосредственно перед выполнением запроса,
+// остальные реализации Logger продолжают работать без изменений
+type QueryStartLogger interface {
+	LogQueryStart(ctx context.Context, query string, args []any)
+}
+
+// QueryAttributes - дополнительные сведения о запросе для агрегаторов логов и APM
+type QueryAttributes struct {
+	// Fingerprint - хэш шаблона запроса (см. Fingerprint)
+	Fingerprint string
+}
+
+// AttributedLogger - необязательное расширение Logger. Если логгер его реализует,
+// вместо LogQuery вызывается LogQueryWithAttributes
+type AttributedLogger interface {
+	LogQueryWithAttributes(ctx context.Context, query string, args []any, duration time.Duration, err error, attrs QueryAttributes)
+}
+
+// logQuery передает запрос в логгер, добавляя атрибуты, если логгер их поддерживает
+func logQuery(logger Logger, ctx context.Context, query string, args []any, duration time.Duration, err error) {
+	if l, ok := logger.(AttributedLogger); ok {
+		l.LogQueryWithAttributes(ctx, query, args, duration, err, QueryAttributes{Fingerprint: Fingerprint(query)})
+		return
+	}
+	logger.LogQuery(ctx, query, args, duration, err)
+}
+
+// logQueryStart вызывает LogQueryStart, если логгер его поддерживает
+func logQueryStart(logger Logger, ctx context.Context, query string, args []any) {
+	if l, ok := logger.(QueryStartLogger); ok {
+		l.LogQueryStart(ctx, query, args)
+	}
+}
+
+// returningClause - RETURNING col1, col2 для диалектов с поддержкой, иначе пустая строка
+func returningClause(d dialect.Dialect, columns []string) string {
+	if len(columns) == 0 || !d.SupportsReturning() {
+		return ""
+	}
+	return "RETURNING " + strings.Join(quoteIdentifiers(d, columns), ", ")
+}
+
+// queryReturning выполняет INSERT/UPDATE/DELETE ... RETURNING и сканирует строки в dest
+func queryReturning(ctx context.Context, db SQLXExecutor, logger Logger, d dialect.Dialect, dest any, query string, args []any) error {
+	if !d.SupportsReturning() {
+		return fmt.Errorf("RETURNING: %w", ErrNotSupported)
+	}
+
+	var start time.Time
+	if logger != nil {
+		logQueryStart(logger, ctx, query, args)
+		start = time.Now()
+	}
+
+	err := db.SelectContext(ctx, dest, query, args...)
+
+	if logger != nil {
+		duration := time.Since(start)
+		logQuery(logger, ctx, query, args, duration, err)
+	}
+
+	return err
+}
+
+// qualifyTable добавляет схему к имени таблицы, если схема задана,
+// а таблица еще не содержит схему ("schema.table")
+func qualifyTable(schema, table string) string {
+	table = strings.TrimSpace(table)
+	if schema == "" || table == "" || strings.Contains(table, ".") || strings.HasPrefix(table, "(") {
+		return table
+	}
+	return schema + "." + table
+}
+
+// joinConditions объединяет условия через AND, если условие не начинается с AND/OR
+func joinConditions(conditions []string) string {
+	var parts []string
+	for i, cond := range conditions {
+		if i == 0 || strings.HasPrefix(cond, "AND ") || strings.HasPrefix(cond, "OR ") {
+			parts = append(parts, cond)
+		} else {
+			parts = append(parts, "AND "+cond)
+		}
+	}
+	result := strings.Join(parts, " ")
+	result = strings.TrimPrefix(result, "AND ")
+	return strings.TrimPrefix(result, "OR ")
+}
+
+// orderExpression убирает ведущий "ORDER BY " (в любом регистре): выражения
+// сортировки хранятся без префикса и объединяются в один ORDER BY через запятую
+func orderExpression(order string) string {
+	order = strings.TrimSpace(order)
+	if len(order) >= 9 && strings.EqualFold(order[:9], "ORDER BY ") {
+		return strings.TrimSpace(order[9:])
+	}
+	return order
+}
+
+// jsonDocument сериализует значение для JSON предикатов, json.RawMessage и []byte передаются как есть
+func jsonDocument(value any) (string, error) {
+	switch v := value.(type) {
+	case json.RawMessage:
+		return string(v), nil
+	case []byte:
+		return string(v), nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("json value: %w", err)
+	}
+	return string(data), nil
+}
+
 // Debuggable - интерфейс для отладки
 type Debuggable interface {
 	ToSQL() (string, []any)
 	Explain() ([]map[string]any, error)
 }
 
+// toInt64 приводит значение из драйвера к int64. MySQL возвращает числа
+// строками или []byte, они разбираются через strconv. NULL - 0
+func toInt64(value any) (int64, error) {
+	switch v := value.(type) {
+	case nil:
+		return 0, nil
+	case int64:
+		return v, nil
+	case int:
+		return int64(v), nil
+	case int32:
+		return int64(v), nil
+	case uint64:
+		return int64(v), nil
+	case float64:
+		return int64(v), nil
+	case bool:
+		if v {
+			return 1, nil
+		}
+		return 0, nil
+	case []byte:
+		return strconv.ParseInt(string(v), 10, 64)
+	case string:
+		return strconv.ParseInt(v, 10, 64)
+	}
+	return 0, fmt.Errorf("cannot convert %T to int64", value)
+}
+
+func toFloat64(value any) (float64, error) {
+	switch v := value.(type) {
+	case nil:
+		return 0, nil
+	case float64:
+		return v, nil
+	case float32:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case int:
+		return float64(v), nil
+	case []byte:
+		return strconv.ParseFloat(string(v), 64)
+	case string:
+		return strconv.ParseFloat(v, 64)
+	}
+	return 0, fmt.Errorf("cannot convert %T to float64", value)
+}
+
+func toString(value any) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	case time.Time:
+		return v.Format(time.RFC3339Nano), nil
+	}
+	return fmt.Sprintf("%v", value), nil
+}
+
+// toBool - TINYINT(1) в MySQL приходит числом или строкой "0"/"1"
+func toBool(value any) (bool, error) {
+	switch v := value.(type) {
+	case nil:
+		return false, nil
+	case bool:
+		return v, nil
+	case int64:
+		return v != 0, nil
+	case int:
+		return v != 0, nil
+	case []byte:
+		return strconv.ParseBool(string(v))
+	case string:
+		return strconv.ParseBool(v)
+	}
+	return false, fmt.Errorf("cannot convert %T to bool", value)
+}
+
+// fieldAs приводит значение Field функцией convert; при ошибке возвращает нулевое значение
+func fieldAs[T any](value any, column string, convert func(any) (T, error)) (T, error) {
+	converted, err := convert(value)
+	if err != nil {
+		var zero T
+		return zero, fmt.Errorf("column %s: %w", column, err)
+	}
+	return converted, nil
+}
+
+// pluckAs приводит каждое значение Pluck функцией convert
+func pluckAs[T any](values []any, column string, convert func(any) (T, error)) ([]T, error) {
+	results := make([]T, 0, len(values))
+	for i, value := range values {
+		converted, err := convert(value)
+		if err != nil {
+			return nil, fmt.Errorf("column %s, row %d: %w", column, i, err)
+		}
+		results = append(results, converted)
+	}
+	return results, nil
+}
+
 func convertByteArrayToString(data map[string]any) map[string]any {
 	if data == nil {
 		return nil
@@ -73,3 +309,227 @@ func formatArg(arg any) string {
 		return fmt.Sprintf("%v", v)
 	}
 }
+
+// sqlClauseKeywords - ключевые слова, перед которыми FormatSQL переносит строку
+var sqlClauseKeywords = []string{
+	"FROM", "WHERE", "GROUP BY", "HAVING", "ORDER BY", "LIMIT", "OFFSET",
+}
+
+// sqlJoinKeywords - варианты JOIN, которые FormatSQL переносит с отступом
+var sqlJoinKeywords = []string{
+	"INNER JOIN", "LEFT JOIN", "RIGHT JOIN", "CROSS JOIN", "OUTER JOIN", "FULL JOIN", "JOIN",
+}
+
+// FormatSQL форматирует SQL для чтения: переносит строку перед FROM, JOIN,
+// WHERE, GROUP BY, HAVING, ORDER BY, LIMIT, OFFSET. SQL не разбирается
+// семантически - строки в кавычках и подзапросы в скобках не изменяются.
+func FormatSQL(sql string) string {
+	var b strings.Builder
+	depth := 0
+	var quote byte
+
+	for i := 0; i < len(sql); i++ {
+		c := sql[i]
+
+		if quote != 0 {
+			b.WriteByte(c)
+			if c == quote {
+				quote = 0
+			}
+			continue
+		}
+
+		switch c {
+		case '\'', '"', '`':
+			quote = c
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ' ':
+			if depth == 0 {
+				// Ключевое слово пишем целиком, чтобы не разбить "LEFT JOIN"
+				if kw := matchKeyword(sql[i+1:], sqlJoinKeywords); kw != "" {
+					b.WriteString("\n  " + sql[i+1:i+1+len(kw)])
+					i += len(kw)
+					continue
+				}
+				if kw := matchKeyword(sql[i+1:], sqlClauseKeywords); kw != "" {
+					b.WriteString("\n" + sql[i+1:i+1+len(kw)])
+					i += len(kw)
+					continue
+				}
+			}
+		}
+		b.WriteByte(c)
+	}
+
+	return b.String()
+}
+
+// matchKeyword возвращает ключевое слово, с которого начинается s (без учета регистра)
+func matchKeyword(s string, keywords []string) string {
+	for _, kw := range keywords {
+		if len(s) < len(kw) || !strings.EqualFold(s[:len(kw)], kw) {
+			continue
+		}
+		// Ключевое слово должно заканчиваться на границе слова
+		if len(s) == len(kw) || s[len(kw)] == ' ' || s[len(kw)] == '\n' {
+			return kw
+		}
+	}
+	return ""
+}
+
+// normalizedInList - список плейсхолдеров внутри IN (...)
+var normalizedInList = regexp.MustCompile(`(?i)\b(NOT )?IN \(\?(?: ?, ?\?)*\)`)
+
+// NormalizeSQL приводит SQL к каноническому виду для ключей кэша и группировки
+// медленных запросов: строковые литералы, числа и плейсхолдеры ($1, ?) заменяются
+// на ?, списки IN (?, ?, ?) сворачиваются в IN (?), пробелы схлопываются.
+// Идентификаторы в `обратных` и "двойных" кавычках не изменяются.
+func NormalizeSQL(sql string) string {
+	var b strings.Builder
+	space := false
+
+	for i := 0; i < len(sql); i++ {
+		c := sql[i]
+
+		if isSQLSpace(c) {
+			space = true
+			continue
+		}
+		if space {
+			if b.Len() > 0 {
+				b.WriteByte(' ')
+			}
+			space = false
+		}
+
+		switch {
+		case c == '\'':
+			// Строковый литерал, '' внутри строки - экранированная кавычка
+			i++
+			for i < len(sql) {
+				if sql[i] == '\\' {
+					i += 2
+					continue
+				}
+				if sql[i] == '\'' {
+					if i+1 < len(sql) && sql[i+1] == '\'' {
+						i += 2
+						continue
+					}
+					break
+				}
+				i++
+			}
+			b.WriteByte('?')
+		case c == '`' || c == '"':
+			end := strings.IndexByte(sql[i+1:], c)
+			if end < 0 {
+				b.WriteString(sql[i:])
+				i = len(sql)
+				continue
+			}
+			b.WriteString(sql[i : i+end+2])
+			i += end + 1
+		case c == '$' && i+1 < len(sql) && isDigit(sql[i+1]):
+			for i+1 < len(sql) && isDigit(sql[i+1]) {
+				i++
+			}
+			b.WriteByte('?')
+		case isDigit(c) && (i == 0 || !isIdentChar(sql[i-1])):
+			for i+1 < len(sql) && (isDigit(sql[i+1]) || sql[i+1] == '.') {
+				i++
+			}
+			b.WriteByte('?')
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	return normalizedInList.ReplaceAllString(b.String(), "${1}IN (?)")
+}
+
+// Fingerprint возвращает хэш шаблона запроса: комментарии удаляются, значения
+// заменяются на ? (см. NormalizeSQL), слова вне кавычек приводятся к нижнему регистру.
+// Запросы, отличающиеся только значениями, получают одинаковый fingerprint
+func Fingerprint(sql string) string {
+	normalized := NormalizeSQL(stripSQLComments(sql))
+
+	var b strings.Builder
+	for i := 0; i < len(normalized); i++ {
+		c := normalized[i]
+		if c == '`' || c == '"' {
+			// Идентификаторы в кавычках чувствительны к регистру
+			end := strings.IndexByte(normalized[i+1:], c)
+			if end < 0 {
+				b.WriteString(normalized[i:])
+				break
+			}
+			b.WriteString(normalized[i : i+end+2])
+			i += end + 1
+			continue
+		}
+		if c >= 'A' && c <= 'Z' {
+			c += 'a' - 'A'
+		}
+		b.WriteByte(c)
+	}
+
+	h := fnv.New64a()
+	h.Write([]byte(b.String()))
+	return fmt.Sprintf("%016x", h.Sum64())
+}
+
+// stripSQLComments удаляет комментарии -- и /* */ вне строковых литералов
+func stripSQLComments(sql string) string {
+	var b strings.Builder
+	for i := 0; i < len(sql); i++ {
+		c := sql[i]
+		switch {
+		case c == '\'' || c == '`' || c == '"':
+			end := i + 1
+			for end < len(sql) && sql[end] != c {
+				if sql[end] == '\\' && c == '\'' {
+					end++
+				}
+				end++
+			}
+			if end >= len(sql) {
+				end = len(sql) - 1
+			}
+			b.WriteString(sql[i : end+1])
+			i = end
+		case c == '-' && i+1 < len(sql) && sql[i+1] == '-':
+			for i < len(sql) && sql[i] != '\n' {
+				i++
+			}
+			b.WriteByte(' ')
+		case c == '/' && i+1 < len(sql) && sql[i+1] == '*':
+			end := strings.Index(sql[i+2:], "*/")
+			if end < 0 {
+				i = len(sql)
+			} else {
+				i += end + 3
+			}
+			b.WriteByte(' ')
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+func isSQLSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isIdentChar(c byte) bool {
+	return c == '_' || isDigit(c) || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 	"time"
 
@@ -18,6 +19,7 @@ type InsertBuilder interface {
 	Values(values ...any) InsertBuilder
 	ValuesMap(values map[string]any) InsertBuilder
 	ValuesMaps(values []map[string]any) InsertBuilder
+	WithMissingValueDefault(defaultVal any) InsertBuilder
 
 	// Конфликты
 	OnConflictDoNothing() InsertBuilder
@@ -28,14 +30,20 @@ type InsertBuilder interface {
 	// INSERT FROM SELECT
 	FromSelect(selectBuilder SelectBuilder) InsertBuilder
 
+	// RETURNING (PostgreSQL), в MySQL не добавляется
+	Returning(columns ...string) InsertBuilder
+
 	// Выполнение
 	Exec() (sql.Result, error)
+	ExecReturning(dest any) error
 	ExecReturnID() (int64, error)
+	ExecRowsAffected() (int64, error)
 
 	// Утилиты
 	WithContext(ctx context.Context) InsertBuilder
 	ToSQL() (string, []any)
 	PrintSQL() InsertBuilder
+	PrettyPrint() InsertBuilder
 	Clone() InsertBuilder
 }
 
@@ -51,9 +59,14 @@ type insertBuilder struct {
 	onConflict          string
 	onConflictDoNothing bool
 	fromSelect          SelectBuilder
+	returning           []string
+
+	// Значение для ключей, отсутствующих в ValuesMaps
+	missingValueDefault any
 
 	// Print SQL flag
-	printSQL bool
+	printSQL  bool
+	prettySQL bool
 }
 
 func NewInsertBuilder(db SQLXExecutor, dialect dialect.Dialect, table string) InsertBuilder {
@@ -193,11 +206,13 @@ func (i *insertBuilder) ValuesMaps(values []map[string]any) InsertBuilder {
 		return i
 	}
 
-	// Extract columns from first map
+	// Extract columns from first map and sort them once,
+	// so every row uses exactly the same column order
 	var columns []string
 	for col := range values[0] {
 		columns = append(columns, col)
 	}
+	sort.Strings(columns)
 
 	i.columns = columns
 
@@ -208,9 +223,14 @@ func (i *insertBuilder) ValuesMaps(values []map[string]any) InsertBuilder {
 			continue
 		}
 
-		var vals []any
-		for _, col := range columns {
-			vals = append(vals, m[col])
+		vals := make([]any, len(columns))
+		for j, col := range columns {
+			val, ok := m[col]
+			if !ok {
+				// Ключ отсутствует - значение подставится при сборке SQL
+				val = missingValue{}
+			}
+			vals[j] = val
 		}
 		i.values = append(i.values, vals)
 	}
@@ -218,6 +238,16 @@ func (i *insertBuilder) ValuesMaps(values []map[string]any) InsertBuilder {
 	return i
 }
 
+// missingValue отмечает колонку, которой не было в одной из карт ValuesMaps
+type missingValue struct{}
+
+// WithMissingValueDefault задает значение для колонок, отсутствующих
+// в некоторых картах ValuesMaps (по умолчанию nil)
+func (i *insertBuilder) WithMissingValueDefault(defaultVal any) InsertBuilder {
+	i.missingValueDefault = defaultVal
+	return i
+}
+
 func (i *insertBuilder) OnConflictDoNothing() InsertBuilder {
 	// Для MySQL используем INSERT IGNORE
 	// Это будет обработано в диалекте
@@ -237,6 +267,12 @@ func (i *insertBuilder) FromSelect(selectBuilder SelectBuilder) InsertBuilder {
 	return i
 }
 
+// Returning задает колонки RETURNING, "*" - все колонки
+func (i *insertBuilder) Returning(columns ...string) InsertBuilder {
+	i.returning = columns
+	return i
+}
+
 func (i *insertBuilder) WithContext(ctx context.Context) InsertBuilder {
 	i.ctx = ctx
 	return i
@@ -253,6 +289,8 @@ func (i *insertBuilder) Clone() InsertBuilder {
 		onConflict:          i.onConflict,
 		onConflictDoNothing: i.onConflictDoNothing,
 		fromSelect:          i.fromSelect,
+		missingValueDefault: i.missingValueDefault,
+		returning:           append([]string(nil), i.returning...),
 	}
 
 	copy(clone.columns, i.columns)
@@ -317,7 +355,12 @@ func (i *insertBuilder) buildValuesSQL() (string, []any) {
 				placeholders[j] = i.dialect.PlaceholderFormat()
 			}
 			valueParts = append(valueParts, fmt.Sprintf("(%s)", strings.Join(placeholders, ", ")))
-			args = append(args, row...)
+			for _, val := range row {
+				if _, ok := val.(missingValue); ok {
+					val = i.missingValueDefault
+				}
+				args = append(args, val)
+			}
 		}
 		queryParts = append(queryParts, "VALUES", strings.Join(valueParts, ", "))
 	}
@@ -327,15 +370,20 @@ func (i *insertBuilder) buildValuesSQL() (string, []any) {
 		// For MySQL, this would be ON DUPLICATE KEY UPDATE
 		// This should be handled by the dialect
 		queryParts = append(queryParts, i.dialect.InsertOnConflict(i.columns, i.columns, nil))
-	} else if i.onConflictDoNothing {
-		// Handle ON CONFLICT DO NOTHING
+	} else if i.onConflictDoNothing || (i.onConflict == "IGNORE" && insertKeyword == "INSERT INTO") {
+		// Handle ON CONFLICT DO NOTHING, в том числе для диалектов без INSERT IGNORE
 		onConflictClause := i.dialect.InsertOnConflictDoNothing()
 		if onConflictClause != "" {
 			queryParts = append(queryParts, onConflictClause)
 		}
 	}
 
-	return strings.Join(queryParts, " "), args
+	if returning := returningClause(i.dialect, i.returning); returning != "" {
+		queryParts = append(queryParts, returning)
+	}
+
+	// Плейсхолдеры в формате драйвера ($1, $2... для PostgreSQL)
+	return i.dialect.Rebind(strings.Join(queryParts, " ")), args
 }
 
 func (i *insertBuilder) buildFromSelectSQL() (string, []any) {
@@ -387,7 +435,11 @@ func (i *insertBuilder) buildFromSelectSQL() (string, []any) {
 		}
 	}
 
-	return strings.Join(queryParts, " "), args
+	if returning := returningClause(i.dialect, i.returning); returning != "" {
+		queryParts = append(queryParts, returning)
+	}
+
+	return i.dialect.Rebind(strings.Join(queryParts, " ")), args
 }
 
 func (i *insertBuilder) ToSQL() (string, []any) {
@@ -399,6 +451,13 @@ func (i *insertBuilder) PrintSQL() InsertBuilder {
 	return i
 }
 
+// PrettyPrint печатает SQL в многострочном виде (см. FormatSQL)
+func (i *insertBuilder) PrettyPrint() InsertBuilder {
+	i.printSQL = true
+	i.prettySQL = true
+	return i
+}
+
 func (i *insertBuilder) setLogger(logger Logger) {
 	i.logger = logger
 }
@@ -413,12 +472,16 @@ func (i *insertBuilder) Exec() (sql.Result, error) {
 		for _, arg := range args {
 			formattedSQL = strings.Replace(formattedSQL, i.dialect.PlaceholderFormat(), fmt.Sprintf("'%v'", arg), 1)
 		}
+		if i.prettySQL {
+			formattedSQL = FormatSQL(formattedSQL)
+		}
 		fmt.Println(formattedSQL)
 	}
 
 	// Log query if logger is set
 	var start time.Time
 	if i.logger != nil {
+		logQueryStart(i.logger, i.ctx, sql, args)
 		start = time.Now()
 	}
 
@@ -427,12 +490,22 @@ func (i *insertBuilder) Exec() (sql.Result, error) {
 	// Log query execution
 	if i.logger != nil {
 		duration := time.Since(start)
-		i.logger.LogQuery(i.ctx, sql, args, duration, err)
+		logQuery(i.logger, i.ctx, sql, args, duration, err)
 	}
 
 	return result, err
 }
 
+// ExecReturning выполняет INSERT ... RETURNING и сканирует строки в dest (срез структур).
+// Без Returning возвращаются все колонки, в диалектах без RETURNING - ErrNotSupported
+func (i *insertBuilder) ExecReturning(dest any) error {
+	if len(i.returning) == 0 {
+		i.returning = []string{"*"}
+	}
+	sql, args := i.buildSQL()
+	return queryReturning(i.ctx, i.db, i.logger, i.dialect, dest, sql, args)
+}
+
 func (i *insertBuilder) ExecReturnID() (int64, error) {
 	result, err := i.Exec()
 	if err != nil {
@@ -441,3 +514,13 @@ func (i *insertBuilder) ExecReturnID() (int64, error) {
 
 	return result.LastInsertId()
 }
+
+// ExecRowsAffected выполняет запрос и возвращает количество затронутых строк
+func (i *insertBuilder) ExecRowsAffected() (int64, error) {
+	result, err := i.Exec()
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
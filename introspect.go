@@ -0,0 +1,189 @@
+package querycraft
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// QueryInspection - результат разбора SQL функцией Introspect
+type QueryInspection struct {
+	Operation    string   // SELECT, INSERT, UPDATE, DELETE, MERGE...
+	Tables       []string // все таблицы, включая подзапросы
+	WhereColumns []string // колонки из WHERE основного запроса
+	Limit        *int
+	Offset       *int
+	HasSubquery  bool
+}
+
+var (
+	introspectTableRe    = regexp.MustCompile("(?i)\\b(?:FROM|JOIN|INTO|UPDATE|USING)\\s+(" + introspectIdent + ")")
+	introspectWhereColRe = regexp.MustCompile("(?i)(" + introspectIdent + ")\\s*(?:=|<>|!=|<=|>=|<|>|\\bNOT\\s+(?:IN|LIKE|BETWEEN)\\b|\\b(?:IN|LIKE|BETWEEN|IS)\\b)")
+	introspectLimitRe    = regexp.MustCompile(`(?i)\bLIMIT\s+(\d+)(?:\s*,\s*(\d+))?`)
+	introspectOffsetRe   = regexp.MustCompile(`(?i)\bOFFSET\s+(\d+)`)
+	introspectSubqueryRe = regexp.MustCompile(`(?i)\(\s*(SELECT|WITH)\b`)
+	introspectWhereEndRe = regexp.MustCompile(`(?i)\b(GROUP\s+BY|HAVING|ORDER\s+BY|LIMIT|OFFSET|FOR\s+UPDATE|UNION|RETURNING)\b`)
+)
+
+// introspectIdent - идентификатор, возможно в кавычках и с префиксом схемы/таблицы
+const introspectIdent = "(?:[`\"]?[A-Za-z_][A-Za-z0-9_]*[`\"]?\\.)*[`\"]?[A-Za-z_][A-Za-z0-9_]*[`\"]?"
+
+// introspectKeywords не являются колонками или таблицами
+var introspectKeywords = map[string]bool{
+	"AND": true, "OR": true, "NOT": true, "WHERE": true, "SELECT": true, "NULL": true,
+	"EXISTS": true, "ON": true, "AS": true, "SET": true, "VALUES": true,
+}
+
+// Introspect разбирает SQL без полноценного парсера: определяет тип операции,
+// таблицы, колонки в WHERE, LIMIT/OFFSET и наличие подзапросов.
+// Подходит для middleware (авторизация, аудит) и тестов
+func Introspect(sql string) QueryInspection {
+	sql = maskStringLiterals(sql)
+	top := stripSubqueries(sql)
+
+	inspection := QueryInspection{
+		Operation:   introspectOperation(top),
+		HasSubquery: introspectSubqueryRe.MatchString(sql),
+	}
+
+	// Таблицы - по всему запросу, включая подзапросы
+	seen := make(map[string]bool)
+	for _, m := range introspectTableRe.FindAllStringSubmatch(sql, -1) {
+		table := unquoteIdentifier(m[1])
+		if introspectKeywords[strings.ToUpper(table)] || seen[table] {
+			continue
+		}
+		seen[table] = true
+		inspection.Tables = append(inspection.Tables, table)
+	}
+
+	// WHERE основного запроса
+	if where := topLevelWhere(top); where != "" {
+		seen := make(map[string]bool)
+		for _, m := range introspectWhereColRe.FindAllStringSubmatch(where, -1) {
+			column := unquoteIdentifier(m[1])
+			if introspectKeywords[strings.ToUpper(column)] || seen[column] {
+				continue
+			}
+			seen[column] = true
+			inspection.WhereColumns = append(inspection.WhereColumns, column)
+		}
+	}
+
+	if m := introspectLimitRe.FindStringSubmatch(top); m != nil {
+		if m[2] != "" {
+			// MySQL: LIMIT offset, count
+			offset, _ := strconv.Atoi(m[1])
+			limit, _ := strconv.Atoi(m[2])
+			inspection.Offset = &offset
+			inspection.Limit = &limit
+		} else {
+			limit, _ := strconv.Atoi(m[1])
+			inspection.Limit = &limit
+		}
+	}
+	if m := introspectOffsetRe.FindStringSubmatch(top); m != nil {
+		offset, _ := strconv.Atoi(m[1])
+		inspection.Offset = &offset
+	}
+
+	return inspection
+}
+
+// introspectOperation - первое ключевое слово; для WITH - операция после CTE
+func introspectOperation(top string) string {
+	fields := strings.Fields(top)
+	if len(fields) == 0 {
+		return ""
+	}
+	op := strings.ToUpper(fields[0])
+	if op != "WITH" {
+		return op
+	}
+	for _, f := range fields[1:] {
+		switch kw := strings.ToUpper(f); kw {
+		case "SELECT", "INSERT", "UPDATE", "DELETE", "MERGE":
+			return kw
+		}
+	}
+	return op
+}
+
+// topLevelWhere возвращает текст после WHERE до GROUP BY/ORDER BY/LIMIT...
+func topLevelWhere(top string) string {
+	idx := indexKeyword(top, "WHERE")
+	if idx < 0 {
+		return ""
+	}
+	where := top[idx+len("WHERE"):]
+	if loc := introspectWhereEndRe.FindStringIndex(where); loc != nil {
+		where = where[:loc[0]]
+	}
+	return where
+}
+
+func indexKeyword(s, kw string) int {
+	re := regexp.MustCompile(`(?i)\b` + kw + `\b`)
+	if loc := re.FindStringIndex(s); loc != nil {
+		return loc[0]
+	}
+	return -1
+}
+
+// maskStringLiterals заменяет содержимое строк в '...' на пустую строку
+func maskStringLiterals(sql string) string {
+	var b strings.Builder
+	inString := false
+	for i := 0; i < len(sql); i++ {
+		c := sql[i]
+		if inString {
+			if c == '\'' {
+				if i+1 < len(sql) && sql[i+1] == '\'' {
+					i++
+					continue
+				}
+				inString = false
+				b.WriteByte(c)
+			}
+			continue
+		}
+		if c == '\'' {
+			inString = true
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+// stripSubqueries заменяет (SELECT ...) на (?) - остается только основной запрос
+func stripSubqueries(sql string) string {
+	var b strings.Builder
+	for i := 0; i < len(sql); i++ {
+		if sql[i] == '(' {
+			if loc := introspectSubqueryRe.FindStringIndex(sql[i:]); loc != nil && loc[0] == 0 {
+				depth := 0
+				j := i
+				for ; j < len(sql); j++ {
+					if sql[j] == '(' {
+						depth++
+					} else if sql[j] == ')' {
+						depth--
+						if depth == 0 {
+							break
+						}
+					}
+				}
+				b.WriteString("(?)")
+				i = j
+				continue
+			}
+		}
+		b.WriteByte(sql[i])
+	}
+	return b.String()
+}
+
+// unquoteIdentifier: `users`.`id` -> users.id
+func unquoteIdentifier(name string) string {
+	return strings.NewReplacer("`", "", "\"", "").Replace(name)
+}
@@ -0,0 +1,67 @@
+package querycraft
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrStopIteration - fn из Iterate возвращает ее, чтобы остановить обход без ошибки
+var ErrStopIteration = errors.New("stop iteration")
+
+// Iterate читает строки курсором и вызывает fn для каждой, не накапливая их в памяти.
+// Обход прерывается при ошибке fn (ErrStopIteration - без ошибки) или отмене контекста
+func (s *selectBuilder) Iterate(fn func(row map[string]any) error) (err error) {
+	if s.err != nil {
+		return s.err
+	}
+	sql, args := s.buildSQL()
+
+	// Print SQL if needed
+	if s.printSQL {
+		// Simple placeholder replacement for debugging
+		formattedSQL := sql
+		for _, arg := range args {
+			formattedSQL = strings.Replace(formattedSQL, s.dialect.PlaceholderFormat(), formatArg(arg), 1)
+		}
+		if s.prettySQL {
+			formattedSQL = FormatSQL(formattedSQL)
+		}
+		fmt.Println(formattedSQL)
+	}
+
+	// Log query if logger is set
+	var start time.Time
+	if s.logger != nil {
+		logQueryStart(s.logger, s.ctx, sql, args)
+		start = time.Now()
+		defer func() {
+			logQuery(s.logger, s.ctx, sql, args, time.Since(start), err)
+		}()
+	}
+
+	rows, err := s.db.QueryxContext(s.ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := s.ctx.Err(); err != nil {
+			return err
+		}
+		row := make(map[string]any)
+		if err := rows.MapScan(row); err != nil {
+			return err
+		}
+		if err := fn(convertByteArrayToString(row)); err != nil {
+			if errors.Is(err, ErrStopIteration) {
+				return nil
+			}
+			return err
+		}
+	}
+
+	return rows.Err()
+}
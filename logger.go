@@ -57,6 +57,15 @@ func NewFileLogger(options Options) *FileLogger {
 
 // LogQuery logs a query
 func (l *FileLogger) LogQuery(ctx context.Context, query string, args []any, duration time.Duration, err error) {
+	l.logQuery(query, args, duration, err, QueryAttributes{})
+}
+
+// LogQueryWithAttributes logs a query together with its fingerprint
+func (l *FileLogger) LogQueryWithAttributes(ctx context.Context, query string, args []any, duration time.Duration, err error, attrs QueryAttributes) {
+	l.logQuery(query, args, duration, err, attrs)
+}
+
+func (l *FileLogger) logQuery(query string, args []any, duration time.Duration, err error, attrs QueryAttributes) {
 	if !l.options.LogEnabled {
 		return
 	}
@@ -80,6 +89,9 @@ func (l *FileLogger) LogQuery(ctx context.Context, query string, args []any, dur
 			"query":     formattedQuery,
 			"error":     err,
 		}
+		if attrs.Fingerprint != "" {
+			logData["fingerprint"] = attrs.Fingerprint
+		}
 
 		jsonData, err := json.Marshal(logData)
 		if err != nil {
@@ -97,15 +109,63 @@ func (l *FileLogger) LogQuery(ctx context.Context, query string, args []any, dur
 		}
 	} else {
 		// Create text log entry
+		fingerprint := ""
+		if attrs.Fingerprint != "" {
+			fingerprint = ", Fingerprint: " + attrs.Fingerprint
+		}
 		logEntry = fmt.Sprintf(
-			"[%s] [QUERY] Duration: %v, Query: %s, Error: %v\n",
+			"[%s] [QUERY] Duration: %v, Query: %s, Error: %v%s\n",
 			timestamp.Format("2006-01-02 15:04:05"),
 			duration,
 			formattedQuery,
 			err,
+			fingerprint,
 		)
 	}
 
+	l.write(timestamp, logEntry)
+}
+
+// LogQueryStart logs the moment a query is sent to the database.
+// Entries are written only with LogLevelDebug.
+func (l *FileLogger) LogQueryStart(ctx context.Context, query string, args []any) {
+	if !l.options.LogEnabled || l.options.LogLevel > LogLevelDebug {
+		return
+	}
+
+	// Format the query with arguments
+	formattedQuery := query
+	for _, arg := range args {
+		formattedQuery = strings.Replace(formattedQuery, "?", fmt.Sprintf("'%v'", arg), 1)
+	}
+
+	timestamp := time.Now()
+	var logEntry string
+
+	if l.options.LogFormat == LogFormatJSON {
+		logData := map[string]any{
+			"timestamp": timestamp.Format("2006-01-02 15:04:05"),
+			"type":      "START",
+			"query":     formattedQuery,
+		}
+
+		jsonData, err := json.Marshal(logData)
+		if err != nil {
+			logEntry = fmt.Sprintf("[%s] [START] Query: %s, JSON_Error: %v\n",
+				timestamp.Format("2006-01-02 15:04:05"), formattedQuery, err)
+		} else {
+			logEntry = string(jsonData) + "\n"
+		}
+	} else {
+		logEntry = fmt.Sprintf("[%s] [START] Query: %s\n",
+			timestamp.Format("2006-01-02 15:04:05"), formattedQuery)
+	}
+
+	l.write(timestamp, logEntry)
+}
+
+// write prints the entry to console and/or appends it to the daily log file
+func (l *FileLogger) write(timestamp time.Time, logEntry string) {
 	// Print to console if PrintToConsole is enabled
 	if l.options.LogPrintToConsole {
 		fmt.Print(logEntry)
@@ -0,0 +1,240 @@
+package querycraft
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/antibomberman/querycraft/dialect"
+)
+
+// ErrMergeNotSupported - диалект не поддерживает MERGE (например, MySQL)
+var ErrMergeNotSupported = errors.New("MERGE is not supported by dialect")
+
+// MergeBuilder - интерфейс для MERGE запросов (SQL Server, PostgreSQL 15+)
+type MergeBuilder interface {
+	Into(targetTable string) MergeBuilder
+	Using(sourceTable string, condition string) MergeBuilder
+
+	// Ветки WHEN; условия WHERE из fn попадают в WHEN ... AND условие
+	WhenMatched(fn func(UpdateBuilder) UpdateBuilder) MergeBuilder
+	WhenNotMatched(fn func(InsertBuilder) InsertBuilder) MergeBuilder
+	WhenNotMatchedBySource(fn func(DeleteBuilder) DeleteBuilder) MergeBuilder
+
+	// Выполнение
+	Exec() (sql.Result, error)
+
+	// Утилиты
+	WithContext(ctx context.Context) MergeBuilder
+	ToSQL() (string, []any)
+	PrintSQL() MergeBuilder
+}
+
+type mergeBuilder struct {
+	db      SQLXExecutor
+	dialect dialect.Dialect
+	ctx     context.Context
+	logger  Logger
+
+	table           string
+	sourceTable     string
+	sourceCondition string
+	clauses         []string
+	clauseArgs      []any
+
+	// Print SQL flag
+	printSQL bool
+}
+
+func NewMergeBuilder(db SQLXExecutor, dialect dialect.Dialect, table string) MergeBuilder {
+	return &mergeBuilder{
+		db:      db,
+		dialect: dialect,
+		ctx:     context.Background(),
+		table:   table,
+	}
+}
+
+func (m *mergeBuilder) Into(targetTable string) MergeBuilder {
+	m.table = targetTable
+	return m
+}
+
+func (m *mergeBuilder) Using(sourceTable string, condition string) MergeBuilder {
+	m.sourceTable = sourceTable
+	m.sourceCondition = condition
+	return m
+}
+
+// WhenMatched - WHEN MATCHED [AND ...] THEN UPDATE SET ...
+func (m *mergeBuilder) WhenMatched(fn func(UpdateBuilder) UpdateBuilder) MergeBuilder {
+	scratch := &updateBuilder{dialect: m.dialect, ctx: m.ctx}
+	if ub, ok := fn(scratch).(*updateBuilder); ok && len(ub.sets) > 0 {
+		when := "WHEN MATCHED"
+		if cond := joinMergeConditions(ub.wheres); cond != "" {
+			when += " AND " + cond
+		}
+		m.clauses = append(m.clauses, fmt.Sprintf("%s THEN UPDATE SET %s", when, strings.Join(ub.sets, ", ")))
+		// Условие WHEN идет в SQL раньше SET
+		m.clauseArgs = append(m.clauseArgs, ub.whereArgs...)
+		m.clauseArgs = append(m.clauseArgs, ub.setArgs...)
+	}
+	return m
+}
+
+// WhenNotMatched - WHEN NOT MATCHED THEN INSERT (...) VALUES (...), используется первая строка значений
+func (m *mergeBuilder) WhenNotMatched(fn func(InsertBuilder) InsertBuilder) MergeBuilder {
+	scratch := &insertBuilder{dialect: m.dialect, ctx: m.ctx}
+	if ib, ok := fn(scratch).(*insertBuilder); ok && len(ib.values) > 0 {
+		row := ib.values[0]
+		placeholders := make([]string, len(row))
+		for i := range row {
+			placeholders[i] = m.dialect.PlaceholderFormat()
+		}
+		m.clauses = append(m.clauses, fmt.Sprintf("WHEN NOT MATCHED THEN INSERT (%s) VALUES (%s)",
+			strings.Join(quoteIdentifiers(m.dialect, ib.columns), ", "),
+			strings.Join(placeholders, ", ")))
+		for _, val := range row {
+			if _, ok := val.(missingValue); ok {
+				val = ib.missingValueDefault
+			}
+			m.clauseArgs = append(m.clauseArgs, val)
+		}
+	}
+	return m
+}
+
+// WhenNotMatchedBySource - WHEN NOT MATCHED BY SOURCE [AND ...] THEN DELETE
+func (m *mergeBuilder) WhenNotMatchedBySource(fn func(DeleteBuilder) DeleteBuilder) MergeBuilder {
+	scratch := &deleteBuilder{dialect: m.dialect, ctx: m.ctx}
+	if db, ok := fn(scratch).(*deleteBuilder); ok {
+		when := "WHEN NOT MATCHED BY SOURCE"
+		if cond := joinMergeConditions(db.wheres); cond != "" {
+			when += " AND " + cond
+		}
+		m.clauses = append(m.clauses, when+" THEN DELETE")
+		m.clauseArgs = append(m.clauseArgs, db.whereArgs...)
+	}
+	return m
+}
+
+// joinMergeConditions объединяет WHERE фрагменты так же, как buildSQL билдеров
+func joinMergeConditions(wheres []string) string {
+	if len(wheres) == 0 {
+		return ""
+	}
+	var parts []string
+	for i, where := range wheres {
+		if i == 0 || strings.HasPrefix(where, "AND ") || strings.HasPrefix(where, "OR ") || strings.HasPrefix(where, "(") {
+			parts = append(parts, where)
+		} else {
+			parts = append(parts, "AND "+where)
+		}
+	}
+	cond := strings.Join(parts, " ")
+	cond = strings.TrimPrefix(cond, "AND ")
+	cond = strings.TrimPrefix(cond, "OR ")
+	if len(wheres) > 1 {
+		cond = "(" + cond + ")"
+	}
+	return cond
+}
+
+func (m *mergeBuilder) quoteTableNameWithAlias(tableName string) string {
+	re := regexp.MustCompile(`(?i)^(.+?)\s+(as\s+)?(.+?)$`)
+	matches := re.FindStringSubmatch(tableName)
+
+	if len(matches) == 4 {
+		table := strings.TrimSpace(matches[1])
+		alias := strings.TrimSpace(matches[3])
+		return fmt.Sprintf("%s AS %s", m.dialect.QuoteIdentifier(table), alias)
+	}
+
+	return m.dialect.QuoteIdentifier(tableName)
+}
+
+func (m *mergeBuilder) quoteJoinCondition(condition string) string {
+	re := regexp.MustCompile(`[a-zA-Z_][a-zA-Z0-9_]*(\.[a-zA-Z_][a-zA-Z0-9_]*)*`)
+	return re.ReplaceAllStringFunc(condition, func(identifier string) string {
+		upperIdentifier := strings.ToUpper(identifier)
+		if upperIdentifier == "AND" || upperIdentifier == "OR" || upperIdentifier == "ON" || upperIdentifier == "AS" {
+			return identifier
+		}
+		return m.dialect.QuoteIdentifier(identifier)
+	})
+}
+
+func (m *mergeBuilder) buildSQL() (string, []any) {
+	queryParts := []string{
+		"MERGE INTO", m.quoteTableNameWithAlias(m.table),
+		"USING", m.quoteTableNameWithAlias(m.sourceTable),
+		"ON", m.quoteJoinCondition(m.sourceCondition),
+	}
+	queryParts = append(queryParts, m.clauses...)
+
+	args := make([]any, len(m.clauseArgs))
+	copy(args, m.clauseArgs)
+
+	// Плейсхолдеры в формате драйвера ($1, $2... для PostgreSQL)
+	return m.dialect.Rebind(strings.Join(queryParts, " ")), args
+}
+
+func (m *mergeBuilder) ToSQL() (string, []any) {
+	return m.buildSQL()
+}
+
+func (m *mergeBuilder) PrintSQL() MergeBuilder {
+	m.printSQL = true
+	return m
+}
+
+func (m *mergeBuilder) WithContext(ctx context.Context) MergeBuilder {
+	m.ctx = ctx
+	return m
+}
+
+func (m *mergeBuilder) setLogger(logger Logger) {
+	m.logger = logger
+}
+
+func (m *mergeBuilder) Exec() (sql.Result, error) {
+	if !m.dialect.SupportsMerge() {
+		return nil, ErrMergeNotSupported
+	}
+	if m.sourceTable == "" || len(m.clauses) == 0 {
+		return nil, errors.New("merge requires Using and at least one WHEN clause")
+	}
+
+	sql, args := m.buildSQL()
+
+	// Print SQL if needed
+	if m.printSQL {
+		// Simple placeholder replacement for debugging
+		formattedSQL := sql
+		for _, arg := range args {
+			formattedSQL = strings.Replace(formattedSQL, m.dialect.PlaceholderFormat(), formatArg(arg), 1)
+		}
+		fmt.Println(formattedSQL)
+	}
+
+	// Log query if logger is set
+	var start time.Time
+	if m.logger != nil {
+		logQueryStart(m.logger, m.ctx, sql, args)
+		start = time.Now()
+	}
+
+	result, err := m.db.ExecContext(m.ctx, sql, args...)
+
+	// Log query execution
+	if m.logger != nil {
+		duration := time.Since(start)
+		logQuery(m.logger, m.ctx, sql, args, duration, err)
+	}
+
+	return result, err
+}
@@ -3,10 +3,15 @@ package querycraft
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"io/fs"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/antibomberman/querycraft/dialect"
+	"github.com/jmoiron/sqlx"
 )
 
 type MigrationManager interface {
@@ -21,7 +26,11 @@ type MigrationManager interface {
 	Current() (string, error)
 
 	// Создание миграций
-	Create(name string) error
+	Create(name string, dir string) error
+	LoadMigrationsFromDir(dir string) error
+	LoadMigrationsFromFS(fsys fs.FS, dir string) error
+	CreateFromSchema(name string, model any) (string, error)
+	SetMigrationsDir(dir string) MigrationManager
 
 	// Сброс
 	Reset() error
@@ -30,6 +39,10 @@ type MigrationManager interface {
 	// Работа с миграциями
 	RegisterMigration(name string, migration Migration) error
 	GetMigrations() map[string]Migration
+
+	// Версии
+	GetMigrationVersion(name string) (string, error)
+	RollbackToVersion(version string) error
 }
 
 type Migration interface {
@@ -37,27 +50,70 @@ type Migration interface {
 	Down(schema SchemaBuilder) error
 }
 
+// Versioned - необязательный интерфейс миграции. Если миграция его реализует,
+// версия сохраняется в колонке version таблицы migrations
+type Versioned interface {
+	Version() string
+}
+
+// Hashed - необязательный интерфейс миграции. Hash сохраняется в колонке checksum
+// таблицы migrations, изменение уже примененной миграции приводит к ошибке
+// ErrChecksumMismatch в Up, Migrate и Status
+type Hashed interface {
+	Hash() string
+}
+
+// ErrChecksumMismatch - примененная миграция была изменена
+var ErrChecksumMismatch = errors.New("migration checksum mismatch")
+
 type MigrationStatus struct {
 	Name      string
 	Applied   bool
 	AppliedAt *time.Time
 	Batch     int
+	Checksum  string
+	// Missing - миграция применена, но больше не зарегистрирована (удалена или объединена)
+	Missing bool
+}
+
+// MigrationOption - опции MigrationManager
+type MigrationOption func(*MigrationConfig)
+
+type MigrationConfig struct {
+	// Transactional - каждая миграция вместе с записью в migrations выполняется
+	// в своей транзакции. Игнорируется диалектами без транзакционного DDL (MySQL)
+	Transactional bool
+}
+
+func WithTransactionalMigrations(enabled bool) MigrationOption {
+	return func(config *MigrationConfig) {
+		config.Transactional = enabled
+	}
 }
 
 type migrationManager struct {
 	db         SQLXExecutor
 	dialect    dialect.Dialect
 	migrations map[string]Migration
+	order      []string // порядок регистрации, map не гарантирует порядок обхода
 	ctx        context.Context
+	config     MigrationConfig
+
+	// Каталог для сгенерированных файлов миграций
+	migrationsDir string
 }
 
-func NewMigrationManager(db SQLXExecutor, dialect dialect.Dialect) MigrationManager {
-	return &migrationManager{
+func NewMigrationManager(db SQLXExecutor, dialect dialect.Dialect, opts ...MigrationOption) MigrationManager {
+	m := &migrationManager{
 		db:         db,
 		dialect:    dialect,
 		migrations: make(map[string]Migration),
 		ctx:        context.Background(),
 	}
+	for _, opt := range opts {
+		opt(&m.config)
+	}
+	return m
 }
 
 func (m *migrationManager) WithContext(ctx context.Context) MigrationManager {
@@ -65,8 +121,17 @@ func (m *migrationManager) WithContext(ctx context.Context) MigrationManager {
 	return m
 }
 
+// SetMigrationsDir задает каталог, в который Create и CreateFromSchema записывают файлы
+func (m *migrationManager) SetMigrationsDir(dir string) MigrationManager {
+	m.migrationsDir = dir
+	return m
+}
+
 // Регистрация миграций
 func (m *migrationManager) RegisterMigration(name string, migration Migration) error {
+	if _, exists := m.migrations[name]; !exists {
+		m.order = append(m.order, name)
+	}
 	m.migrations[name] = migration
 	return nil
 }
@@ -88,22 +153,21 @@ func (m *migrationManager) Up() error {
 		return err
 	}
 
+	if err := m.verifyAppliedChecksums(); err != nil {
+		return err
+	}
+
 	// Определяем следующий batch номер
 	batch, err := m.getNextBatchNumber()
 	if err != nil {
 		return err
 	}
 
-	// Применяем непримененные миграции
-	for name, migration := range m.migrations {
+	// Применяем непримененные миграции в порядке регистрации
+	for _, name := range m.order {
+		migration := m.migrations[name]
 		if !contains(applied, name) {
-			schema := NewSchemaBuilder(m.db, m.dialect)
-			if err := migration.Up(schema); err != nil {
-				return fmt.Errorf("error applying migration %s: %w", name, err)
-			}
-
-			// Записываем в таблицу миграций
-			if err := m.recordMigration(name, batch); err != nil {
+			if err := m.applyMigration(name, migration, batch); err != nil {
 				return err
 			}
 		}
@@ -127,13 +191,7 @@ func (m *migrationManager) Down() error {
 			return fmt.Errorf("migration %s not found", name)
 		}
 
-		schema := NewSchemaBuilder(m.db, m.dialect)
-		if err := migration.Down(schema); err != nil {
-			return fmt.Errorf("error rolling back migration %s: %w", name, err)
-		}
-
-		// Удаляем запись из таблицы миграций
-		if err := m.removeMigrationRecord(name); err != nil {
+		if err := m.revertMigration(name, migration); err != nil {
 			return err
 		}
 	}
@@ -158,6 +216,10 @@ func (m *migrationManager) Migrate(steps ...int) error {
 		return err
 	}
 
+	if err := m.verifyAppliedChecksums(); err != nil {
+		return err
+	}
+
 	// Определяем следующий batch номер
 	batch, err := m.getNextBatchNumber()
 	if err != nil {
@@ -166,15 +228,10 @@ func (m *migrationManager) Migrate(steps ...int) error {
 
 	// Применяем непримененные миграции
 	appliedCount := 0
-	for name, migration := range m.migrations {
+	for _, name := range m.order {
+		migration := m.migrations[name]
 		if !contains(applied, name) && appliedCount < stepCount {
-			schema := NewSchemaBuilder(m.db, m.dialect)
-			if err := migration.Up(schema); err != nil {
-				return fmt.Errorf("error applying migration %s: %w", name, err)
-			}
-
-			// Записываем в таблицу миграций
-			if err := m.recordMigration(name, batch); err != nil {
+			if err := m.applyMigration(name, migration, batch); err != nil {
 				return err
 			}
 
@@ -211,13 +268,7 @@ func (m *migrationManager) Rollback(steps ...int) error {
 			return fmt.Errorf("migration %s not found", name)
 		}
 
-		schema := NewSchemaBuilder(m.db, m.dialect)
-		if err := migration.Down(schema); err != nil {
-			return fmt.Errorf("error rolling back migration %s: %w", name, err)
-		}
-
-		// Удаляем запись из таблицы миграций
-		if err := m.removeMigrationRecord(name); err != nil {
+		if err := m.revertMigration(name, migration); err != nil {
 			return err
 		}
 
@@ -240,9 +291,17 @@ func (m *migrationManager) Status() ([]MigrationStatus, error) {
 		return nil, err
 	}
 
+	checksums := make(map[string]string, len(applied))
+	for _, status := range applied {
+		checksums[status.Name] = status.Checksum
+	}
+	if err := m.compareChecksums(checksums); err != nil {
+		return nil, err
+	}
+
 	// Создаем статусы для всех миграций
 	var statuses []MigrationStatus
-	for name := range m.migrations {
+	for _, name := range m.order {
 		status := MigrationStatus{
 			Name:    name,
 			Applied: false,
@@ -254,6 +313,7 @@ func (m *migrationManager) Status() ([]MigrationStatus, error) {
 				status.Applied = true
 				status.AppliedAt = appliedStatus.AppliedAt
 				status.Batch = appliedStatus.Batch
+				status.Checksum = appliedStatus.Checksum
 				break
 			}
 		}
@@ -261,9 +321,71 @@ func (m *migrationManager) Status() ([]MigrationStatus, error) {
 		statuses = append(statuses, status)
 	}
 
+	// Примененные миграции, которых нет среди зарегистрированных
+	for _, appliedStatus := range applied {
+		if _, ok := m.migrations[appliedStatus.Name]; !ok {
+			appliedStatus.Missing = true
+			statuses = append(statuses, appliedStatus)
+		}
+	}
+
 	return statuses, nil
 }
 
+// Версии
+func (m *migrationManager) GetMigrationVersion(name string) (string, error) {
+	var version string
+	query := m.dialect.Rebind("SELECT COALESCE(version, '') FROM migrations WHERE name = ?")
+	err := m.db.GetContext(m.ctx, &version, query, name)
+	if err == sql.ErrNoRows {
+		return "", fmt.Errorf("migration %s is not applied", name)
+	}
+	if err != nil {
+		return "", err
+	}
+	return version, nil
+}
+
+// RollbackToVersion откатывает все примененные миграции с версией новее version.
+// Миграции без версии не откатываются
+func (m *migrationManager) RollbackToVersion(version string) error {
+	query := "SELECT name, COALESCE(version, '') FROM migrations ORDER BY created_at DESC, id DESC"
+	rows, err := m.db.QueryContext(m.ctx, query)
+	if err != nil {
+		return err
+	}
+
+	var names []string
+	for rows.Next() {
+		var name, applied string
+		if err := rows.Scan(&name, &applied); err != nil {
+			rows.Close()
+			return err
+		}
+		if applied != "" && compareVersions(applied, version) > 0 {
+			names = append(names, name)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	// Откатываем от новых к старым
+	for _, name := range names {
+		migration, exists := m.migrations[name]
+		if !exists {
+			return fmt.Errorf("migration %s not found", name)
+		}
+
+		if err := m.revertMigration(name, migration); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *migrationManager) Current() (string, error) {
 	lastBatch, err := m.getLastBatchNumber()
 	if err != nil {
@@ -282,13 +404,6 @@ func (m *migrationManager) Current() (string, error) {
 	return "", nil
 }
 
-// Создание миграций
-func (m *migrationManager) Create(name string) error {
-	// Эта функция должна создавать файлы миграций, но для простоты
-	// мы просто возвращаем nil
-	return nil
-}
-
 // Сброс
 func (m *migrationManager) Reset() error {
 	// Откатываем все миграции
@@ -318,16 +433,120 @@ func (m *migrationManager) Refresh() error {
 }
 
 // Вспомогательные методы
+
+// applyMigration выполняет Up и записывает миграцию в таблицу migrations
+func (m *migrationManager) applyMigration(name string, migration Migration, batch int) error {
+	return m.inTransaction(func(tm *migrationManager) error {
+		schema := NewSchemaBuilder(tm.db, tm.dialect)
+		if err := migration.Up(schema); err != nil {
+			return fmt.Errorf("error applying migration %s: %w", name, err)
+		}
+
+		// Записываем в таблицу миграций
+		return tm.recordMigration(name, batch, migrationVersion(migration), migrationHash(migration))
+	})
+}
+
+// revertMigration выполняет Down и удаляет запись из таблицы migrations
+func (m *migrationManager) revertMigration(name string, migration Migration) error {
+	return m.inTransaction(func(tm *migrationManager) error {
+		schema := NewSchemaBuilder(tm.db, tm.dialect)
+		if err := migration.Down(schema); err != nil {
+			return fmt.Errorf("error rolling back migration %s: %w", name, err)
+		}
+
+		// Удаляем запись из таблицы миграций
+		return tm.removeMigrationRecord(name)
+	})
+}
+
+// inTransaction вызывает fn с менеджером, работающим в транзакции, если
+// включен Transactional и диалект поддерживает DDL в транзакциях
+func (m *migrationManager) inTransaction(fn func(tm *migrationManager) error) error {
+	beginner, ok := m.db.(interface {
+		BeginTxx(ctx context.Context, opts *sql.TxOptions) (*sqlx.Tx, error)
+	})
+	if !m.config.Transactional || !m.dialect.SupportsDDLTransactions() || !ok {
+		return fn(m)
+	}
+
+	tx, err := beginner.BeginTxx(m.ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	tm := *m
+	tm.db = tx
+	if err := fn(&tm); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// migrationsTableUpgrades - колонки, добавленные в migrations после первой версии.
+// Таблицы, созданные старыми версиями, дополняются в upgradeMigrationsTable
+var migrationsTableUpgrades = []struct {
+	name       string
+	definition string
+}{
+	{"version", "VARCHAR(64) NULL"},
+	{"checksum", "VARCHAR(64) NULL"},
+}
+
 func (m *migrationManager) createMigrationsTable() error {
+	// Первичный ключ как в TableBuilder.ID: BIGSERIAL (PostgreSQL) и INTEGER PRIMARY KEY (SQLite)
+	// уже автоинкрементные, AUTO_INCREMENT нужен только MySQL
+	dataType := m.dialect.GetIDColumnType()
+	id := dataType + " PRIMARY KEY"
+	if !strings.HasSuffix(dataType, "SERIAL") && dataType != "INTEGER" {
+		id += " AUTO_INCREMENT"
+	}
 	query := `
 	CREATE TABLE IF NOT EXISTS migrations (
-		id INT AUTO_INCREMENT PRIMARY KEY,
+		id ` + id + `,
 		name VARCHAR(255) NOT NULL UNIQUE,
 		batch INT NOT NULL,
+		version VARCHAR(64) NULL,
+		checksum VARCHAR(64) NULL,
 		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 	)`
-	_, err := m.db.ExecContext(m.ctx, query)
-	return err
+	if _, err := m.db.ExecContext(m.ctx, query); err != nil {
+		return err
+	}
+	return m.upgradeMigrationsTable()
+}
+
+// upgradeMigrationsTable добавляет недостающие колонки в существующую таблицу migrations
+func (m *migrationManager) upgradeMigrationsTable() error {
+	rows, err := m.db.QueryContext(m.ctx, m.dialect.GetColumnsQuery("migrations"))
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]bool)
+	for rows.Next() {
+		var name, dataType string
+		if err := rows.Scan(&name, &dataType); err != nil {
+			rows.Close()
+			return err
+		}
+		existing[strings.ToLower(name)] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, column := range migrationsTableUpgrades {
+		if existing[column.name] {
+			continue
+		}
+		query := fmt.Sprintf("ALTER TABLE migrations ADD COLUMN %s %s", column.name, column.definition)
+		if _, err := m.db.ExecContext(m.ctx, query); err != nil {
+			return fmt.Errorf("error upgrading migrations table: %w", err)
+		}
+	}
+	return nil
 }
 
 func (m *migrationManager) dropMigrationsTable() error {
@@ -337,7 +556,7 @@ func (m *migrationManager) dropMigrationsTable() error {
 }
 
 func (m *migrationManager) getAppliedMigrations() ([]string, error) {
-	query := "SELECT name FROM migrations ORDER BY created_at ASC"
+	query := "SELECT name FROM migrations ORDER BY created_at ASC, id ASC"
 	rows, err := m.db.QueryContext(m.ctx, query)
 	if err != nil {
 		return nil, err
@@ -357,7 +576,7 @@ func (m *migrationManager) getAppliedMigrations() ([]string, error) {
 }
 
 func (m *migrationManager) getAppliedMigrationsByBatch(batch int) ([]string, error) {
-	query := "SELECT name FROM migrations WHERE batch = ? ORDER BY created_at ASC"
+	query := m.dialect.Rebind("SELECT name FROM migrations WHERE batch = ? ORDER BY created_at ASC, id ASC")
 	rows, err := m.db.QueryContext(m.ctx, query, batch)
 	if err != nil {
 		return nil, err
@@ -377,7 +596,7 @@ func (m *migrationManager) getAppliedMigrationsByBatch(batch int) ([]string, err
 }
 
 func (m *migrationManager) getMigrationStatuses() ([]MigrationStatus, error) {
-	query := "SELECT name, batch, created_at FROM migrations ORDER BY created_at ASC"
+	query := "SELECT name, batch, created_at, COALESCE(checksum, '') FROM migrations ORDER BY created_at ASC, id ASC"
 	rows, err := m.db.QueryContext(m.ctx, query)
 	if err != nil {
 		return nil, err
@@ -388,7 +607,7 @@ func (m *migrationManager) getMigrationStatuses() ([]MigrationStatus, error) {
 	for rows.Next() {
 		var status MigrationStatus
 		var appliedAt time.Time
-		if err := rows.Scan(&status.Name, &status.Batch, &appliedAt); err != nil {
+		if err := rows.Scan(&status.Name, &status.Batch, &appliedAt, &status.Checksum); err != nil {
 			return nil, err
 		}
 		status.Applied = true
@@ -419,14 +638,61 @@ func (m *migrationManager) getLastBatchNumber() (int, error) {
 	return batch, nil
 }
 
-func (m *migrationManager) recordMigration(name string, batch int) error {
-	query := "INSERT INTO migrations (name, batch) VALUES (?, ?)"
-	_, err := m.db.ExecContext(m.ctx, query, name, batch)
+func (m *migrationManager) recordMigration(name string, batch int, version, checksum string) error {
+	query := m.dialect.Rebind("INSERT INTO migrations (name, batch, version, checksum) VALUES (?, ?, ?, ?)")
+	var v, c any
+	if version != "" {
+		v = version
+	}
+	if checksum != "" {
+		c = checksum
+	}
+	_, err := m.db.ExecContext(m.ctx, query, name, batch, v, c)
 	return err
 }
 
+// verifyAppliedChecksums сверяет сохраненные checksum с текущими миграциями
+func (m *migrationManager) verifyAppliedChecksums() error {
+	query := "SELECT name, COALESCE(checksum, '') FROM migrations"
+	rows, err := m.db.QueryContext(m.ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	checksums := make(map[string]string)
+	for rows.Next() {
+		var name, checksum string
+		if err := rows.Scan(&name, &checksum); err != nil {
+			return err
+		}
+		checksums[name] = checksum
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	return m.compareChecksums(checksums)
+}
+
+// compareChecksums - миграции без сохраненного или без текущего hash не проверяются
+func (m *migrationManager) compareChecksums(checksums map[string]string) error {
+	for _, name := range m.order {
+		stored := checksums[name]
+		if stored == "" {
+			continue
+		}
+		current := migrationHash(m.migrations[name])
+		if current != "" && current != stored {
+			return fmt.Errorf("migration %s was modified after it was applied (stored %s, current %s): %w",
+				name, stored, current, ErrChecksumMismatch)
+		}
+	}
+	return nil
+}
+
 func (m *migrationManager) removeMigrationRecord(name string) error {
-	query := "DELETE FROM migrations WHERE name = ?"
+	query := m.dialect.Rebind("DELETE FROM migrations WHERE name = ?")
 	_, err := m.db.ExecContext(m.ctx, query, name)
 	return err
 }
@@ -440,3 +706,61 @@ func contains(slice []string, item string) bool {
 	}
 	return false
 }
+
+// migrationVersion возвращает версию миграции, если она реализует Versioned
+func migrationVersion(migration Migration) string {
+	if v, ok := migration.(Versioned); ok {
+		return v.Version()
+	}
+	return ""
+}
+
+// migrationHash возвращает hash миграции, если она реализует Hashed
+func migrationHash(migration Migration) string {
+	if h, ok := migration.(Hashed); ok {
+		return h.Hash()
+	}
+	return ""
+}
+
+// compareVersions сравнивает версии вида "v2.1.0" по числовым частям.
+// Возвращает -1, 0 или 1. Нечисловые части сравниваются как строки
+func compareVersions(a, b string) int {
+	aParts := strings.Split(strings.TrimPrefix(a, "v"), ".")
+	bParts := strings.Split(strings.TrimPrefix(b, "v"), ".")
+
+	for i := 0; i < len(aParts) || i < len(bParts); i++ {
+		var ap, bp string
+		if i < len(aParts) {
+			ap = aParts[i]
+		}
+		if i < len(bParts) {
+			bp = bParts[i]
+		}
+
+		an, aErr := strconv.Atoi(defaultString(ap, "0"))
+		bn, bErr := strconv.Atoi(defaultString(bp, "0"))
+		if aErr == nil && bErr == nil {
+			if an != bn {
+				if an < bn {
+					return -1
+				}
+				return 1
+			}
+			continue
+		}
+
+		if c := strings.Compare(ap, bp); c != 0 {
+			return c
+		}
+	}
+
+	return 0
+}
+
+func defaultString(s, def string) string {
+	if s == "" {
+		return def
+	}
+	return s
+}
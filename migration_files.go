@@ -0,0 +1,356 @@
+package querycraft
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Файловые миграции двух видов:
+//   - Go: <timestamp>_<name>.up.go / .down.go, создаются Create. Go-код нельзя загрузить
+//     во время выполнения без plugin (только linux/darwin и сборка тем же компилятором),
+//     поэтому Create генерирует в каталоге register.go с функцией Register, которая
+//     регистрирует все Go миграции каталога через RegisterMigration;
+//   - SQL: <timestamp>_<name>.up.sql / .down.sql, загружаются с диска и из embed.FS
+const (
+	migrationUpSuffix     = ".up.sql"
+	migrationDownSuffix   = ".down.sql"
+	migrationGoUpSuffix   = ".up.go"
+	migrationGoDownSuffix = ".down.go"
+	migrationRegisterFile = "register.go"
+)
+
+// sqlMigration - миграция из SQL файлов, запросы выполняются по порядку
+type sqlMigration struct {
+	up   []string
+	down []string
+}
+
+// rawExecer - SchemaBuilder, умеющий выполнять произвольный DDL
+type rawExecer interface {
+	execQuery(query string, args ...any) error
+}
+
+func (m *sqlMigration) Up(schema SchemaBuilder) error {
+	return execMigrationStatements(schema, m.up)
+}
+
+func (m *sqlMigration) Down(schema SchemaBuilder) error {
+	return execMigrationStatements(schema, m.down)
+}
+
+// Hash - SHA-256 запросов up миграции
+func (m *sqlMigration) Hash() string {
+	sum := sha256.Sum256([]byte(strings.Join(m.up, ";\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+func execMigrationStatements(schema SchemaBuilder, statements []string) error {
+	execer, ok := schema.(rawExecer)
+	if !ok {
+		return fmt.Errorf("schema builder %T cannot execute raw SQL", schema)
+	}
+	for _, stmt := range statements {
+		if err := execer.execQuery(stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Create создает заготовки Go миграции <timestamp>_<name>.up.go и .down.go
+// и перегенерирует register.go каталога. Если dir пустой, используется каталог из SetMigrationsDir
+func (m *migrationManager) Create(name string, dir string) error {
+	if dir == "" {
+		dir = m.migrationsDir
+	}
+	if dir == "" {
+		return fmt.Errorf("migrations directory is not set")
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	base := time.Now().Format("20060102150405") + "_" + name
+	typeName := toCamelCase(base)
+	files := map[string]string{
+		base + migrationGoUpSuffix:   renderMigrationSkeleton(typeName, name, "Up", true),
+		base + migrationGoDownSuffix: renderMigrationSkeleton(typeName, name, "Down", false),
+	}
+	for file, content := range files {
+		path := filepath.Join(dir, file)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			return fmt.Errorf("error writing migration %s: %w", path, err)
+		}
+	}
+
+	return writeMigrationRegister(dir)
+}
+
+// renderMigrationSkeleton - файл с методом method миграции typeName,
+// тип объявляется в файле up
+func renderMigrationSkeleton(typeName, name, method string, declareType bool) string {
+	var b strings.Builder
+	b.WriteString("package migrations\n\n")
+	b.WriteString("import \"github.com/antibomberman/querycraft\"\n\n")
+	if declareType {
+		fmt.Fprintf(&b, "// %s - миграция %s\n", typeName, name)
+		fmt.Fprintf(&b, "type %s struct{}\n\n", typeName)
+	}
+	fmt.Fprintf(&b, "func (m *%s) %s(schema querycraft.SchemaBuilder) error {\n", typeName, method)
+	b.WriteString("\treturn nil\n}\n")
+	return b.String()
+}
+
+// writeMigrationRegister генерирует register.go с функцией Register,
+// регистрирующей все Go миграции каталога в порядке имен файлов
+func writeMigrationRegister(dir string) error {
+	names, err := migrationNames(os.DirFS(dir), ".", migrationGoUpSuffix)
+	if err != nil {
+		return err
+	}
+
+	var b strings.Builder
+	b.WriteString("// Code generated by querycraft. DO NOT EDIT.\n\n")
+	b.WriteString("package migrations\n\n")
+	b.WriteString("import \"github.com/antibomberman/querycraft\"\n\n")
+	b.WriteString("// Register регистрирует Go миграции каталога\n")
+	b.WriteString("func Register(manager querycraft.MigrationManager) error {\n")
+	b.WriteString("\tmigrations := []struct {\n\t\tname      string\n\t\tmigration querycraft.Migration\n\t}{\n")
+	for _, name := range names {
+		fmt.Fprintf(&b, "\t\t{%s, &%s{}},\n", strconv.Quote(name), toCamelCase(name))
+	}
+	b.WriteString("\t}\n")
+	b.WriteString("\tfor _, m := range migrations {\n")
+	b.WriteString("\t\tif err := manager.RegisterMigration(m.name, m.migration); err != nil {\n")
+	b.WriteString("\t\t\treturn err\n\t\t}\n\t}\n")
+	b.WriteString("\treturn nil\n}\n")
+
+	path := filepath.Join(dir, migrationRegisterFile)
+	if err := os.WriteFile(path, []byte(b.String()), 0644); err != nil {
+		return fmt.Errorf("error writing migration register %s: %w", path, err)
+	}
+	return nil
+}
+
+// migrationNames - отсортированные имена файлов каталога с суффиксом suffix, без суффикса
+func migrationNames(fsys fs.FS, dir, suffix string) ([]string, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), suffix) {
+			names = append(names, strings.TrimSuffix(entry.Name(), suffix))
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// LoadMigrationsFromDir регистрирует SQL миграции из каталога
+func (m *migrationManager) LoadMigrationsFromDir(dir string) error {
+	return m.LoadMigrationsFromFS(os.DirFS(dir), ".")
+}
+
+// LoadMigrationsFromFS регистрирует SQL миграции из fs.FS (например, embed.FS).
+// Имя миграции - имя файла без .up.sql, файл .down.sql необязателен.
+// Go миграции (.up.go) должны быть уже зарегистрированы функцией Register из register.go
+func (m *migrationManager) LoadMigrationsFromFS(fsys fs.FS, dir string) error {
+	goNames, err := migrationNames(fsys, dir, migrationGoUpSuffix)
+	if err != nil {
+		return err
+	}
+	for _, name := range goNames {
+		if _, ok := m.migrations[name]; !ok {
+			return fmt.Errorf("go migration %s is not registered, call Register from %s", name, migrationRegisterFile)
+		}
+	}
+
+	names, err := migrationNames(fsys, dir, migrationUpSuffix)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		up, err := fs.ReadFile(fsys, path.Join(dir, name+migrationUpSuffix))
+		if err != nil {
+			return err
+		}
+		down, err := fs.ReadFile(fsys, path.Join(dir, name+migrationDownSuffix))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+
+		migration := &sqlMigration{
+			up:   splitSQLStatements(string(up)),
+			down: splitSQLStatements(string(down)),
+		}
+		if err := m.RegisterMigration(name, migration); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// splitSQLStatements делит файл на запросы по строкам, оканчивающимся на ";".
+// Строки-комментарии (--) пропускаются. Точка с запятой внутри строк, комментариев,
+// тел $$ ... $$ (PostgreSQL) и блоков BEGIN ... END (триггеры, процедуры) запрос не завершает
+func splitSQLStatements(content string) []string {
+	var statements []string
+	var current []string
+	var scanner sqlScanner
+
+	flush := func() {
+		stmt := strings.TrimSpace(strings.Join(current, "\n"))
+		stmt = strings.TrimSpace(strings.TrimSuffix(stmt, ";"))
+		if stmt != "" {
+			statements = append(statements, stmt)
+		}
+		current = current[:0]
+	}
+
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if scanner.outside() && (trimmed == "" || strings.HasPrefix(trimmed, "--")) {
+			continue
+		}
+		current = append(current, line)
+		scanner.scanLine(line)
+		if scanner.outside() && scanner.depth == 0 && strings.HasSuffix(trimmed, ";") {
+			flush()
+		}
+	}
+	flush()
+
+	return statements
+}
+
+// sqlScanner отслеживает между строками, находится ли текущая позиция внутри
+// строки, комментария, тела $tag$ или блока BEGIN/CASE ... END
+type sqlScanner struct {
+	quote        byte   // ', ", ` - открытая строка или идентификатор
+	blockComment bool   // /* ... */
+	dollarTag    string // $tag$ открытого тела функции
+	depth        int    // вложенность BEGIN/CASE ... END
+	pending      string // BEGIN или END, смысл которых зависит от следующего слова
+}
+
+func (s *sqlScanner) outside() bool {
+	return s.quote == 0 && !s.blockComment && s.dollarTag == ""
+}
+
+func (s *sqlScanner) scanLine(line string) {
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case s.blockComment:
+			if strings.HasPrefix(line[i:], "*/") {
+				s.blockComment = false
+				i++
+			}
+		case s.quote != 0:
+			// Удвоенная кавычка экранирует саму себя и обрабатывается как две строки подряд
+			if c == s.quote {
+				s.quote = 0
+			} else if c == '\\' && s.quote != '`' && i+1 < len(line) {
+				i++
+			}
+		case s.dollarTag != "":
+			if strings.HasPrefix(line[i:], s.dollarTag) {
+				i += len(s.dollarTag) - 1
+				s.dollarTag = ""
+			}
+		case strings.HasPrefix(line[i:], "--"):
+			return
+		case strings.HasPrefix(line[i:], "/*"):
+			s.blockComment = true
+			i++
+		case c == '\'' || c == '"' || c == '`':
+			s.quote = c
+		case c == '$':
+			if tag := dollarQuoteTag(line[i:]); tag != "" {
+				s.dollarTag = tag
+				i += len(tag) - 1
+			}
+		case c == ';':
+			s.word(";")
+		case isSQLWordByte(c):
+			j := i
+			for j < len(line) && isSQLWordByte(line[j]) {
+				j++
+			}
+			s.word(strings.ToUpper(line[i:j]))
+			i = j - 1
+		}
+	}
+}
+
+// word учитывает ключевые слова блоков. BEGIN; и BEGIN TRANSACTION открывают
+// транзакцию, а не блок; END IF, END LOOP и т.п. закрывают конструкции,
+// которые не считаются открывающими
+func (s *sqlScanner) word(w string) {
+	switch s.pending {
+	case "BEGIN":
+		s.pending = ""
+		switch w {
+		case ";", "TRANSACTION", "WORK", "DEFERRED", "IMMEDIATE", "EXCLUSIVE":
+		default:
+			s.depth++
+		}
+	case "END":
+		s.pending = ""
+		switch w {
+		case "IF", "LOOP", "WHILE", "REPEAT":
+			return
+		case "CASE":
+			// END CASE закрывает CASE
+			s.closeBlock()
+			return
+		}
+		s.closeBlock()
+	}
+
+	switch w {
+	case "BEGIN", "END":
+		s.pending = w
+	case "CASE":
+		s.depth++
+	}
+}
+
+func (s *sqlScanner) closeBlock() {
+	if s.depth > 0 {
+		s.depth--
+	}
+}
+
+// dollarQuoteTag возвращает $tag$ в начале строки или "" ($1 - не тег)
+func dollarQuoteTag(text string) string {
+	for i := 1; i < len(text); i++ {
+		c := text[i]
+		if c == '$' {
+			return text[:i+1]
+		}
+		if !isSQLWordByte(c) || (i == 1 && c >= '0' && c <= '9') {
+			return ""
+		}
+	}
+	return ""
+}
+
+func isSQLWordByte(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
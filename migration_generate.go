@@ -0,0 +1,360 @@
+package querycraft
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// schemaCall - вызов метода TableBuilder/ColumnBuilder, из которого
+// строится и SQL (dry-run), и исходный код миграции
+type schemaCall struct {
+	method string
+	args   []any
+}
+
+// schemaColumn - колонка модели: метод типа и цепочка модификаторов
+type schemaColumn struct {
+	calls []schemaCall
+}
+
+// CreateFromSchema генерирует исходный код миграции по тегам db и schema структуры.
+// Поддерживаемые опции тега schema (через ;): type:text|json|date|datetime|timestamp|decimal,
+// size:N, precision:N, scale:N, nullable, unique, index, primary, auto_increment, default:V, "-".
+// Если задан каталог миграций (SetMigrationsDir), файл <name>.go записывается в него
+func (m *migrationManager) CreateFromSchema(name string, model any) (string, error) {
+	t := reflect.TypeOf(model)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return "", fmt.Errorf("model must be a struct, got %T", model)
+	}
+
+	table := modelTableName(model, t)
+	columns, err := modelSchemaColumns(t)
+	if err != nil {
+		return "", err
+	}
+	if len(columns) == 0 {
+		return "", fmt.Errorf("model %s has no columns with db tag", t.Name())
+	}
+
+	// Dry-run: собираем SQL через TableBuilder без выполнения
+	builder := newTableBuilder(m.db, m.dialect, table)
+	for _, col := range columns {
+		col.apply(builder)
+	}
+	query, _ := builder.toSQL()
+	if builder.err != nil {
+		return "", builder.err
+	}
+
+	source := renderMigrationSource(name, table, query, columns)
+
+	if m.migrationsDir != "" {
+		path := filepath.Join(m.migrationsDir, name+".go")
+		if err := os.WriteFile(path, []byte(source), 0644); err != nil {
+			return "", fmt.Errorf("error writing migration %s: %w", path, err)
+		}
+	}
+
+	return source, nil
+}
+
+// modelTableName - TableName() модели или snake_case имени типа во множественном числе
+func modelTableName(model any, t reflect.Type) string {
+	if tn, ok := model.(interface{ TableName() string }); ok {
+		return tn.TableName()
+	}
+	return toSnakeCase(t.Name()) + "s"
+}
+
+func modelSchemaColumns(t reflect.Type) ([]schemaColumn, error) {
+	var columns []schemaColumn
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		column := strings.Split(field.Tag.Get("db"), ",")[0]
+		if column == "" || column == "-" {
+			continue
+		}
+
+		tag := field.Tag.Get("schema")
+		if tag == "-" {
+			continue
+		}
+
+		col, err := newSchemaColumn(column, field.Type, parseSchemaTag(tag))
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		columns = append(columns, col)
+	}
+
+	return columns, nil
+}
+
+// parseSchemaTag разбирает `schema:"size:100;nullable;default:0"`
+func parseSchemaTag(tag string) map[string]string {
+	opts := make(map[string]string)
+	for _, part := range strings.Split(tag, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		key, value, _ := strings.Cut(part, ":")
+		opts[strings.ToLower(strings.TrimSpace(key))] = strings.TrimSpace(value)
+	}
+	return opts
+}
+
+func newSchemaColumn(name string, fieldType reflect.Type, opts map[string]string) (schemaColumn, error) {
+	nullable := false
+	if fieldType.Kind() == reflect.Ptr {
+		nullable = true
+		fieldType = fieldType.Elem()
+	}
+
+	_, primary := opts["primary"]
+	_, autoIncrement := opts["auto_increment"]
+
+	// id BIGINT UNSIGNED PRIMARY KEY AUTO_INCREMENT
+	if name == "id" && opts["type"] == "" && isIntegerKind(fieldType.Kind()) && (primary || autoIncrement || len(opts) == 0) {
+		return schemaColumn{calls: []schemaCall{{method: "ID"}}}, nil
+	}
+
+	typeCall, err := schemaTypeCall(name, fieldType, opts)
+	if err != nil {
+		return schemaColumn{}, err
+	}
+	col := schemaColumn{calls: []schemaCall{typeCall}}
+
+	if _, ok := opts["nullable"]; ok || nullable {
+		col.calls = append(col.calls, schemaCall{method: "Nullable"})
+	}
+	if value, ok := opts["default"]; ok {
+		col.calls = append(col.calls, schemaCall{method: "Default", args: []any{parseDefaultValue(value)}})
+	}
+	if _, ok := opts["unique"]; ok {
+		col.calls = append(col.calls, schemaCall{method: "Unique"})
+	}
+	if _, ok := opts["index"]; ok {
+		col.calls = append(col.calls, schemaCall{method: "Index"})
+	}
+	if primary {
+		col.calls = append(col.calls, schemaCall{method: "Primary"})
+	}
+	if autoIncrement {
+		col.calls = append(col.calls, schemaCall{method: "AutoIncrement"})
+	}
+
+	return col, nil
+}
+
+func schemaTypeCall(name string, fieldType reflect.Type, opts map[string]string) (schemaCall, error) {
+	switch strings.ToLower(opts["type"]) {
+	case "text":
+		return schemaCall{method: "Text", args: []any{name}}, nil
+	case "json":
+		return schemaCall{method: "JSON", args: []any{name}}, nil
+	case "date":
+		return schemaCall{method: "Date", args: []any{name}}, nil
+	case "datetime":
+		return schemaCall{method: "DateTime", args: []any{name}}, nil
+	case "timestamp":
+		return schemaCall{method: "Timestamp", args: []any{name}}, nil
+	case "decimal":
+		return decimalCall(name, opts), nil
+	case "":
+	default:
+		return schemaCall{}, fmt.Errorf("unsupported schema type %q", opts["type"])
+	}
+
+	if fieldType == reflect.TypeOf(time.Time{}) {
+		return schemaCall{method: "Timestamp", args: []any{name}}, nil
+	}
+
+	switch fieldType.Kind() {
+	case reflect.String:
+		if size, err := strconv.Atoi(opts["size"]); err == nil && size > 0 {
+			return schemaCall{method: "String", args: []any{name, size}}, nil
+		}
+		return schemaCall{method: "String", args: []any{name}}, nil
+	case reflect.Bool:
+		return schemaCall{method: "Boolean", args: []any{name}}, nil
+	case reflect.Int64, reflect.Uint64:
+		return schemaCall{method: "BigInteger", args: []any{name}}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return schemaCall{method: "Integer", args: []any{name}}, nil
+	case reflect.Float32, reflect.Float64:
+		return decimalCall(name, opts), nil
+	case reflect.Map, reflect.Slice, reflect.Struct:
+		return schemaCall{method: "JSON", args: []any{name}}, nil
+	}
+
+	return schemaCall{}, fmt.Errorf("unsupported field type %s", fieldType)
+}
+
+func decimalCall(name string, opts map[string]string) schemaCall {
+	precision, scale := 10, 2
+	if p, err := strconv.Atoi(opts["precision"]); err == nil {
+		precision = p
+	}
+	if s, err := strconv.Atoi(opts["scale"]); err == nil {
+		scale = s
+	}
+	return schemaCall{method: "Decimal", args: []any{name, precision, scale}}
+}
+
+func isIntegerKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
+
+// parseDefaultValue - числа и bool передаются в Default без кавычек
+func parseDefaultValue(value string) any {
+	if i, err := strconv.Atoi(value); err == nil {
+		return i
+	}
+	if b, err := strconv.ParseBool(value); err == nil {
+		return b
+	}
+	return value
+}
+
+// apply вызывает методы колонки на TableBuilder
+func (c schemaColumn) apply(t *tableBuilder) {
+	for _, call := range c.calls {
+		switch call.method {
+		case "ID":
+			t.ID()
+		case "String":
+			if len(call.args) > 1 {
+				t.String(call.args[0].(string), call.args[1].(int))
+			} else {
+				t.String(call.args[0].(string))
+			}
+		case "Text":
+			t.Text(call.args[0].(string))
+		case "Integer":
+			t.Integer(call.args[0].(string))
+		case "BigInteger":
+			t.BigInteger(call.args[0].(string))
+		case "Decimal":
+			t.Decimal(call.args[0].(string), call.args[1].(int), call.args[2].(int))
+		case "Boolean":
+			t.Boolean(call.args[0].(string))
+		case "Date":
+			t.Date(call.args[0].(string))
+		case "DateTime":
+			t.DateTime(call.args[0].(string))
+		case "Timestamp":
+			t.Timestamp(call.args[0].(string))
+		case "JSON":
+			t.JSON(call.args[0].(string))
+		case "Nullable":
+			t.Nullable()
+		case "Default":
+			t.Default(call.args[0])
+		case "Unique":
+			t.Unique()
+		case "Index":
+			t.Index()
+		case "Primary":
+			t.Primary()
+		case "AutoIncrement":
+			t.AutoIncrement()
+		}
+	}
+}
+
+// code возвращает цепочку вызовов, например table.String("name", 100).Unique()
+func (c schemaColumn) code() string {
+	var b strings.Builder
+	b.WriteString("table")
+	for _, call := range c.calls {
+		args := make([]string, len(call.args))
+		for i, arg := range call.args {
+			args[i] = goLiteral(arg)
+		}
+		fmt.Fprintf(&b, ".%s(%s)", call.method, strings.Join(args, ", "))
+	}
+	return b.String()
+}
+
+func goLiteral(value any) string {
+	if s, ok := value.(string); ok {
+		return strconv.Quote(s)
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+func renderMigrationSource(name, table, query string, columns []schemaColumn) string {
+	typeName := toCamelCase(name)
+
+	var b strings.Builder
+	b.WriteString("package migrations\n\n")
+	b.WriteString("import \"github.com/antibomberman/querycraft\"\n\n")
+	fmt.Fprintf(&b, "// %s создает таблицу %s:\n//\n//\t%s\n", typeName, table, query)
+	fmt.Fprintf(&b, "type %s struct{}\n\n", typeName)
+
+	fmt.Fprintf(&b, "func (m *%s) Up(schema querycraft.SchemaBuilder) error {\n", typeName)
+	fmt.Fprintf(&b, "\treturn schema.CreateTable(%s, func(table querycraft.TableBuilder) {\n", strconv.Quote(table))
+	for _, col := range columns {
+		fmt.Fprintf(&b, "\t\t%s\n", col.code())
+	}
+	b.WriteString("\t})\n}\n\n")
+
+	fmt.Fprintf(&b, "func (m *%s) Down(schema querycraft.SchemaBuilder) error {\n", typeName)
+	fmt.Fprintf(&b, "\treturn schema.DropTable(%s)\n}\n", strconv.Quote(table))
+
+	return b.String()
+}
+
+// toSnakeCase: UserProfile -> user_profile
+func toSnakeCase(s string) string {
+	var b strings.Builder
+	runes := []rune(s)
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && (unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// toCamelCase: create_users_table -> CreateUsersTable
+func toCamelCase(s string) string {
+	var b strings.Builder
+	for _, part := range strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		runes := []rune(part)
+		runes[0] = unicode.ToUpper(runes[0])
+		b.WriteString(string(runes))
+	}
+	name := b.String()
+	if name == "" || unicode.IsDigit([]rune(name)[0]) {
+		name = "Migration" + name
+	}
+	return name
+}
@@ -1,13 +1,29 @@
 package querycraft
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"reflect"
+	"strings"
+	"time"
 
 	"github.com/antibomberman/querycraft/dialect"
+	"github.com/go-sql-driver/mysql"
 	"github.com/jmoiron/sqlx"
+	"golang.org/x/sync/errgroup"
 )
 
+// ErrUnknownDriver is returned by NewFromDB when the driver of *sql.DB cannot be detected
+var ErrUnknownDriver = errors.New("unknown driver")
+
+// ErrNotSupported is returned when the dialect cannot express the requested construct
+var ErrNotSupported = errors.New("not supported by dialect")
+
+// supportedDrivers lists driver names that have a dialect implementation
+var supportedDrivers = []string{"mysql", "postgres", "pgx", "sqlite3", "sqlite"}
+
 // Options represents the options for QueryCraft
 type Options struct {
 	// Logger options
@@ -18,6 +34,42 @@ type Options struct {
 	LogPrintToConsole bool
 	LogDir            string
 	LogAutoCleanDays  int
+
+	// WarmUpConnections opens this many pool connections inside New (0 disables warm-up)
+	WarmUpConnections int
+	// WarmUpTimeout bounds the warm-up inside New (0 means DefaultWarmUpTimeout)
+	WarmUpTimeout time.Duration
+
+	// TransactionalMigrations runs each migration of Migration() in its own transaction
+	// on dialects with transactional DDL (see WithTransactionalMigrations)
+	TransactionalMigrations bool
+
+	// DeadlockRetryBackoff is the delay before the second attempt of WithTransactionRetry,
+	// it doubles after every further deadlock (0 means DefaultDeadlockRetryBackoff)
+	DeadlockRetryBackoff time.Duration
+}
+
+// DefaultDeadlockRetryBackoff is used when Options.DeadlockRetryBackoff is not set
+const DefaultDeadlockRetryBackoff = 20 * time.Millisecond
+
+// DefaultWarmUpTimeout bounds the warm-up inside New when Options.WarmUpTimeout is not set
+const DefaultWarmUpTimeout = 30 * time.Second
+
+// WithWarmUpOnNew returns options that warm up the connection pool inside New
+func (o Options) WithWarmUpOnNew(connections int) Options {
+	o.WarmUpConnections = connections
+	return o
+}
+
+// TxOptions configures a transaction started by BeginTx or WithTransaction
+type TxOptions struct {
+	// IsolationLevel, sql.LevelDefault keeps the server default
+	IsolationLevel sql.IsolationLevel
+	ReadOnly       bool
+}
+
+func (o TxOptions) sqlOptions() *sql.TxOptions {
+	return &sql.TxOptions{Isolation: o.IsolationLevel, ReadOnly: o.ReadOnly}
 }
 
 type QueryCraft interface {
@@ -27,12 +79,19 @@ type QueryCraft interface {
 	Upsert(table string) UpsertBuilder
 	Update(table string) UpdateBuilder
 	Delete(table string) DeleteBuilder
+	Merge(table string) MergeBuilder
 
 	// Raw queries
 	Raw(query string, args ...any) Raw
+	BatchExec(queries []RawQuery) error
+	Rebind(sql string) string
+	BindNamed(sql string, arg any) (string, []any, error)
 
 	// Transactions
 	Begin() (Transaction, error)
+	BeginTx(opts TxOptions) (Transaction, error)
+	WithTransaction(ctx context.Context, fn func(Transaction) error, opts ...TxOptions) error
+	WithTransactionRetry(ctx context.Context, attempts int, fn func(Transaction) error) error
 	GetDB() *sqlx.DB
 
 	// Bulk operations
@@ -41,6 +100,12 @@ type QueryCraft interface {
 	// Schema and migrations
 	Schema() SchemaBuilder
 	Migration() MigrationManager
+
+	// Multi-schema databases
+	WithSchema(schemaName string) QueryCraft
+
+	// Connection pool
+	WarmUp(ctx context.Context, connections int) error
 }
 
 type queryCraft struct {
@@ -48,6 +113,12 @@ type queryCraft struct {
 	dialect    dialect.Dialect
 	migrations MigrationManager
 	logger     Logger
+
+	// defaultSchema is prepended to table names that have no schema
+	defaultSchema string
+
+	// deadlockRetryBackoff is the first delay of WithTransactionRetry
+	deadlockRetryBackoff time.Duration
 }
 
 // DefaultOptions returns the default options for QueryCraft
@@ -79,29 +150,98 @@ func New(driver string, db *sql.DB, opts ...Options) (QueryCraft, error) {
 	sqlxDB := sqlx.NewDb(db, driver).Unsafe()
 
 	qc := &queryCraft{
-		db:     sqlxDB,
-		logger: logger,
+		db:                   sqlxDB,
+		logger:               logger,
+		deadlockRetryBackoff: options.DeadlockRetryBackoff,
+	}
+	if qc.deadlockRetryBackoff <= 0 {
+		qc.deadlockRetryBackoff = DefaultDeadlockRetryBackoff
 	}
 
 	// Set dialect based on driver
+	d, err := dialectForDriver(driver)
+	if err != nil {
+		return nil, err
+	}
+	qc.dialect = d
+
+	// Initialize migration manager
+	qc.migrations = NewMigrationManager(qc.db, qc.dialect, WithTransactionalMigrations(options.TransactionalMigrations))
+
+	if options.WarmUpConnections > 0 {
+		timeout := options.WarmUpTimeout
+		if timeout <= 0 {
+			timeout = DefaultWarmUpTimeout
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		if err := qc.WarmUp(ctx, options.WarmUpConnections); err != nil {
+			return nil, fmt.Errorf("warm up: %w", err)
+		}
+	}
+
+	return qc, nil
+}
+
+// NewFromDB creates QueryCraft detecting the driver name from the *sql.DB itself.
+// The driver is matched against drivers registered via sql.Register.
+func NewFromDB(db *sql.DB, opts ...Options) (QueryCraft, error) {
+	driver, err := detectDriverName(db)
+	if err != nil {
+		return nil, err
+	}
+	return New(driver, db, opts...)
+}
+
+// dialectForDriver returns the dialect for the given driver name
+func dialectForDriver(driver string) (dialect.Dialect, error) {
 	switch driver {
 	case "mysql":
-		qc.dialect = &dialect.MySQLDialect{}
+		return &dialect.MySQLDialect{}, nil
+	case "postgres", "pgx":
+		return &dialect.PostgresDialect{}, nil
+	case "sqlite3", "sqlite":
+		return &dialect.SQLiteDialect{}, nil
 	default:
 		return nil, fmt.Errorf("unsupported driver: %s", driver)
 	}
+}
 
-	// Initialize migration manager
-	qc.migrations = NewMigrationManager(qc.db, qc.dialect)
+// detectDriverName finds the registered name of the driver used by db
+func detectDriverName(db *sql.DB) (string, error) {
+	if db == nil {
+		return "", fmt.Errorf("%w: db is nil", ErrUnknownDriver)
+	}
 
-	return qc, nil
+	driverType := reflect.TypeOf(db.Driver())
+	for _, name := range sql.Drivers() {
+		// sql.Open does not connect, it only looks up the registered driver
+		probe, err := sql.Open(name, "")
+		if err != nil {
+			continue
+		}
+		probeType := reflect.TypeOf(probe.Driver())
+		_ = probe.Close()
+
+		if probeType != driverType {
+			continue
+		}
+		for _, supported := range supportedDrivers {
+			if name == supported {
+				return name, nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("%w: %s, supported drivers: %s", ErrUnknownDriver, driverType, strings.Join(supportedDrivers, ", "))
 }
 
 func (qc *queryCraft) Select(columns ...string) SelectBuilder {
 	builder := NewSelectBuilder(qc.db, qc.dialect, columns...)
-	// Set logger if available
-	if qc.logger != nil {
-		if sb, ok := builder.(*selectBuilder); ok {
+	if sb, ok := builder.(*selectBuilder); ok {
+		sb.defaultSchema = qc.defaultSchema
+		// Set logger if available
+		if qc.logger != nil {
 			sb.setLogger(qc.logger)
 		}
 	}
@@ -109,7 +249,7 @@ func (qc *queryCraft) Select(columns ...string) SelectBuilder {
 }
 
 func (qc *queryCraft) Insert(table string) InsertBuilder {
-	builder := NewInsertBuilder(qc.db, qc.dialect, table)
+	builder := NewInsertBuilder(qc.db, qc.dialect, qualifyTable(qc.defaultSchema, table))
 	// Set logger if available
 	if qc.logger != nil {
 		if ib, ok := builder.(*insertBuilder); ok {
@@ -120,7 +260,7 @@ func (qc *queryCraft) Insert(table string) InsertBuilder {
 }
 
 func (qc *queryCraft) Upsert(table string) UpsertBuilder {
-	builder := NewUpsertBuilder(qc.db, qc.dialect, table)
+	builder := NewUpsertBuilder(qc.db, qc.dialect, qualifyTable(qc.defaultSchema, table))
 	// Set logger if available
 	if qc.logger != nil {
 		if ub, ok := builder.(*upsertBuilder); ok {
@@ -131,7 +271,7 @@ func (qc *queryCraft) Upsert(table string) UpsertBuilder {
 }
 
 func (qc *queryCraft) Update(table string) UpdateBuilder {
-	builder := NewUpdateBuilder(qc.db, qc.dialect, table)
+	builder := NewUpdateBuilder(qc.db, qc.dialect, qualifyTable(qc.defaultSchema, table))
 	// Set logger if available
 	if qc.logger != nil {
 		if ub, ok := builder.(*updateBuilder); ok {
@@ -142,7 +282,7 @@ func (qc *queryCraft) Update(table string) UpdateBuilder {
 }
 
 func (qc *queryCraft) Delete(table string) DeleteBuilder {
-	builder := NewDeleteBuilder(qc.db, qc.dialect, table)
+	builder := NewDeleteBuilder(qc.db, qc.dialect, qualifyTable(qc.defaultSchema, table))
 	// Set logger if available
 	if qc.logger != nil {
 		if db, ok := builder.(*deleteBuilder); ok {
@@ -152,6 +292,17 @@ func (qc *queryCraft) Delete(table string) DeleteBuilder {
 	return builder
 }
 
+func (qc *queryCraft) Merge(table string) MergeBuilder {
+	builder := NewMergeBuilder(qc.db, qc.dialect, qualifyTable(qc.defaultSchema, table))
+	// Set logger if available
+	if qc.logger != nil {
+		if mb, ok := builder.(*mergeBuilder); ok {
+			mb.setLogger(qc.logger)
+		}
+	}
+	return builder
+}
+
 func (qc *queryCraft) Raw(query string, args ...any) Raw {
 	builder := NewRaw(qc.db, query, args...)
 	// Set logger if available
@@ -163,8 +314,33 @@ func (qc *queryCraft) Raw(query string, args ...any) Raw {
 	return builder
 }
 
+// Rebind converts ? placeholders to the bindvar format of the driver ($1 for PostgreSQL)
+func (qc *queryCraft) Rebind(sql string) string {
+	return qc.db.Rebind(sql)
+}
+
+// BindNamed replaces :name parameters with driver placeholders, taking values from
+// a struct (db tags) or map[string]any
+func (qc *queryCraft) BindNamed(sql string, arg any) (string, []any, error) {
+	return qc.db.BindNamed(sql, arg)
+}
+
+// BatchExec executes queries in order and stops on the first error
+func (qc *queryCraft) BatchExec(queries []RawQuery) error {
+	return batchExec(context.Background(), qc.db, qc.logger, queries)
+}
+
 func (qc *queryCraft) Begin() (Transaction, error) {
-	tx, err := qc.db.Beginx()
+	return qc.beginTx(context.Background(), nil)
+}
+
+// BeginTx starts a transaction with the given isolation level and access mode
+func (qc *queryCraft) BeginTx(opts TxOptions) (Transaction, error) {
+	return qc.beginTx(context.Background(), opts.sqlOptions())
+}
+
+func (qc *queryCraft) beginTx(ctx context.Context, opts *sql.TxOptions) (Transaction, error) {
+	tx, err := qc.db.BeginTxx(ctx, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -178,6 +354,82 @@ func (qc *queryCraft) Begin() (Transaction, error) {
 	return transaction, nil
 }
 
+// WithTransaction runs fn inside a transaction. It commits when fn returns nil
+// and rolls back when fn returns an error or panics; the rollback error is logged
+// and fn's error is returned. opts may set the isolation level or read-only mode,
+// e.g. REPEATABLE READ for consistent reports
+func (qc *queryCraft) WithTransaction(ctx context.Context, fn func(Transaction) error, opts ...TxOptions) error {
+	var txOpts *sql.TxOptions
+	if len(opts) > 0 {
+		txOpts = opts[0].sqlOptions()
+	}
+
+	tx, err := qc.beginTx(ctx, txOpts)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			qc.rollback(tx)
+			panic(p)
+		}
+	}()
+
+	if err := fn(tx); err != nil {
+		qc.rollback(tx)
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// WithTransactionRetry is WithTransaction that reruns the whole transaction when fn
+// or the commit fails with a deadlock (MySQL 1213, PostgreSQL 40P01), up to attempts times
+func (qc *queryCraft) WithTransactionRetry(ctx context.Context, attempts int, fn func(Transaction) error) error {
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	backoff := qc.deadlockRetryBackoff
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		err = qc.WithTransaction(ctx, fn)
+		if err == nil || !isDeadlock(err) || attempt == attempts {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+	return err
+}
+
+// isDeadlock reports whether err is a deadlock chosen by the server as victim
+func isDeadlock(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return mysqlErr.Number == 1213
+	}
+	// lib/pq and pgx expose the SQLSTATE code through SQLState()
+	var pgErr interface{ SQLState() string }
+	if errors.As(err, &pgErr) {
+		return pgErr.SQLState() == "40P01"
+	}
+	return false
+}
+
+// rollback rolls tx back and reports a failed rollback to the logger
+func (qc *queryCraft) rollback(tx Transaction) {
+	start := time.Now()
+	if err := tx.Rollback(); err != nil && qc.logger != nil {
+		logQuery(qc.logger, context.Background(), "ROLLBACK", nil, time.Since(start), err)
+	}
+}
+
 func (qc *queryCraft) GetDB() *sqlx.DB {
 	return qc.db
 }
@@ -208,6 +460,51 @@ func (qc *queryCraft) Migration() MigrationManager {
 	return qc.migrations
 }
 
+// WithSchema returns a copy of QueryCraft that prefixes table names with schemaName.
+// Tables that already contain a schema ("other.table") are left unchanged.
+func (qc *queryCraft) WithSchema(schemaName string) QueryCraft {
+	clone := *qc
+	clone.defaultSchema = schemaName
+	return &clone
+}
+
+// WarmUp concurrently opens connections and runs SELECT 1 on each, so the pool
+// holds that many connections before serving traffic. The pool keeps them only
+// up to its idle limit, see sql.DB.SetMaxIdleConns. connections is capped at
+// sql.DB.SetMaxOpenConns, since holding more would block forever.
+func (qc *queryCraft) WarmUp(ctx context.Context, connections int) error {
+	if connections < 0 {
+		return fmt.Errorf("warm up: connections must not be negative, got %d", connections)
+	}
+	if maxOpen := qc.db.Stats().MaxOpenConnections; maxOpen > 0 && connections > maxOpen {
+		connections = maxOpen
+	}
+
+	conns := make([]*sql.Conn, connections)
+	g, gctx := errgroup.WithContext(ctx)
+	for i := range conns {
+		g.Go(func() error {
+			conn, err := qc.db.Conn(gctx)
+			if err != nil {
+				return err
+			}
+			conns[i] = conn
+			_, err = conn.ExecContext(gctx, "SELECT 1")
+			return err
+		})
+	}
+	// Connections are held until all are open, otherwise the pool would reuse one
+	err := g.Wait()
+
+	for _, conn := range conns {
+		if conn != nil {
+			_ = conn.Close()
+		}
+	}
+
+	return err
+}
+
 func (qc *queryCraft) SetLogger(logger Logger) QueryCraft {
 	qc.logger = logger
 	return qc
@@ -23,7 +23,10 @@ type Raw interface {
 	WithContext(ctx context.Context) Raw
 	Args() []any
 	Query() string
+	Rebind() Raw
+	NormalizedSQL() string
 	PrintSQL() Raw
+	PrettyPrint() Raw
 }
 
 type rawQuery struct {
@@ -34,7 +37,8 @@ type rawQuery struct {
 	logger Logger
 
 	// Print SQL flag
-	printSQL bool
+	printSQL  bool
+	prettySQL bool
 }
 
 func NewRaw(db SQLXExecutor, query string, args ...any) Raw {
@@ -54,12 +58,16 @@ func (r *rawQuery) One(dest any) error {
 		for _, arg := range r.args {
 			formattedSQL = strings.Replace(formattedSQL, "?", fmt.Sprintf("'%v'", arg), 1)
 		}
+		if r.prettySQL {
+			formattedSQL = FormatSQL(formattedSQL)
+		}
 		fmt.Println(formattedSQL)
 	}
 
 	// Log query if logger is set
 	var start time.Time
 	if r.logger != nil {
+		logQueryStart(r.logger, r.ctx, r.query, r.args)
 		start = time.Now()
 	}
 
@@ -68,7 +76,7 @@ func (r *rawQuery) One(dest any) error {
 	// Log query execution
 	if r.logger != nil {
 		duration := time.Since(start)
-		r.logger.LogQuery(r.ctx, r.query, r.args, duration, err)
+		logQuery(r.logger, r.ctx, r.query, r.args, duration, err)
 	}
 
 	return err
@@ -82,12 +90,16 @@ func (r *rawQuery) All(dest any) error {
 		for _, arg := range r.args {
 			formattedSQL = strings.Replace(formattedSQL, "?", fmt.Sprintf("'%v'", arg), 1)
 		}
+		if r.prettySQL {
+			formattedSQL = FormatSQL(formattedSQL)
+		}
 		fmt.Println(formattedSQL)
 	}
 
 	// Log query if logger is set
 	var start time.Time
 	if r.logger != nil {
+		logQueryStart(r.logger, r.ctx, r.query, r.args)
 		start = time.Now()
 	}
 
@@ -96,7 +108,7 @@ func (r *rawQuery) All(dest any) error {
 	// Log query execution
 	if r.logger != nil {
 		duration := time.Since(start)
-		r.logger.LogQuery(r.ctx, r.query, r.args, duration, err)
+		logQuery(r.logger, r.ctx, r.query, r.args, duration, err)
 	}
 
 	return err
@@ -110,12 +122,16 @@ func (r *rawQuery) Row() (map[string]any, error) {
 		for _, arg := range r.args {
 			formattedSQL = strings.Replace(formattedSQL, "?", fmt.Sprintf("'%v'", arg), 1)
 		}
+		if r.prettySQL {
+			formattedSQL = FormatSQL(formattedSQL)
+		}
 		fmt.Println(formattedSQL)
 	}
 
 	// Log query if logger is set
 	var start time.Time
 	if r.logger != nil {
+		logQueryStart(r.logger, r.ctx, r.query, r.args)
 		start = time.Now()
 	}
 
@@ -124,7 +140,7 @@ func (r *rawQuery) Row() (map[string]any, error) {
 		// Log query execution
 		if r.logger != nil {
 			duration := time.Since(start)
-			r.logger.LogQuery(r.ctx, r.query, r.args, duration, err)
+			logQuery(r.logger, r.ctx, r.query, r.args, duration, err)
 		}
 		return nil, err
 	}
@@ -136,7 +152,7 @@ func (r *rawQuery) Row() (map[string]any, error) {
 			// Log query execution
 			if r.logger != nil {
 				duration := time.Since(start)
-				r.logger.LogQuery(r.ctx, r.query, r.args, duration, err)
+				logQuery(r.logger, r.ctx, r.query, r.args, duration, err)
 			}
 			return nil, err
 		}
@@ -144,7 +160,7 @@ func (r *rawQuery) Row() (map[string]any, error) {
 		// Log query execution
 		if r.logger != nil {
 			duration := time.Since(start)
-			r.logger.LogQuery(r.ctx, r.query, r.args, duration, nil)
+			logQuery(r.logger, r.ctx, r.query, r.args, duration, nil)
 		}
 
 		return convertByteArrayToString(row), nil
@@ -153,7 +169,7 @@ func (r *rawQuery) Row() (map[string]any, error) {
 	// Log query execution
 	if r.logger != nil {
 		duration := time.Since(start)
-		r.logger.LogQuery(r.ctx, r.query, r.args, duration, sql.ErrNoRows)
+		logQuery(r.logger, r.ctx, r.query, r.args, duration, sql.ErrNoRows)
 	}
 
 	return nil, sql.ErrNoRows
@@ -167,12 +183,16 @@ func (r *rawQuery) Rows() ([]map[string]any, error) {
 		for _, arg := range r.args {
 			formattedSQL = strings.Replace(formattedSQL, "?", fmt.Sprintf("'%v'", arg), 1)
 		}
+		if r.prettySQL {
+			formattedSQL = FormatSQL(formattedSQL)
+		}
 		fmt.Println(formattedSQL)
 	}
 
 	// Log query if logger is set
 	var start time.Time
 	if r.logger != nil {
+		logQueryStart(r.logger, r.ctx, r.query, r.args)
 		start = time.Now()
 	}
 
@@ -181,7 +201,7 @@ func (r *rawQuery) Rows() ([]map[string]any, error) {
 		// Log query execution
 		if r.logger != nil {
 			duration := time.Since(start)
-			r.logger.LogQuery(r.ctx, r.query, r.args, duration, err)
+			logQuery(r.logger, r.ctx, r.query, r.args, duration, err)
 		}
 		return nil, err
 	}
@@ -194,7 +214,7 @@ func (r *rawQuery) Rows() ([]map[string]any, error) {
 			// Log query execution
 			if r.logger != nil {
 				duration := time.Since(start)
-				r.logger.LogQuery(r.ctx, r.query, r.args, duration, err)
+				logQuery(r.logger, r.ctx, r.query, r.args, duration, err)
 			}
 			return nil, err
 		}
@@ -204,7 +224,7 @@ func (r *rawQuery) Rows() ([]map[string]any, error) {
 	// Log query execution
 	if r.logger != nil {
 		duration := time.Since(start)
-		r.logger.LogQuery(r.ctx, r.query, r.args, duration, nil)
+		logQuery(r.logger, r.ctx, r.query, r.args, duration, nil)
 	}
 
 	return results, nil
@@ -218,12 +238,16 @@ func (r *rawQuery) Exec() (sql.Result, error) {
 		for _, arg := range r.args {
 			formattedSQL = strings.Replace(formattedSQL, "?", fmt.Sprintf("'%v'", arg), 1)
 		}
+		if r.prettySQL {
+			formattedSQL = FormatSQL(formattedSQL)
+		}
 		fmt.Println(formattedSQL)
 	}
 
 	// Log query if logger is set
 	var start time.Time
 	if r.logger != nil {
+		logQueryStart(r.logger, r.ctx, r.query, r.args)
 		start = time.Now()
 	}
 
@@ -232,7 +256,7 @@ func (r *rawQuery) Exec() (sql.Result, error) {
 	// Log query execution
 	if r.logger != nil {
 		duration := time.Since(start)
-		r.logger.LogQuery(r.ctx, r.query, r.args, duration, err)
+		logQuery(r.logger, r.ctx, r.query, r.args, duration, err)
 	}
 
 	return result, err
@@ -251,11 +275,29 @@ func (r *rawQuery) Query() string {
 	return r.query
 }
 
+// Rebind заменяет ? на плейсхолдеры драйвера ($1 для PostgreSQL)
+func (r *rawQuery) Rebind() Raw {
+	r.query = r.db.Rebind(r.query)
+	return r
+}
+
+// NormalizedSQL возвращает канонический SQL без значений (см. NormalizeSQL)
+func (r *rawQuery) NormalizedSQL() string {
+	return NormalizeSQL(r.query)
+}
+
 func (r *rawQuery) PrintSQL() Raw {
 	r.printSQL = true
 	return r
 }
 
+// PrettyPrint печатает SQL в многострочном виде (см. FormatSQL)
+func (r *rawQuery) PrettyPrint() Raw {
+	r.printSQL = true
+	r.prettySQL = true
+	return r
+}
+
 func (r *rawQuery) setLogger(logger Logger) {
 	r.logger = logger
 }
@@ -3,11 +3,13 @@ package querycraft
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/antibomberman/querycraft/dialect"
+	"github.com/jmoiron/sqlx"
 )
 
 type TableInfo struct {
@@ -24,16 +26,98 @@ type IndexInfo struct {
 	Columns []string
 }
 
+// ConstraintInfo - именованное ограничение таблицы.
+// Type: PRIMARY KEY, UNIQUE, FOREIGN KEY или CHECK
+type ConstraintInfo struct {
+	Name              string
+	Type              string
+	Columns           []string
+	ReferencedTable   *string
+	ReferencedColumns []string
+	CheckClause       *string
+}
+
+// ForeignKeyInfo - колонка внешнего ключа, для составных ключей по записи на колонку
+type ForeignKeyInfo struct {
+	Name      string
+	Column    string
+	RefTable  string
+	RefColumn string
+	OnDelete  string
+	OnUpdate  string
+}
+
+type TableSizeInfo struct {
+	Name       string
+	DataBytes  int64
+	IndexBytes int64
+	TotalBytes int64
+	RowCount   int64
+}
+
+// IndexOption - опции для CreateIndex
+type IndexOption func(*IndexConfig)
+
+type IndexConfig struct {
+	Name   string // по умолчанию table_col1_col2_index (или _unique)
+	Unique bool
+	Where  string // условие частичного индекса (PostgreSQL, SQLite)
+	Method string // BTREE, HASH, GIN, GIST
+}
+
+func IndexUnique() IndexOption {
+	return func(config *IndexConfig) {
+		config.Unique = true
+	}
+}
+
+func IndexName(name string) IndexOption {
+	return func(config *IndexConfig) {
+		config.Name = name
+	}
+}
+
+func IndexPartial(condition string) IndexOption {
+	return func(config *IndexConfig) {
+		config.Where = condition
+	}
+}
+
+func IndexUsing(method string) IndexOption {
+	return func(config *IndexConfig) {
+		config.Method = method
+	}
+}
+
 type SchemaBuilder interface {
 	// Управление таблицами
 	CreateTable(name string, callback func(TableBuilder)) error
+	CreateTableIfNotExists(name string, callback func(TableBuilder)) error
 	AlterTable(name string, callback func(TableBuilder)) error
 	DropTable(name string) error
+	DropTableIfExists(name string) error
+	DropTableCascade(name string) error
+	DropTableRestrict(name string) error
 	RenameTable(from, to string) error
 	ClearTable(table string) error
 
+	// Представления
+	CreateView(name string, query SelectBuilder) error
+	CreateOrReplaceView(name string, query SelectBuilder) error
+	DropView(name string) error
+
+	// Индексы без AlterTable
+	CreateIndex(table string, columns []string, opts ...IndexOption) error
+	DropIndex(table, indexName string) error
+
+	// Колонки без AlterTable
+	AddColumn(table, name, columnType string, modifiers ...string) error
+	RenameColumn(table, from, to string) error
+	DropColumn(table, column string) error
+
 	// Проверки существования
 	HasTable(name string) (bool, error)
+	HasView(name string) (bool, error)
 	HasColumn(table, column string) (bool, error)
 	HasIndex(table, index string) (bool, error)
 
@@ -41,47 +125,99 @@ type SchemaBuilder interface {
 	GetTables() ([]TableInfo, error)
 	GetColumns(table string) ([]ColumnInfo, error)
 	GetIndexes(table string) ([]IndexInfo, error)
+	GetConstraints(table string) ([]ConstraintInfo, error)
+	GetConstraint(table, name string) (*ConstraintInfo, error)
+	GetForeignKeys(table string) ([]ForeignKeyInfo, error)
+
+	// Размер хранилища
+	GetTableSize(table string) (TableSizeInfo, error)
+	GetDatabaseSize() (int64, error)
+	GetIndexSize(table, index string) (int64, error)
+	GetTopTablesBySize(limit int) ([]TableSizeInfo, error)
 
 	WithContext(ctx context.Context) SchemaBuilder
 }
 type TableBuilder interface {
 	// Колонки
 	ID() TableBuilder                                        // auto increment primary key
+	UUIDPrimary() TableBuilder                               // UUID primary key
 	String(name string, length ...int) ColumnBuilder         // VARCHAR
 	Text(name string) ColumnBuilder                          // TEXT
 	Integer(name string) ColumnBuilder                       // INT
 	BigInteger(name string) ColumnBuilder                    // BIGINT
+	SmallInteger(name string) ColumnBuilder                  // SMALLINT
+	TinyInteger(name string) ColumnBuilder                   // TINYINT
+	MediumInteger(name string) ColumnBuilder                 // MEDIUMINT
+	Float(name string) ColumnBuilder                         // FLOAT
+	Double(name string) ColumnBuilder                        // DOUBLE
+	Char(name string, length int) ColumnBuilder              // CHAR
+	Binary(name string, length int) ColumnBuilder            // BINARY
+	Blob(name string) ColumnBuilder                          // BLOB
+	UUID(name string) ColumnBuilder                          // UUID (CHAR(36) в MySQL)
 	Decimal(name string, precision, scale int) ColumnBuilder // DECIMAL
 	Boolean(name string) ColumnBuilder                       // BOOLEAN
 	Date(name string) ColumnBuilder                          // DATE
 	DateTime(name string) ColumnBuilder                      // DATETIME
 	Timestamp(name string) ColumnBuilder                     // TIMESTAMP
 	JSON(name string) ColumnBuilder                          // JSON
+	Point(name string) ColumnBuilder                         // POINT
+	LineString(name string) ColumnBuilder                    // LINESTRING
+	Polygon(name string) ColumnBuilder                       // POLYGON
+	Geometry(name string) ColumnBuilder                      // GEOMETRY
 	Enum(name string, values ...string) ColumnBuilder        // ENUM
 	Set(name string, values ...string) ColumnBuilder         // SET
 	Unsigned() ColumnBuilder                                 // UNSIGNED (для числовых)
 	OnDelete(action string) TableBuilder                     // ON DELETE для последнего внешнего ключа
 	OnUpdate(action string) TableBuilder                     // ON UPDATE для последнего внешнего ключа
 
+	// Вычисляемые колонки: GENERATED ALWAYS AS (expression) STORED/VIRTUAL,
+	// Default и AutoIncrement для них игнорируются
+	Generated(name string, dataType string, expression string, stored bool) ColumnBuilder
+
 	// Специальные колонки
 	Timestamps() TableBuilder  // created_at, updated_at
 	SoftDeletes() TableBuilder // deleted_at
 
+	// Комментарий к таблице: COMMENT = '...' (MySQL) или COMMENT ON TABLE (PostgreSQL)
+	TableComment(comment string) TableBuilder
+
 	// Индексы
 	AddIndex(name string, columns ...string) TableBuilder
 	UniqueIndex(columns ...string) TableBuilder
 	PrimaryKey(columns ...string) TableBuilder
-	ForeignKey(column, refTable, refColumn string) TableBuilder
+	AddSpatialIndex(columns ...string) TableBuilder
+	ForeignKey(column, refTable, refColumn string) ForeignKeyBuilder
+	Check(name, expression string) TableBuilder
 	//HasIndex(table string, index string) (bool, error)
 
+	// Изменение существующей колонки (только AlterTable), тип задается через Type
+	ModifyColumn(name string) ColumnBuilder
+
 	// Удаление
 	DropColumn(name string) TableBuilder
 	DropIndex(name string) TableBuilder
 	DropForeign(name string) TableBuilder
+	DropCheck(name string) TableBuilder
 	RenameColumn(from, to string) TableBuilder
 	RenameIndex(from, to string) TableBuilder
 }
 
+// Действия внешнего ключа для OnDelete/OnUpdate
+const (
+	CascadeAction    = "CASCADE"
+	SetNullAction    = "SET NULL"
+	SetDefaultAction = "SET DEFAULT"
+	RestrictAction   = "RESTRICT"
+	NoActionAction   = "NO ACTION"
+)
+
+// ForeignKeyBuilder - действия для только что объявленного внешнего ключа.
+// action: CascadeAction, SetNullAction, SetDefaultAction, RestrictAction, NoActionAction
+type ForeignKeyBuilder interface {
+	OnDelete(action string) TableBuilder
+	OnUpdate(action string) TableBuilder
+}
+
 type ColumnBuilder interface {
 	Nullable() ColumnBuilder
 	NotNull() ColumnBuilder
@@ -91,6 +227,8 @@ type ColumnBuilder interface {
 	Primary() ColumnBuilder
 	AutoIncrement() ColumnBuilder
 	Comment(comment string) ColumnBuilder
+	Type(dataType string) ColumnBuilder
+	SRID(srid int) ColumnBuilder       // для пространственных колонок
 	After(column string) ColumnBuilder // MySQL
 	First() ColumnBuilder              // MySQL
 }
@@ -117,26 +255,35 @@ func (s *schemaBuilder) WithContext(ctx context.Context) SchemaBuilder {
 
 // Управление таблицами
 func (s *schemaBuilder) CreateTable(name string, callback func(TableBuilder)) error {
+	return s.createTable(name, callback, false)
+}
+
+// CreateTableIfNotExists - CREATE TABLE IF NOT EXISTS, существующая таблица не изменяется
+func (s *schemaBuilder) CreateTableIfNotExists(name string, callback func(TableBuilder)) error {
+	return s.createTable(name, callback, true)
+}
+
+func (s *schemaBuilder) createTable(name string, callback func(TableBuilder), ifNotExists bool) error {
 	builder := newTableBuilder(s.db, s.dialect, name)
+	builder.ifNotExists = ifNotExists
 	callback(builder)
 
 	query, args := builder.toSQL()
-
-	// Log query if logger is set
-	var start time.Time
-	if s.logger != nil {
-		start = time.Now()
+	if builder.err != nil {
+		return builder.err
 	}
-
-	_, err := s.db.ExecContext(s.ctx, query, args...)
-
-	// Log query execution
-	if s.logger != nil {
-		duration := time.Since(start)
-		s.logger.LogQuery(s.ctx, query, args, duration, err)
+	if query != "" {
+		if err := s.execQuery(query, args...); err != nil {
+			return err
+		}
 	}
 
-	return err
+	for _, stmt := range builder.afterStatements() {
+		if err := s.execQuery(stmt); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (s *schemaBuilder) AlterTable(name string, callback func(TableBuilder)) error {
@@ -145,39 +292,104 @@ func (s *schemaBuilder) AlterTable(name string, callback func(TableBuilder)) err
 	callback(builder)
 
 	query, args := builder.toSQL()
+	if builder.err != nil {
+		return builder.err
+	}
+	if query != "" {
+		if err := s.execQuery(query, args...); err != nil {
+			return err
+		}
+	}
 
-	// Log query if logger is set
-	var start time.Time
-	if s.logger != nil {
-		start = time.Now()
+	for _, stmt := range builder.afterStatements() {
+		if err := s.execQuery(stmt); err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
-	_, err := s.db.ExecContext(s.ctx, query, args...)
+// DropTable удаляет таблицу в режиме RESTRICT, чтобы не удалить связанные данные
+func (s *schemaBuilder) DropTable(name string) error {
+	return s.DropTableRestrict(name)
+}
 
-	// Log query execution
-	if s.logger != nil {
-		duration := time.Since(start)
-		s.logger.LogQuery(s.ctx, query, args, duration, err)
+// DropTableIfExists - DROP TABLE IF EXISTS, отсутствие таблицы не считается ошибкой
+func (s *schemaBuilder) DropTableIfExists(name string) error {
+	return s.execQuery(fmt.Sprintf("DROP TABLE IF EXISTS %s", s.dialect.QuoteIdentifier(name)))
+}
+
+// DropTableRestrict - DROP TABLE ... RESTRICT. Если на таблицу ссылаются
+// внешние ключи, ошибка содержит список зависимых таблиц
+func (s *schemaBuilder) DropTableRestrict(name string) error {
+	err := s.execQuery(s.dialect.DropTableSQL(name, false))
+	if err == nil {
+		return nil
 	}
 
+	dependents, depErr := s.dependentTables(name)
+	if depErr != nil || len(dependents) == 0 {
+		return err
+	}
+	return fmt.Errorf("cannot drop table %s, it is referenced by: %s: %w", name, strings.Join(dependents, ", "), err)
+}
+
+// DropTableCascade удаляет таблицу вместе со ссылками на нее:
+// PostgreSQL - DROP TABLE ... CASCADE, MySQL - с отключенными FOREIGN_KEY_CHECKS
+func (s *schemaBuilder) DropTableCascade(name string) error {
+	query := s.dialect.DropTableSQL(name, true)
+
+	disable := s.dialect.ForeignKeyChecksSQL(false)
+	if disable == "" {
+		return s.execQuery(query)
+	}
+
+	// SET FOREIGN_KEY_CHECKS действует на сессию, поэтому все запросы
+	// должны идти через одно соединение
+	db := s.db
+	if pool, ok := s.db.(*sqlx.DB); ok {
+		conn, err := pool.Connx(s.ctx)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+		db = &connExecutor{Conn: conn, driverName: pool.DriverName()}
+	}
+
+	session := &schemaBuilder{db: db, dialect: s.dialect, ctx: s.ctx, logger: s.logger}
+	if err := session.execQuery(disable); err != nil {
+		return err
+	}
+	err := session.execQuery(query)
+	if enableErr := session.execQuery(s.dialect.ForeignKeyChecksSQL(true)); err == nil {
+		err = enableErr
+	}
 	return err
 }
 
-func (s *schemaBuilder) DropTable(name string) error {
-	query := fmt.Sprintf("DROP TABLE %s", s.dialect.QuoteIdentifier(name))
+func (s *schemaBuilder) dependentTables(name string) ([]string, error) {
+	var tables []string
+	if err := s.db.SelectContext(s.ctx, &tables, s.dialect.DependentTablesQuery(name)); err != nil {
+		return nil, err
+	}
+	return tables, nil
+}
 
+// execQuery выполняет DDL запрос с логированием
+func (s *schemaBuilder) execQuery(query string, args ...any) error {
 	// Log query if logger is set
 	var start time.Time
 	if s.logger != nil {
+		logQueryStart(s.logger, s.ctx, query, args)
 		start = time.Now()
 	}
 
-	_, err := s.db.ExecContext(s.ctx, query)
+	_, err := s.db.ExecContext(s.ctx, query, args...)
 
 	// Log query execution
 	if s.logger != nil {
 		duration := time.Since(start)
-		s.logger.LogQuery(s.ctx, query, nil, duration, err)
+		logQuery(s.logger, s.ctx, query, args, duration, err)
 	}
 
 	return err
@@ -190,6 +402,7 @@ func (s *schemaBuilder) RenameTable(from, to string) error {
 	// Log query if logger is set
 	var start time.Time
 	if s.logger != nil {
+		logQueryStart(s.logger, s.ctx, query, nil)
 		start = time.Now()
 	}
 
@@ -198,7 +411,7 @@ func (s *schemaBuilder) RenameTable(from, to string) error {
 	// Log query execution
 	if s.logger != nil {
 		duration := time.Since(start)
-		s.logger.LogQuery(s.ctx, query, nil, duration, err)
+		logQuery(s.logger, s.ctx, query, nil, duration, err)
 	}
 
 	return err
@@ -222,6 +435,18 @@ func (s *schemaBuilder) HasTable(name string) (bool, error) {
 	return exists, nil
 }
 
+func (s *schemaBuilder) HasView(name string) (bool, error) {
+	var exists bool
+	err := s.db.GetContext(s.ctx, &exists, s.dialect.HasViewQuery(name))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, err
+	}
+	return exists, nil
+}
+
 func (s *schemaBuilder) HasColumn(table, column string) (bool, error) {
 	query := s.dialect.HasColumnQuery(table, column)
 
@@ -343,12 +568,155 @@ func (s *schemaBuilder) GetIndexes(table string) ([]IndexInfo, error) {
 
 	return indexes, nil
 }
+
+func (s *schemaBuilder) GetConstraints(table string) ([]ConstraintInfo, error) {
+	query := s.dialect.GetConstraintsQuery(table)
+
+	rows, err := s.db.QueryContext(s.ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var constraints []ConstraintInfo
+	for rows.Next() {
+		var name, constraintType string
+		var column, refTable, refColumn, checkClause sql.NullString
+
+		if err := rows.Scan(&name, &constraintType, &column, &refTable, &refColumn, &checkClause); err != nil {
+			return nil, err
+		}
+
+		// Строки идут по одной на колонку, сгруппированные по имени ограничения
+		if len(constraints) == 0 || constraints[len(constraints)-1].Name != name {
+			constraints = append(constraints, ConstraintInfo{Name: name, Type: constraintType})
+		}
+		c := &constraints[len(constraints)-1]
+
+		if column.Valid {
+			c.Columns = append(c.Columns, column.String)
+		}
+		if refTable.Valid && c.ReferencedTable == nil {
+			table := refTable.String
+			c.ReferencedTable = &table
+		}
+		if refColumn.Valid {
+			c.ReferencedColumns = append(c.ReferencedColumns, refColumn.String)
+		}
+		if checkClause.Valid && c.CheckClause == nil {
+			clause := checkClause.String
+			c.CheckClause = &clause
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return constraints, nil
+}
+
+func (s *schemaBuilder) GetConstraint(table, name string) (*ConstraintInfo, error) {
+	constraints, err := s.GetConstraints(table)
+	if err != nil {
+		return nil, err
+	}
+	for i := range constraints {
+		if constraints[i].Name == name {
+			return &constraints[i], nil
+		}
+	}
+	return nil, fmt.Errorf("constraint %s not found on table %s", name, table)
+}
+
+func (s *schemaBuilder) GetForeignKeys(table string) ([]ForeignKeyInfo, error) {
+	rows, err := s.db.QueryContext(s.ctx, s.dialect.GetForeignKeysQuery(table))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []ForeignKeyInfo
+	for rows.Next() {
+		var fk ForeignKeyInfo
+		if err := rows.Scan(&fk.Name, &fk.Column, &fk.RefTable, &fk.RefColumn, &fk.OnDelete, &fk.OnUpdate); err != nil {
+			return nil, err
+		}
+		keys = append(keys, fk)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+// Размер хранилища
+func (s *schemaBuilder) GetTableSize(table string) (TableSizeInfo, error) {
+	sizes, err := s.queryTableSizes(s.dialect.TableSizeQuery(table))
+	if err != nil {
+		return TableSizeInfo{}, err
+	}
+	if len(sizes) == 0 {
+		return TableSizeInfo{}, fmt.Errorf("table %s not found", table)
+	}
+	return sizes[0], nil
+}
+
+func (s *schemaBuilder) GetDatabaseSize() (int64, error) {
+	var size int64
+	err := s.db.GetContext(s.ctx, &size, s.dialect.DatabaseSizeQuery())
+	if err != nil {
+		return 0, err
+	}
+	return size, nil
+}
+
+// GetIndexSize - размер индекса в байтах, 0 если индекс не найден
+func (s *schemaBuilder) GetIndexSize(table, index string) (int64, error) {
+	var size int64
+	err := s.db.GetContext(s.ctx, &size, s.dialect.IndexSizeQuery(table, index))
+	if err != nil {
+		return 0, err
+	}
+	return size, nil
+}
+
+func (s *schemaBuilder) GetTopTablesBySize(limit int) ([]TableSizeInfo, error) {
+	return s.queryTableSizes(s.dialect.TopTablesBySizeQuery(limit))
+}
+
+func (s *schemaBuilder) queryTableSizes(query string) ([]TableSizeInfo, error) {
+	rows, err := s.db.QueryContext(s.ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sizes []TableSizeInfo
+	for rows.Next() {
+		var size TableSizeInfo
+		if err := rows.Scan(&size.Name, &size.DataBytes, &size.IndexBytes, &size.TotalBytes, &size.RowCount); err != nil {
+			return nil, err
+		}
+		sizes = append(sizes, size)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return sizes, nil
+}
+
 func (s *schemaBuilder) ClearTable(table string) error {
 	query := s.dialect.TruncateTableSQL(table)
 
 	// Log query if logger is set
 	var start time.Time
 	if s.logger != nil {
+		logQueryStart(s.logger, s.ctx, query, nil)
 		start = time.Now()
 	}
 
@@ -357,7 +725,7 @@ func (s *schemaBuilder) ClearTable(table string) error {
 	// Log query execution
 	if s.logger != nil {
 		duration := time.Since(start)
-		s.logger.LogQuery(s.ctx, query, nil, duration, err)
+		logQuery(s.logger, s.ctx, query, nil, duration, err)
 	}
 
 	return err
@@ -371,14 +739,24 @@ type tableBuilder struct {
 	tableName string
 	columns   []columnDefinition
 	indexes   []indexDefinition
+	checks    []string // CONSTRAINT name CHECK (expression), в ALTER TABLE - ADD CONSTRAINT ...
 	commands  []string
+	renames   []string // RENAME COLUMN запросы
+	comment   string
+
+	alter       bool // true for ALTER TABLE, false for CREATE TABLE
+	ifNotExists bool // CREATE TABLE IF NOT EXISTS
 
-	alter bool // true for ALTER TABLE, false for CREATE TABLE
+	err error // изменение, которое диалект не может выразить
 }
 
 type columnDefinition struct {
 	name      string
 	dataType  string
+	geomType  string // POINT, POLYGON... для пространственных колонок
+	generated bool   // вычисляемая колонка, значение задается выражением
+	comment   string
+	modify    bool // ModifyColumn: изменение существующей колонки
 	modifiers []string
 	after     string
 	first     bool
@@ -389,6 +767,7 @@ type indexDefinition struct {
 	columns []string
 	unique  bool
 	primary bool
+	spatial bool
 	foreign *foreignDefinition
 }
 
@@ -412,11 +791,23 @@ func newTableBuilder(db SQLXExecutor, dialect dialect.Dialect, tableName string)
 }
 
 // Колонки
+func (t *tableBuilder) UUIDPrimary() TableBuilder {
+	t.UUID("id").NotNull()
+	t.columns[len(t.columns)-1].modifiers = append(t.columns[len(t.columns)-1].modifiers, "PRIMARY KEY")
+	return t
+}
+
 func (t *tableBuilder) ID() TableBuilder {
+	dataType := t.dialect.GetIDColumnType()
+	modifiers := []string{"PRIMARY KEY"}
+	// SERIAL типы (PostgreSQL) и INTEGER PRIMARY KEY (SQLite, псевдоним rowid) уже автоинкрементные
+	if !strings.HasSuffix(dataType, "SERIAL") && dataType != "INTEGER" {
+		modifiers = append(modifiers, "AUTO_INCREMENT")
+	}
 	t.columns = append(t.columns, columnDefinition{
 		name:      "id",
-		dataType:  t.dialect.GetIDColumnType(),
-		modifiers: []string{"PRIMARY KEY", "AUTO_INCREMENT"},
+		dataType:  dataType,
+		modifiers: modifiers,
 	})
 	return t
 }
@@ -458,6 +849,60 @@ func (t *tableBuilder) BigInteger(name string) ColumnBuilder {
 	return t
 }
 
+func (t *tableBuilder) SmallInteger(name string) ColumnBuilder {
+	return t.nativeType(name, "SMALLINT", 0)
+}
+
+func (t *tableBuilder) TinyInteger(name string) ColumnBuilder {
+	return t.nativeType(name, "TINYINT", 0)
+}
+
+func (t *tableBuilder) MediumInteger(name string) ColumnBuilder {
+	return t.nativeType(name, "MEDIUMINT", 0)
+}
+
+func (t *tableBuilder) Float(name string) ColumnBuilder {
+	return t.nativeType(name, "FLOAT", 0)
+}
+
+func (t *tableBuilder) Double(name string) ColumnBuilder {
+	return t.nativeType(name, "DOUBLE", 0)
+}
+
+func (t *tableBuilder) Char(name string, length int) ColumnBuilder {
+	return t.nativeType(name, "CHAR", length)
+}
+
+func (t *tableBuilder) Binary(name string, length int) ColumnBuilder {
+	return t.nativeType(name, "BINARY", length)
+}
+
+func (t *tableBuilder) Blob(name string) ColumnBuilder {
+	return t.nativeType(name, "BLOB", 0)
+}
+
+func (t *tableBuilder) UUID(name string) ColumnBuilder {
+	return t.nativeType(name, "UUID", 0)
+}
+
+func (t *tableBuilder) Generated(name string, dataType string, expression string, stored bool) ColumnBuilder {
+	t.columns = append(t.columns, columnDefinition{
+		name:      name,
+		dataType:  dataType + " " + t.dialect.GeneratedColumn(expression, stored),
+		generated: true,
+	})
+	return t
+}
+
+// nativeType - тип колонки через диалект (TINYINT -> SMALLINT в PostgreSQL и т.д.)
+func (t *tableBuilder) nativeType(name, dataType string, length int) ColumnBuilder {
+	t.columns = append(t.columns, columnDefinition{
+		name:     name,
+		dataType: t.dialect.ColumnType(dataType, length),
+	})
+	return t
+}
+
 func (t *tableBuilder) Decimal(name string, precision, scale int) ColumnBuilder {
 	t.columns = append(t.columns, columnDefinition{
 		name:     name,
@@ -506,6 +951,32 @@ func (t *tableBuilder) JSON(name string) ColumnBuilder {
 	return t
 }
 
+// Пространственные колонки
+func (t *tableBuilder) Point(name string) ColumnBuilder {
+	return t.spatial(name, "POINT")
+}
+
+func (t *tableBuilder) LineString(name string) ColumnBuilder {
+	return t.spatial(name, "LINESTRING")
+}
+
+func (t *tableBuilder) Polygon(name string) ColumnBuilder {
+	return t.spatial(name, "POLYGON")
+}
+
+func (t *tableBuilder) Geometry(name string) ColumnBuilder {
+	return t.spatial(name, "GEOMETRY")
+}
+
+func (t *tableBuilder) spatial(name, geomType string) ColumnBuilder {
+	t.columns = append(t.columns, columnDefinition{
+		name:     name,
+		dataType: t.dialect.SpatialColumnType(geomType, 0),
+		geomType: geomType,
+	})
+	return t
+}
+
 // Специальные колонки
 func (t *tableBuilder) Timestamps() TableBuilder {
 	t.DateTime("created_at").NotNull()
@@ -534,7 +1005,7 @@ func (t *tableBuilder) NotNull() ColumnBuilder {
 }
 
 func (t *tableBuilder) Default(value any) ColumnBuilder {
-	if len(t.columns) > 0 {
+	if len(t.columns) > 0 && !t.columns[len(t.columns)-1].generated {
 		var defaultValue string
 		switch v := value.(type) {
 		case string:
@@ -590,15 +1061,94 @@ func (t *tableBuilder) Primary() ColumnBuilder {
 }
 
 func (t *tableBuilder) AutoIncrement() ColumnBuilder {
-	if len(t.columns) > 0 {
+	if len(t.columns) > 0 && !t.columns[len(t.columns)-1].generated {
 		t.columns[len(t.columns)-1].modifiers = append(t.columns[len(t.columns)-1].modifiers, "AUTO_INCREMENT")
 	}
 	return t
 }
 
+// Comment - COMMENT '...' в определении колонки (MySQL) или COMMENT ON COLUMN после запроса (PostgreSQL)
 func (t *tableBuilder) Comment(comment string) ColumnBuilder {
-	// Comments are typically handled differently in different databases
-	// For now, we'll just ignore this in the SQL generation
+	if len(t.columns) > 0 {
+		col := &t.columns[len(t.columns)-1]
+		col.comment = comment
+		if inline := t.dialect.InlineColumnComment(comment); inline != "" {
+			col.modifiers = append(col.modifiers, inline)
+		}
+	}
+	return t
+}
+
+func (t *tableBuilder) TableComment(comment string) TableBuilder {
+	t.comment = comment
+	return t
+}
+
+// separateIndex - индекс, который диалект без inline KEY (PostgreSQL, SQLite) создает
+// отдельным CREATE INDEX. В CREATE TABLE UNIQUE остается ограничением, в ALTER TABLE
+// отдельно создаются и уникальные индексы
+func (t *tableBuilder) separateIndex(idx indexDefinition) bool {
+	if idx.primary || idx.spatial {
+		return false
+	}
+	if t.dialect.InlineIndex(idx.name, idx.columns, false) != "" {
+		return false
+	}
+	return t.alter || !idx.unique
+}
+
+// afterStatements - RENAME COLUMN, отдельные CREATE INDEX и COMMENT ON запросы,
+// выполняемые после CREATE/ALTER TABLE
+func (t *tableBuilder) afterStatements() []string {
+	statements := append([]string{}, t.renames...)
+	for _, idx := range t.indexes {
+		if idx.spatial {
+			if stmt := t.dialect.CreateSpatialIndexSQL(t.tableName, idx.name, idx.columns); stmt != "" {
+				statements = append(statements, stmt)
+			}
+			continue
+		}
+		if t.separateIndex(idx) && idx.foreign == nil {
+			statements = append(statements, t.dialect.CreateIndexSQL(t.tableName, idx.name, idx.columns, idx.unique, "", ""))
+		}
+	}
+	if t.comment != "" {
+		if stmt := t.dialect.CommentOnSQL(t.tableName, "", t.comment); stmt != "" {
+			statements = append(statements, stmt)
+		}
+	}
+	for _, col := range t.columns {
+		if col.comment == "" {
+			continue
+		}
+		if stmt := t.dialect.CommentOnSQL(t.tableName, col.name, col.comment); stmt != "" {
+			statements = append(statements, stmt)
+		}
+	}
+	return statements
+}
+
+func (t *tableBuilder) ModifyColumn(name string) ColumnBuilder {
+	t.columns = append(t.columns, columnDefinition{
+		name:   name,
+		modify: true,
+	})
+	return t
+}
+
+func (t *tableBuilder) Type(dataType string) ColumnBuilder {
+	if len(t.columns) > 0 {
+		t.columns[len(t.columns)-1].dataType = dataType
+	}
+	return t
+}
+
+// SRID задает систему координат пространственной колонки, например 4326 (WGS 84)
+func (t *tableBuilder) SRID(srid int) ColumnBuilder {
+	if len(t.columns) > 0 && t.columns[len(t.columns)-1].geomType != "" {
+		col := &t.columns[len(t.columns)-1]
+		col.dataType = t.dialect.SpatialColumnType(col.geomType, srid)
+	}
 	return t
 }
 
@@ -652,7 +1202,19 @@ func (t *tableBuilder) PrimaryKey(columns ...string) TableBuilder {
 	return t
 }
 
-func (t *tableBuilder) ForeignKey(column, refTable, refColumn string) TableBuilder {
+func (t *tableBuilder) AddSpatialIndex(columns ...string) TableBuilder {
+	if len(columns) > 0 {
+		indexName := fmt.Sprintf("%s_%s_spatial", t.tableName, strings.Join(columns, "_"))
+		t.indexes = append(t.indexes, indexDefinition{
+			name:    indexName,
+			columns: columns,
+			spatial: true,
+		})
+	}
+	return t
+}
+
+func (t *tableBuilder) ForeignKey(column, refTable, refColumn string) ForeignKeyBuilder {
 	foreign := &foreignDefinition{
 		column:    column,
 		refTable:  refTable,
@@ -683,6 +1245,40 @@ func (t *tableBuilder) DropIndex(name string) TableBuilder {
 	return t
 }
 
+// Check - SQLite не добавляет CHECK в ALTER TABLE, MySQL до 8.0.16 его не проверяет:
+// вместо пропуска ограничения CreateTable/AlterTable вернут ErrNotSupported
+func (t *tableBuilder) Check(name, expression string) TableBuilder {
+	check := t.dialect.CheckConstraint(name, expression)
+	if t.alter {
+		check = t.dialect.AddCheck(name, expression)
+	}
+	if check == "" {
+		t.fail(fmt.Errorf("check constraint %s: %w", name, ErrNotSupported))
+		return t
+	}
+	t.checks = append(t.checks, check)
+	return t
+}
+
+func (t *tableBuilder) DropCheck(name string) TableBuilder {
+	if t.alter {
+		drop := t.dialect.DropCheck(name)
+		if drop == "" {
+			t.fail(fmt.Errorf("drop check %s: %w", name, ErrNotSupported))
+			return t
+		}
+		t.commands = append(t.commands, drop)
+	}
+	return t
+}
+
+// fail запоминает первую ошибку построения таблицы
+func (t *tableBuilder) fail(err error) {
+	if t.err == nil {
+		t.err = err
+	}
+}
+
 func (t *tableBuilder) DropForeign(name string) TableBuilder {
 	if t.alter {
 		t.commands = append(t.commands, fmt.Sprintf("DROP FOREIGN KEY %s", t.dialect.QuoteIdentifier(name)))
@@ -691,25 +1287,22 @@ func (t *tableBuilder) DropForeign(name string) TableBuilder {
 }
 
 func (t *tableBuilder) Enum(name string, values ...string) ColumnBuilder {
-	vals := make([]string, len(values))
-	for i, v := range values {
-		vals[i] = fmt.Sprintf("'%s'", strings.ReplaceAll(v, "'", "''"))
-	}
-	t.columns = append(t.columns, columnDefinition{
-		name:     name,
-		dataType: fmt.Sprintf("ENUM(%s)", strings.Join(vals, ", ")),
-	})
-	return t
+	return t.enum(name, values, false)
 }
 
 func (t *tableBuilder) Set(name string, values ...string) ColumnBuilder {
+	return t.enum(name, values, true)
+}
+
+// enum - ENUM/SET в MySQL, в остальных диалектах - тип с CHECK ограничением
+func (t *tableBuilder) enum(name string, values []string, set bool) ColumnBuilder {
 	vals := make([]string, len(values))
 	for i, v := range values {
 		vals[i] = fmt.Sprintf("'%s'", strings.ReplaceAll(v, "'", "''"))
 	}
 	t.columns = append(t.columns, columnDefinition{
 		name:     name,
-		dataType: fmt.Sprintf("SET(%s)", strings.Join(vals, ", ")),
+		dataType: t.dialect.EnumColumnType(name, vals, set),
 	})
 	return t
 }
@@ -742,9 +1335,10 @@ func (t *tableBuilder) OnUpdate(action string) TableBuilder {
 	return t
 }
 
+// RenameColumn выполняется отдельным запросом после ALTER TABLE
 func (t *tableBuilder) RenameColumn(from, to string) TableBuilder {
 	if t.alter {
-		t.commands = append(t.commands, fmt.Sprintf("RENAME COLUMN %s TO %s", t.dialect.QuoteIdentifier(from), t.dialect.QuoteIdentifier(to)))
+		t.renames = append(t.renames, t.dialect.RenameColumnSQL(t.tableName, from, to))
 	}
 	return t
 }
@@ -769,6 +1363,9 @@ func (t *tableBuilder) toCreateSQL() (string, []any) {
 	var args []any
 
 	for _, col := range t.columns {
+		if col.modify {
+			continue
+		}
 		def := t.dialect.QuoteIdentifier(col.name) + " " + col.dataType
 		if len(col.modifiers) > 0 {
 			def += " " + strings.Join(col.modifiers, " ")
@@ -776,22 +1373,44 @@ func (t *tableBuilder) toCreateSQL() (string, []any) {
 		columnDefs = append(columnDefs, def)
 	}
 
-	// Add index definitions
+	// Порядок ограничений: PRIMARY KEY, UNIQUE KEY, KEY, SPATIAL, затем FOREIGN KEY
 	for _, idx := range t.indexes {
 		if idx.primary {
 			columnDefs = append(columnDefs, fmt.Sprintf("PRIMARY KEY (%s)",
 				strings.Join(quoteIdentifiers(t.dialect, idx.columns), ", ")))
-		} else if idx.unique {
-			columnDefs = append(columnDefs, fmt.Sprintf("UNIQUE KEY %s (%s)",
-				t.dialect.QuoteIdentifier(idx.name),
-				strings.Join(quoteIdentifiers(t.dialect, idx.columns), ", ")))
+		}
+	}
+	for _, idx := range t.indexes {
+		if idx.unique && !idx.primary {
+			if def := t.dialect.InlineIndex(idx.name, idx.columns, true); def != "" {
+				columnDefs = append(columnDefs, def)
+			}
+		}
+	}
+	for _, idx := range t.indexes {
+		// Индекс для внешнего ключа MySQL создает сам
+		if !idx.primary && !idx.unique && !idx.spatial && idx.foreign == nil {
+			// PostgreSQL и SQLite создают такой индекс отдельно, см. afterStatements
+			if def := t.dialect.InlineIndex(idx.name, idx.columns, false); def != "" {
+				columnDefs = append(columnDefs, def)
+			}
+		}
+	}
+	for _, idx := range t.indexes {
+		if !idx.spatial {
+			continue
+		}
+		// Диалект может не поддерживать пространственные индексы в DDL (SQLite)
+		if def := t.dialect.SpatialIndex(idx.name, idx.columns); def != "" {
+			columnDefs = append(columnDefs, def)
 		}
 	}
 
 	// Add foreign key constraints
 	for _, idx := range t.indexes {
 		if idx.foreign != nil {
-			fk := fmt.Sprintf("FOREIGN KEY (%s) REFERENCES %s(%s)",
+			fk := fmt.Sprintf("CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s(%s)",
+				t.dialect.QuoteIdentifier(idx.name),
 				t.dialect.QuoteIdentifier(idx.foreign.column),
 				t.dialect.QuoteIdentifier(idx.foreign.refTable),
 				t.dialect.QuoteIdentifier(idx.foreign.refColumn))
@@ -804,10 +1423,17 @@ func (t *tableBuilder) toCreateSQL() (string, []any) {
 			columnDefs = append(columnDefs, fk)
 		}
 	}
+	columnDefs = append(columnDefs, t.checks...)
 
-	query := fmt.Sprintf("CREATE TABLE %s (%s)",
+	query := fmt.Sprintf("%s %s (%s)",
+		t.dialect.CreateTablePrefix(t.ifNotExists),
 		t.dialect.QuoteIdentifier(t.tableName),
 		strings.Join(columnDefs, ", "))
+	if t.comment != "" {
+		if inline := t.dialect.InlineTableComment(t.comment); inline != "" {
+			query += " " + inline
+		}
+	}
 
 	return query, args
 }
@@ -818,6 +1444,15 @@ func (t *tableBuilder) toAlterSQL() (string, []any) {
 
 	// Add column definitions
 	for _, col := range t.columns {
+		if col.modify {
+			parts, ok := t.dialect.ModifyColumn(col.name, col.dataType, col.modifiers)
+			if !ok {
+				// SQLite не изменяет колонки совсем, MySQL MODIFY COLUMN требует тип
+				t.fail(fmt.Errorf("modify column %s: %w", col.name, ErrNotSupported))
+			}
+			alterParts = append(alterParts, parts...)
+			continue
+		}
 		def := "ADD COLUMN " + t.dialect.QuoteIdentifier(col.name) + " " + col.dataType
 		if len(col.modifiers) > 0 {
 			def += " " + strings.Join(col.modifiers, " ")
@@ -830,10 +1465,16 @@ func (t *tableBuilder) toAlterSQL() (string, []any) {
 		if idx.primary {
 			alterParts = append(alterParts, fmt.Sprintf("ADD PRIMARY KEY (%s)",
 				strings.Join(quoteIdentifiers(t.dialect, idx.columns), ", ")))
+		} else if t.separateIndex(idx) {
+			continue
 		} else if idx.unique {
 			alterParts = append(alterParts, fmt.Sprintf("ADD UNIQUE KEY %s (%s)",
 				t.dialect.QuoteIdentifier(idx.name),
 				strings.Join(quoteIdentifiers(t.dialect, idx.columns), ", ")))
+		} else if idx.spatial {
+			if def := t.dialect.SpatialIndex(idx.name, idx.columns); def != "" {
+				alterParts = append(alterParts, "ADD "+def)
+			}
 		} else {
 			alterParts = append(alterParts, fmt.Sprintf("ADD INDEX %s (%s)",
 				t.dialect.QuoteIdentifier(idx.name),
@@ -844,7 +1485,8 @@ func (t *tableBuilder) toAlterSQL() (string, []any) {
 	// Add foreign key constraints
 	for _, idx := range t.indexes {
 		if idx.foreign != nil {
-			fk := fmt.Sprintf("ADD FOREIGN KEY (%s) REFERENCES %s(%s)",
+			fk := fmt.Sprintf("ADD CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s(%s)",
+				t.dialect.QuoteIdentifier(idx.name),
 				t.dialect.QuoteIdentifier(idx.foreign.column),
 				t.dialect.QuoteIdentifier(idx.foreign.refTable),
 				t.dialect.QuoteIdentifier(idx.foreign.refColumn))
@@ -857,12 +1499,24 @@ func (t *tableBuilder) toAlterSQL() (string, []any) {
 			alterParts = append(alterParts, fk)
 		}
 	}
+	alterParts = append(alterParts, t.checks...)
 
 	// Add commands
 	for _, cmd := range t.commands {
 		alterParts = append(alterParts, cmd)
 	}
 
+	if t.comment != "" {
+		if inline := t.dialect.InlineTableComment(t.comment); inline != "" {
+			alterParts = append(alterParts, inline)
+		}
+	}
+
+	// Только RENAME COLUMN или COMMENT ON - сам ALTER TABLE не нужен
+	if len(alterParts) == 0 {
+		return "", args
+	}
+
 	query := fmt.Sprintf("ALTER TABLE %s %s", t.dialect.QuoteIdentifier(t.tableName), strings.Join(alterParts, ", "))
 	return query, args
 }
@@ -879,44 +1533,108 @@ func (s *schemaBuilder) setLogger(logger Logger) {
 	s.logger = logger
 }
 
+// AddColumn - ALTER TABLE table ADD COLUMN name columnType modifiers...
+func (s *schemaBuilder) AddColumn(table, name, columnType string, modifiers ...string) error {
+	def := s.dialect.QuoteIdentifier(name) + " " + columnType
+	if len(modifiers) > 0 {
+		def += " " + strings.Join(modifiers, " ")
+	}
+	query := fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s", s.dialect.QuoteIdentifier(table), def)
+	return s.execQuery(query)
+}
+
+// RenameColumn - ALTER TABLE table RENAME COLUMN from TO to
+func (s *schemaBuilder) RenameColumn(table, from, to string) error {
+	return s.execQuery(s.dialect.RenameColumnSQL(table, from, to))
+}
+
 func (s *schemaBuilder) DropColumn(table, column string) error {
 	query := fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s", s.dialect.QuoteIdentifier(table), s.dialect.QuoteIdentifier(column))
 	var start time.Time
 	if s.logger != nil {
+		logQueryStart(s.logger, s.ctx, query, nil)
 		start = time.Now()
 	}
 	_, err := s.db.ExecContext(s.ctx, query)
 	if s.logger != nil {
 		duration := time.Since(start)
-		s.logger.LogQuery(s.ctx, query, nil, duration, err)
+		logQuery(s.logger, s.ctx, query, nil, duration, err)
 	}
 	return err
 }
 
-func (s *schemaBuilder) DropIndex(table, index string) error {
-	query := fmt.Sprintf("ALTER TABLE %s DROP INDEX %s", s.dialect.QuoteIdentifier(table), s.dialect.QuoteIdentifier(index))
-	var start time.Time
-	if s.logger != nil {
-		start = time.Now()
+// Представления
+func (s *schemaBuilder) CreateView(name string, query SelectBuilder) error {
+	return s.createView(name, query, false)
+}
+
+func (s *schemaBuilder) CreateOrReplaceView(name string, query SelectBuilder) error {
+	return s.createView(name, query, true)
+}
+
+// createView - DDL не принимает плейсхолдеры, поэтому запрос представления
+// не должен содержать аргументов (значения задаются через WhereRaw)
+func (s *schemaBuilder) createView(name string, query SelectBuilder, orReplace bool) error {
+	if err := query.Err(); err != nil {
+		return err
 	}
-	_, err := s.db.ExecContext(s.ctx, query)
-	if s.logger != nil {
-		duration := time.Since(start)
-		s.logger.LogQuery(s.ctx, query, nil, duration, err)
+	selectSQL, args := query.ToSQL()
+	if len(args) > 0 {
+		return fmt.Errorf("view %s: query must not have bind arguments, got %d", name, len(args))
 	}
-	return err
+
+	for _, stmt := range s.dialect.CreateViewSQL(name, selectSQL, orReplace) {
+		if err := s.execQuery(stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *schemaBuilder) DropView(name string) error {
+	return s.execQuery(fmt.Sprintf("DROP VIEW %s", s.dialect.QuoteIdentifier(name)))
+}
+
+// CreateIndex - CREATE [UNIQUE] INDEX name ON table (columns...)
+func (s *schemaBuilder) CreateIndex(table string, columns []string, opts ...IndexOption) error {
+	config := &IndexConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+	if len(columns) == 0 {
+		return errors.New("create index: no columns")
+	}
+	if config.Where != "" && !s.dialect.SupportsPartialIndex() {
+		return fmt.Errorf("partial index: %w", ErrNotSupported)
+	}
+
+	name := config.Name
+	if name == "" {
+		suffix := "index"
+		if config.Unique {
+			suffix = "unique"
+		}
+		name = fmt.Sprintf("%s_%s_%s", table, strings.Join(columns, "_"), suffix)
+	}
+
+	return s.execQuery(s.dialect.CreateIndexSQL(table, name, columns, config.Unique, config.Method, config.Where))
+}
+
+func (s *schemaBuilder) DropIndex(table, index string) error {
+	return s.execQuery(s.dialect.DropIndexSQL(table, index))
 }
 
 func (s *schemaBuilder) DropForeign(table, foreign string) error {
 	query := fmt.Sprintf("ALTER TABLE %s DROP FOREIGN KEY %s", s.dialect.QuoteIdentifier(table), s.dialect.QuoteIdentifier(foreign))
 	var start time.Time
 	if s.logger != nil {
+		logQueryStart(s.logger, s.ctx, query, nil)
 		start = time.Now()
 	}
 	_, err := s.db.ExecContext(s.ctx, query)
 	if s.logger != nil {
 		duration := time.Since(start)
-		s.logger.LogQuery(s.ctx, query, nil, duration, err)
+		logQuery(s.logger, s.ctx, query, nil, duration, err)
 	}
 	return err
 }
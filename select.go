@@ -3,10 +3,12 @@ package querycraft
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/antibomberman/querycraft/dialect"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 )
@@ -15,6 +17,24 @@ import (
 type SelectBuilder interface {
 	// Основные методы
 	From(table string) SelectBuilder
+	FromValues(rows []map[string]any, alias string) SelectBuilder
+	FromSub(sub SelectBuilder, alias string) SelectBuilder
+	Select(columns ...string) SelectBuilder
+	Distinct() SelectBuilder
+	DistinctOn(columns ...string) SelectBuilder
+	WithSchema(schema string) SelectBuilder
+	WithHint(hint string) SelectBuilder
+	AddColumn(column string) SelectBuilder
+	AddColumns(columns ...string) SelectBuilder
+	SelectRaw(expression string, args ...any) SelectBuilder
+	AddExprColumn(expr string, args ...any) SelectBuilder
+	AddColumnIf(condition bool, column string) SelectBuilder
+	AddColumnsIf(condition bool, columns ...string) SelectBuilder
+	AddExprColumnIf(condition bool, expr string, args ...any) SelectBuilder
+	AddWindowFunc(alias, fn string, partition []string, orderCols []string) SelectBuilder
+	Window(name, definition string) SelectBuilder
+	With(name string, subquery SelectBuilder, opts ...CTEOption) SelectBuilder
+	WithRecursive(name string, subquery SelectBuilder) SelectBuilder
 
 	// WHERE условия
 	Where(column, operator string, value any) SelectBuilder
@@ -26,6 +46,22 @@ type SelectBuilder interface {
 	WhereBetween(column string, from, to any) SelectBuilder
 	WhereNotBetween(column string, from, to any) SelectBuilder
 	WhereRaw(condition string, args ...any) SelectBuilder
+	WhereLike(column, pattern string) SelectBuilder
+	WhereNotLike(column, pattern string) SelectBuilder
+	WhereILike(column, pattern string) SelectBuilder
+	WhereColumn(first, operator, second string) SelectBuilder
+
+	// WHERE по JSON колонкам, путь в формате диалекта ($.a.b для MySQL, {a,b} для PostgreSQL)
+	WhereJsonContains(column, path string, value any) SelectBuilder
+	WhereJsonPath(column, path, operator string, value any) SelectBuilder
+
+	// WHERE по частям даты/времени
+	WhereDate(column, operator string, value string) SelectBuilder
+	WhereTime(column, operator string, value string) SelectBuilder
+	WhereYear(column, operator string, value int) SelectBuilder
+	WhereMonth(column, operator string, value int) SelectBuilder
+	WhereDay(column, operator string, value int) SelectBuilder
+	WhereHour(column, operator string, value int) SelectBuilder
 
 	WhereExists(subquery SelectBuilder) SelectBuilder
 	WhereNotExists(subquery SelectBuilder) SelectBuilder
@@ -36,7 +72,12 @@ type SelectBuilder interface {
 	OrWhereIn(column string, values ...any) SelectBuilder
 	OrWhereNull(column ...string) SelectBuilder
 	OrWhereNotNull(column ...string) SelectBuilder
+	OrWhereNotIn(column string, values ...any) SelectBuilder
+	OrWhereBetween(column string, from, to any) SelectBuilder
+	OrWhereNotBetween(column string, from, to any) SelectBuilder
 	OrWhereRaw(condition string, args ...any) SelectBuilder
+	OrWhereLike(column, pattern string) SelectBuilder
+	OrWhereColumn(first, operator, second string) SelectBuilder
 
 	// WHERE группировка
 	WhereGroup(fn func(SelectBuilder) SelectBuilder) SelectBuilder
@@ -46,6 +87,30 @@ type SelectBuilder interface {
 	When(condition bool, column, operator string, value any) SelectBuilder
 	WhenFunc(condition bool, fn func(SelectBuilder) SelectBuilder) SelectBuilder
 
+	// Сброс частей запроса (для шаблонных билдеров: Clone().ResetWhere()...)
+	ResetWhere() SelectBuilder
+	ResetOrder() SelectBuilder
+	ResetGroup() SelectBuilder
+	ResetHaving() SelectBuilder
+	ResetJoins() SelectBuilder
+	ResetLimit() SelectBuilder
+	ResetOffset() SelectBuilder
+
+	// Отложенная модификация запроса (применяется при построении SQL)
+	LazyBuild(fn func(SelectBuilder) SelectBuilder) SelectBuilder
+
+	// Операции над множествами; OrderBy, Limit и Offset применяются ко всему результату
+	Union(other SelectBuilder) SelectBuilder
+	UnionAll(other SelectBuilder) SelectBuilder
+	Intersect(other SelectBuilder) SelectBuilder
+	IntersectAll(other SelectBuilder) SelectBuilder
+	Except(other SelectBuilder) SelectBuilder
+	ExceptAll(other SelectBuilder) SelectBuilder
+	AllVariant() SelectBuilder
+
+	// Err возвращает отложенную ошибку построения запроса
+	Err() error
+
 	// JOIN операции
 	Join(table, condition string) SelectBuilder
 	InnerJoin(table, condition string) SelectBuilder
@@ -53,6 +118,8 @@ type SelectBuilder interface {
 	RightJoin(table, condition string) SelectBuilder
 	CrossJoin(table string) SelectBuilder
 	OuterJoin(table, condition string) SelectBuilder
+	CrossApply(subquery SelectBuilder, alias string) SelectBuilder
+	OuterApply(subquery SelectBuilder, alias string) SelectBuilder
 
 	// Сортировка и группировка
 	OrderBy(columns ...string) SelectBuilder
@@ -60,14 +127,33 @@ type SelectBuilder interface {
 	OrderByRaw(expression string) SelectBuilder
 	GroupBy(columns ...string) SelectBuilder
 	Having(condition string, args ...any) SelectBuilder
+	HavingRaw(condition string, args ...any) SelectBuilder
+	OrHaving(condition string, args ...any) SelectBuilder
+	HavingGroup(fn func(SelectBuilder) SelectBuilder) SelectBuilder
 
 	// Пагинация
 	Limit(limit int) SelectBuilder
 	Offset(offset int) SelectBuilder
 	Page(page, perPage int) SelectBuilder
 	Paginate(page, perPage int) (*PaginationResult, error)
+	PaginateWithSubqueryCount(page, perPage int) (*PaginationResult, error)
+	SimplePaginate(page, perPage int) (*SimplePaginationResult, error)
+	UseSubqueryCount(enabled bool) SelectBuilder
 	KeysetPaginate(column string, lastValue any, perPage int, direction string) (*KeysetPaginationResult, error)
 
+	// Блокировка строк, имеет смысл внутри транзакции
+	ForUpdate() SelectBuilder
+	ForShare() SelectBuilder
+	LockInShareMode() SelectBuilder
+
+	// Подсказки индексов (MySQL), в остальных диалектах игнорируются
+	UseIndex(indexes ...string) SelectBuilder
+	ForceIndex(index string) SelectBuilder
+	IgnoreIndex(indexes ...string) SelectBuilder
+
+	// Выбор партиций (MySQL): FROM t PARTITION (p1, p2)
+	Partition(partitions ...string) SelectBuilder
+
 	// Выполнение запросов
 	One(dest any) error
 	Find(dest any) (bool, error)
@@ -75,11 +161,23 @@ type SelectBuilder interface {
 	Get(dest any) (bool, error)
 	Row() (map[string]any, error)
 	Rows() ([]map[string]any, error)
+	Iterate(fn func(row map[string]any) error) error
+	ForEachGrouped(keyColumn string, fn func(key any, rows []map[string]any) error) error
+	ForEachGroupedChunk(keyColumn, idColumn string, chunkSize int, fn func(key any, rows []map[string]any) error) error
+	ChunkById(size int, idColumn string, fn func([]map[string]any) error) error
 	RowsMapKey(keyColumn string) (map[any]map[string]any, error)
 
 	// Получение отдельных значений
 	Field(column string) (any, error)
+	FieldInt64(column string) (int64, error)
+	FieldString(column string) (string, error)
+	FieldFloat64(column string) (float64, error)
+	FieldBool(column string) (bool, error)
 	Pluck(column string) ([]any, error)
+	PluckInt64(column string) ([]int64, error)
+	PluckString(column string) ([]string, error)
+	PluckFloat64(column string) ([]float64, error)
+	PluckBool(column string) ([]bool, error)
 
 	// Агрегатные функции
 	Count() (int64, error)
@@ -89,16 +187,41 @@ type SelectBuilder interface {
 	Max(column string) (any, error)
 	Min(column string) (any, error)
 	Exists() (bool, error)
+	Aggregates() AggregateBuilder
 
 	// Утилиты
 	WithContext(ctx context.Context) SelectBuilder
 	Clone() SelectBuilder
 
 	ToSQL() (string, []any)
+
+	// Инспекция состояния билдера (только чтение)
+	Columns() []string
+	Table() string
+	WhereColumns() []string
+	WhereArgs() []any
+
+	ToInsertSQL(targetTable string, columns ...string) (string, []any)
+	NormalizedSQL() string
+	Fingerprint() string
 	PrintSQL() SelectBuilder
+	PrettyPrint() SelectBuilder
 	Explain() ([]map[string]any, error)
+	ExplainFormat(format string) ([]map[string]any, error)
+	ExplainAnalyze() ([]map[string]any, error)
+	Benchmark(runs int) (BenchmarkResult, error)
+	BenchmarkContext(ctx context.Context, runs int) (BenchmarkResult, error)
 }
 
+// CTEOption - управление материализацией CTE (PostgreSQL 12+)
+type CTEOption = dialect.CTEOption
+
+const (
+	CTEDefault        = dialect.CTEDefault
+	CTEMaterialize    = dialect.CTEMaterialize
+	CTENotMaterialize = dialect.CTENotMaterialize
+)
+
 type PaginationResult struct {
 	Data        []map[string]any `json:"data"`
 	Total       int64            `json:"total"`
@@ -108,6 +231,26 @@ type PaginationResult struct {
 	From        int              `json:"from"`
 	To          int              `json:"to"`
 }
+
+// SimplePaginationResult - результат SimplePaginate, без Total и LastPage
+type SimplePaginationResult struct {
+	Data        []map[string]any `json:"data"`
+	PerPage     int              `json:"per_page"`
+	CurrentPage int              `json:"current_page"`
+	HasMore     bool             `json:"has_more"`
+	From        int              `json:"from"`
+	To          int              `json:"to"`
+}
+
+// BenchmarkResult - статистика времени выполнения запроса
+type BenchmarkResult struct {
+	Min    time.Duration
+	Max    time.Duration
+	Mean   time.Duration
+	Median time.Duration
+	Runs   int
+}
+
 type KeysetPaginationResult struct {
 	Data       []map[string]any `json:"data"`
 	HasMore    bool             `json:"has_more"`
@@ -149,24 +292,72 @@ type selectBuilder struct {
 	logger  Logger
 
 	// Query parts
-	columns    []string
-	table      string
+	ctes    []string
+	cteArgs []any
+	columns []string
+	table   string
+
+	// Имена CTE не квалифицируются схемой в FROM и JOIN
+	cteNames     []string
+	recursiveCTE bool
+
+	// Таблица из FromValues / FromSub вместо table
+	fromValues string
+	fromArgs   []any
+
+	// Подсказки оптимизатора: SELECT /*+ ... */
+	hints []string
+
+	// Подсказки индексов после имени таблицы: USE INDEX (...)
+	indexHints []string
+
+	// Партиции: FROM t PARTITION (p1, p2) AS alias
+	partitions []string
+
+	// SELECT DISTINCT / SELECT DISTINCT ON (...)
+	distinct   bool
+	distinctOn []string
+
+	// Выражения из AddExprColumn не экранируются, их аргументы идут перед WHERE
+	exprColumns map[string]bool
+	columnArgs  []any
+
 	joins      []string
+	joinArgs   []any
 	wheres     []string
 	whereArgs  []any
 	orders     []string
 	groups     []string
 	havings    []string
 	havingArgs []any
+	windows    []string
 	limit      *int
 	offset     *int
+	lock       string
 
 	// For subqueries in where exists
 	subqueries   []string
 	subqueryArgs []any
 
+	// Paginate считает total через подзапрос
+	useSubqueryCount bool
+
+	// Схема по умолчанию для From (см. QueryCraft.WithSchema)
+	defaultSchema string
+
+	// Функции LazyBuild, применяются к копии билдера в buildSQL
+	lazyFns []func(SelectBuilder) SelectBuilder
+
+	// Операции над множествами: UNION, INTERSECT, EXCEPT
+	setOps []setOperation
+
+	// Отложенная ошибка построения (например, операция не поддерживается диалектом),
+	// возвращается методами выполнения
+	err error
+
 	// Print SQL flag
-	printSQL bool
+	printSQL  bool
+	prettySQL bool
 }
 
 func NewSelectBuilder(db SQLXExecutor, dialect dialect.Dialect, columns ...string) SelectBuilder {
@@ -180,9 +371,226 @@ func NewSelectBuilder(db SQLXExecutor, dialect dialect.Dialect, columns ...strin
 
 func (s *selectBuilder) From(table string) SelectBuilder {
 	s.table = table
+	s.fromValues = ""
+	s.fromArgs = nil
+	return s
+}
+
+// FromValues - FROM по строкам из памяти без временной таблицы:
+// (VALUES (?, ?), ...) AS t(id, name) в PostgreSQL, SELECT ... UNION ALL SELECT ... в MySQL.
+// Колонки берутся из ключей строк в алфавитном порядке
+func (s *selectBuilder) FromValues(rows []map[string]any, alias string) SelectBuilder {
+	s.table = ""
+	s.fromValues, s.fromArgs = s.dialect.InlineValues(rows, alias)
+	return s
+}
+
+// FromSub - производная таблица: FROM (SELECT ...) AS alias.
+// Аргументы подзапроса идут перед аргументами JOIN и WHERE внешнего запроса
+func (s *selectBuilder) FromSub(sub SelectBuilder, alias string) SelectBuilder {
+	if err := sub.Err(); err != nil && s.err == nil {
+		s.err = err
+	}
+	subSQL, subArgs := sub.ToSQL()
+	s.table = ""
+	s.fromValues = fmt.Sprintf("(%s) AS %s", subSQL, s.dialect.QuoteIdentifier(alias))
+	s.fromArgs = subArgs
+	return s
+}
+
+// Distinct - SELECT DISTINCT ...
+func (s *selectBuilder) Distinct() SelectBuilder {
+	s.distinct = true
+	return s
+}
+
+// DistinctOn - SELECT DISTINCT ON (columns) ... (PostgreSQL).
+// Для диалектов без DISTINCT ON запрос не выполнится с ErrNotSupported
+func (s *selectBuilder) DistinctOn(columns ...string) SelectBuilder {
+	if !s.dialect.SupportsDistinctOn() && s.err == nil {
+		s.err = fmt.Errorf("DISTINCT ON: %w", ErrNotSupported)
+	}
+	s.distinctOn = append(s.distinctOn, columns...)
+	return s
+}
+
+// WithSchema задает схему для FROM и JOIN таблиц без явной схемы:
+// WithSchema("analytics").From("events") -> FROM `analytics`.`events`.
+// Для JOIN схема применяется в момент вызова, поэтому WithSchema вызывается до Join
+func (s *selectBuilder) WithSchema(schema string) SelectBuilder {
+	s.defaultSchema = schema
+	return s
+}
+
+// Select заменяет список колонок: qc.Select().From("users").Select("id", "name")
+func (s *selectBuilder) Select(columns ...string) SelectBuilder {
+	s.SetColumns(columns...)
+	return s
+}
+
+// AddColumn добавляет одну колонку к текущему списку
+func (s *selectBuilder) AddColumn(column string) SelectBuilder {
+	return s.AddColumns(column)
+}
+
+// AddColumns добавляет колонки к текущему списку
+func (s *selectBuilder) AddColumns(columns ...string) SelectBuilder {
+	s.columns = append(s.columns, columns...)
+	return s
+}
+
+// AddExprColumn добавляет выражение как есть: AddExprColumn("price * ? AS total", 1.2)
+func (s *selectBuilder) AddExprColumn(expr string, args ...any) SelectBuilder {
+	if s.exprColumns == nil {
+		s.exprColumns = make(map[string]bool)
+	}
+	s.exprColumns[expr] = true
+	s.columns = append(s.columns, expr)
+	s.columnArgs = append(s.columnArgs, args...)
+	return s
+}
+
+// SelectRaw добавляет сырое выражение в список колонок без экранирования:
+// SelectRaw("COUNT(*) AS total"). Аргументы выражения идут перед аргументами WHERE
+func (s *selectBuilder) SelectRaw(expression string, args ...any) SelectBuilder {
+	return s.AddExprColumn(expression, args...)
+}
+
+// AddWindowFunc добавляет оконную функцию как колонку:
+// AddWindowFunc("rn", "ROW_NUMBER", []string{"user_id"}, []string{"created_at DESC"}) ->
+// ROW_NUMBER() OVER (PARTITION BY `user_id` ORDER BY `created_at` DESC) AS `rn`.
+// fn со скобками используется как есть: "LAG(price, 1)". Для именованного окна из Window
+// используйте AddExprColumn("RANK() OVER w AS rnk")
+func (s *selectBuilder) AddWindowFunc(alias, fn string, partition []string, orderCols []string) SelectBuilder {
+	if !strings.Contains(fn, "(") {
+		fn += "()"
+	}
+	expr := fmt.Sprintf("%s OVER (%s)", fn, s.windowDefinition(partition, orderCols))
+	if alias != "" {
+		expr += " AS " + s.dialect.QuoteIdentifier(alias)
+	}
+	return s.AddExprColumn(expr)
+}
+
+// Window добавляет именованное окно: WINDOW name AS (definition)
+func (s *selectBuilder) Window(name, definition string) SelectBuilder {
+	s.windows = append(s.windows, fmt.Sprintf("%s AS (%s)", s.dialect.QuoteIdentifier(name), definition))
+	return s
+}
+
+// windowDefinition строит PARTITION BY ... ORDER BY ..., направление сортировки сохраняется
+func (s *selectBuilder) windowDefinition(partition []string, orderCols []string) string {
+	var parts []string
+	if len(partition) > 0 {
+		parts = append(parts, "PARTITION BY "+strings.Join(quoteIdentifiers(s.dialect, partition), ", "))
+	}
+	if len(orderCols) > 0 {
+		orders := make([]string, len(orderCols))
+		for i, col := range orderCols {
+			fields := strings.Fields(col)
+			if len(fields) == 2 && (strings.EqualFold(fields[1], "ASC") || strings.EqualFold(fields[1], "DESC")) {
+				orders[i] = s.dialect.QuoteIdentifier(fields[0]) + " " + strings.ToUpper(fields[1])
+			} else {
+				orders[i] = s.dialect.QuoteIdentifier(col)
+			}
+		}
+		parts = append(parts, "ORDER BY "+strings.Join(orders, ", "))
+	}
+	return strings.Join(parts, " ")
+}
+
+// AddColumnIf добавляет колонку только если condition == true
+func (s *selectBuilder) AddColumnIf(condition bool, column string) SelectBuilder {
+	if condition {
+		return s.AddColumns(column)
+	}
+	return s
+}
+
+func (s *selectBuilder) AddColumnsIf(condition bool, columns ...string) SelectBuilder {
+	if condition {
+		return s.AddColumns(columns...)
+	}
 	return s
 }
 
+func (s *selectBuilder) AddExprColumnIf(condition bool, expr string, args ...any) SelectBuilder {
+	if condition {
+		return s.AddExprColumn(expr, args...)
+	}
+	return s
+}
+
+// WithHint добавляет подсказку оптимизатора, например HintMaxExecutionTime(1000).
+// Все подсказки объединяются в один блок /*+ ... */ после SELECT, если диалект
+// их не поддерживает - подсказки не выводятся
+func (s *selectBuilder) WithHint(hint string) SelectBuilder {
+	// */ внутри подсказки закрыл бы комментарий
+	hint = strings.TrimSpace(strings.ReplaceAll(hint, "*/", ""))
+	if hint != "" {
+		s.hints = append(s.hints, hint)
+	}
+	return s
+}
+
+// HintMaxExecutionTime ограничивает время выполнения запроса в миллисекундах
+func HintMaxExecutionTime(ms int) string {
+	return fmt.Sprintf("MAX_EXECUTION_TIME(%d)", ms)
+}
+
+func HintNoIndexMerge(table string) string {
+	return fmt.Sprintf("NO_INDEX_MERGE(%s)", table)
+}
+
+func HintHashJoin(tables ...string) string {
+	return fmt.Sprintf("HASH_JOIN(%s)", strings.Join(tables, ", "))
+}
+
+// With добавляет CTE: WITH name AS (subquery)
+func (s *selectBuilder) With(name string, subquery SelectBuilder, opts ...CTEOption) SelectBuilder {
+	opt := CTEDefault
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	if err := subquery.Err(); err != nil && s.err == nil {
+		s.err = err
+	}
+	sql, args := subquery.ToSQL()
+	s.ctes = append(s.ctes, s.dialect.FormatCTE(name, sql, opt))
+	s.cteArgs = append(s.cteArgs, args...)
+	s.cteNames = append(s.cteNames, name)
+	return s
+}
+
+// WithRecursive добавляет рекурсивное CTE, весь префикс становится WITH RECURSIVE.
+// Подзапрос обычно строится как anchor.UnionAll(рекурсивная часть, ссылающаяся на name)
+func (s *selectBuilder) WithRecursive(name string, subquery SelectBuilder) SelectBuilder {
+	s.recursiveCTE = true
+	return s.With(name, subquery)
+}
+
+// isCTE проверяет, что таблица (без алиаса) объявлена через With
+func (s *selectBuilder) isCTE(table string) bool {
+	fields := strings.Fields(table)
+	if len(fields) == 0 {
+		return false
+	}
+	for _, name := range s.cteNames {
+		if name == fields[0] {
+			return true
+		}
+	}
+	return false
+}
+
+// qualifyTable добавляет схему по умолчанию ко всем таблицам, кроме CTE
+func (s *selectBuilder) qualifyTable(table string) string {
+	if s.isCTE(table) {
+		return strings.TrimSpace(table)
+	}
+	return qualifyTable(s.defaultSchema, table)
+}
+
 func (s *selectBuilder) Where(column, operator string, value any) SelectBuilder {
 	s.wheres = append(s.wheres, fmt.Sprintf("%s %s %s", s.dialect.QuoteIdentifier(column), operator, s.dialect.PlaceholderFormat()))
 	s.whereArgs = append(s.whereArgs, value)
@@ -251,6 +659,85 @@ func (s *selectBuilder) WhereRaw(condition string, args ...any) SelectBuilder {
 	return s
 }
 
+// WhereLike добавляет условие column LIKE pattern
+func (s *selectBuilder) WhereLike(column, pattern string) SelectBuilder {
+	return s.Where(column, "LIKE", pattern)
+}
+
+func (s *selectBuilder) WhereNotLike(column, pattern string) SelectBuilder {
+	return s.Where(column, "NOT LIKE", pattern)
+}
+
+// WhereILike - регистронезависимый LIKE (ILIKE в PostgreSQL, LIKE в MySQL/SQLite)
+func (s *selectBuilder) WhereILike(column, pattern string) SelectBuilder {
+	return s.Where(column, s.dialect.ILikeOperator(), pattern)
+}
+
+// WhereColumn сравнивает две колонки: `a` = `b`, поддерживает table.column
+func (s *selectBuilder) WhereColumn(first, operator, second string) SelectBuilder {
+	s.wheres = append(s.wheres, fmt.Sprintf("%s %s %s", s.dialect.QuoteIdentifier(first), operator, s.dialect.QuoteIdentifier(second)))
+	return s
+}
+
+// WhereJsonContains - JSON_CONTAINS(col, ?, path) в MySQL, col @> ? в PostgreSQL.
+// Значение сериализуется в JSON, json.RawMessage передается как есть
+func (s *selectBuilder) WhereJsonContains(column, path string, value any) SelectBuilder {
+	doc, err := jsonDocument(value)
+	if err != nil {
+		if s.err == nil {
+			s.err = err
+		}
+		return s
+	}
+	expr, args := s.dialect.JsonContains(s.dialect.QuoteIdentifier(column), path, doc)
+	s.wheres = append(s.wheres, expr)
+	s.whereArgs = append(s.whereArgs, args...)
+	return s
+}
+
+// WhereJsonPath - сравнение значения по пути: JSON_EXTRACT(col, path) op ? в MySQL, col #>> path op ? в PostgreSQL
+func (s *selectBuilder) WhereJsonPath(column, path, operator string, value any) SelectBuilder {
+	expr, args := s.dialect.JsonExtract(s.dialect.QuoteIdentifier(column), path)
+	s.wheres = append(s.wheres, fmt.Sprintf("%s %s %s", expr, operator, s.dialect.PlaceholderFormat()))
+	s.whereArgs = append(s.whereArgs, args...)
+	s.whereArgs = append(s.whereArgs, value)
+	return s
+}
+
+// whereDatePart добавляет условие по части даты, функция берется из диалекта
+func (s *selectBuilder) whereDatePart(part, column, operator string, value any) SelectBuilder {
+	expr := s.dialect.DatePart(part, s.dialect.QuoteIdentifier(column))
+	s.wheres = append(s.wheres, fmt.Sprintf("%s %s %s", expr, operator, s.dialect.PlaceholderFormat()))
+	s.whereArgs = append(s.whereArgs, value)
+	return s
+}
+
+// WhereDate - DATE(column) operator value, value в формате 2006-01-02
+func (s *selectBuilder) WhereDate(column, operator string, value string) SelectBuilder {
+	return s.whereDatePart(dialect.DatePartDate, column, operator, value)
+}
+
+// WhereTime - TIME(column) operator value, value в формате 15:04:05
+func (s *selectBuilder) WhereTime(column, operator string, value string) SelectBuilder {
+	return s.whereDatePart(dialect.DatePartTime, column, operator, value)
+}
+
+func (s *selectBuilder) WhereYear(column, operator string, value int) SelectBuilder {
+	return s.whereDatePart(dialect.DatePartYear, column, operator, value)
+}
+
+func (s *selectBuilder) WhereMonth(column, operator string, value int) SelectBuilder {
+	return s.whereDatePart(dialect.DatePartMonth, column, operator, value)
+}
+
+func (s *selectBuilder) WhereDay(column, operator string, value int) SelectBuilder {
+	return s.whereDatePart(dialect.DatePartDay, column, operator, value)
+}
+
+func (s *selectBuilder) WhereHour(column, operator string, value int) SelectBuilder {
+	return s.whereDatePart(dialect.DatePartHour, column, operator, value)
+}
+
 func (s *selectBuilder) WhereExists(subquery SelectBuilder) SelectBuilder {
 	// For simplicity in this implementation, we'll just add a placeholder
 	// A full implementation would need to handle the subquery properly
@@ -301,12 +788,49 @@ func (s *selectBuilder) OrWhereNotNull(columns ...string) SelectBuilder {
 	return s
 }
 
+func (s *selectBuilder) OrWhereNotIn(column string, values ...any) SelectBuilder {
+	placeholders := make([]string, len(values))
+	for i := range values {
+		placeholders[i] = s.dialect.PlaceholderFormat()
+	}
+	s.wheres = append(s.wheres, fmt.Sprintf("OR %s NOT IN (%s)", s.dialect.QuoteIdentifier(column), strings.Join(placeholders, ", ")))
+	s.whereArgs = append(s.whereArgs, values...)
+	return s
+}
+
+func (s *selectBuilder) OrWhereBetween(column string, from, to any) SelectBuilder {
+	s.wheres = append(s.wheres, fmt.Sprintf("OR %s BETWEEN %s AND %s",
+		s.dialect.QuoteIdentifier(column),
+		s.dialect.PlaceholderFormat(),
+		s.dialect.PlaceholderFormat()))
+	s.whereArgs = append(s.whereArgs, from, to)
+	return s
+}
+
+func (s *selectBuilder) OrWhereNotBetween(column string, from, to any) SelectBuilder {
+	s.wheres = append(s.wheres, fmt.Sprintf("OR %s NOT BETWEEN %s AND %s",
+		s.dialect.QuoteIdentifier(column),
+		s.dialect.PlaceholderFormat(),
+		s.dialect.PlaceholderFormat()))
+	s.whereArgs = append(s.whereArgs, from, to)
+	return s
+}
+
 func (s *selectBuilder) OrWhereRaw(condition string, args ...any) SelectBuilder {
 	s.wheres = append(s.wheres, "OR "+condition)
 	s.whereArgs = append(s.whereArgs, args...)
 	return s
 }
 
+func (s *selectBuilder) OrWhereLike(column, pattern string) SelectBuilder {
+	return s.OrWhere(column, "LIKE", pattern)
+}
+
+func (s *selectBuilder) OrWhereColumn(first, operator, second string) SelectBuilder {
+	s.wheres = append(s.wheres, fmt.Sprintf("OR %s %s %s", s.dialect.QuoteIdentifier(first), operator, s.dialect.QuoteIdentifier(second)))
+	return s
+}
+
 func (s *selectBuilder) WhereGroup(fn func(SelectBuilder) SelectBuilder) SelectBuilder {
 	// Создаем новый билдер с теми же параметрами, но без columns
 	groupBuilder := &selectBuilder{
@@ -326,6 +850,8 @@ func (s *selectBuilder) WhereGroup(fn func(SelectBuilder) SelectBuilder) SelectB
 		var whereParts []string
 		for i, where := range sb.wheres {
 			if i == 0 {
+				// Первое условие группы может прийти из OrWhere*, префикс внутри скобок не нужен
+				where = strings.TrimPrefix(strings.TrimPrefix(where, "AND "), "OR ")
 				whereParts = append(whereParts, where)
 			} else {
 				// Добавляем AND, если условие не начинается с AND, OR или (
@@ -369,6 +895,8 @@ func (s *selectBuilder) OrWhereGroup(fn func(SelectBuilder) SelectBuilder) Selec
 		var whereParts []string
 		for i, where := range sb.wheres {
 			if i == 0 {
+				// Первое условие группы может прийти из OrWhere*, префикс внутри скобок не нужен
+				where = strings.TrimPrefix(strings.TrimPrefix(where, "AND "), "OR ")
 				whereParts = append(whereParts, where)
 			} else {
 				// Добавляем AND, если условие не начинается с AND, OR или (
@@ -393,6 +921,66 @@ func (s *selectBuilder) OrWhereGroup(fn func(SelectBuilder) SelectBuilder) Selec
 	return s
 }
 
+// ResetWhere удаляет все условия WHERE и их аргументы
+func (s *selectBuilder) ResetWhere() SelectBuilder {
+	s.wheres = nil
+	s.whereArgs = nil
+	return s
+}
+
+func (s *selectBuilder) ResetOrder() SelectBuilder {
+	s.orders = nil
+	return s
+}
+
+func (s *selectBuilder) ResetGroup() SelectBuilder {
+	s.groups = nil
+	return s
+}
+
+func (s *selectBuilder) ResetHaving() SelectBuilder {
+	s.havings = nil
+	s.havingArgs = nil
+	return s
+}
+
+func (s *selectBuilder) ResetJoins() SelectBuilder {
+	s.joins = nil
+	s.joinArgs = nil
+	return s
+}
+
+func (s *selectBuilder) ResetLimit() SelectBuilder {
+	s.limit = nil
+	return s
+}
+
+func (s *selectBuilder) ResetOffset() SelectBuilder {
+	s.offset = nil
+	return s
+}
+
+// LazyBuild откладывает fn до ToSQL() или выполнения запроса. Удобно для
+// middleware, например добавить WHERE tenant_id = ? к каждому SELECT.
+// Функции применяются по порядку к копии билдера, сам билдер не изменяется
+func (s *selectBuilder) LazyBuild(fn func(SelectBuilder) SelectBuilder) SelectBuilder {
+	s.lazyFns = append(s.lazyFns, fn)
+	return s
+}
+
+// buildLazySQL применяет функции LazyBuild к копии и строит SQL по ней,
+// чтобы повторный вызов buildSQL не добавлял условия дважды
+func (s *selectBuilder) buildLazySQL() (string, []any) {
+	clone := s.Clone().(*selectBuilder)
+	clone.lazyFns = nil
+
+	var builder SelectBuilder = clone
+	for _, fn := range s.lazyFns {
+		builder = fn(builder)
+	}
+	return builder.ToSQL()
+}
+
 func (s *selectBuilder) When(condition bool, column, operator string, value any) SelectBuilder {
 	if condition {
 		return s.Where(column, operator, value)
@@ -440,32 +1028,63 @@ func (s *selectBuilder) quoteJoinCondition(condition string) string {
 	})
 }
 
+// quoteJoinTable добавляет схему билдера к таблице без схемы и экранирует ее
+func (s *selectBuilder) quoteJoinTable(table string) string {
+	return s.quoteTableNameWithAlias(s.qualifyTable(table))
+}
+
 func (s *selectBuilder) Join(table, condition string) SelectBuilder {
 	return s.InnerJoin(table, condition)
 }
 
 func (s *selectBuilder) InnerJoin(table, condition string) SelectBuilder {
-	s.joins = append(s.joins, fmt.Sprintf("INNER JOIN %s ON %s", s.quoteTableNameWithAlias(table), s.quoteJoinCondition(condition)))
+	s.joins = append(s.joins, fmt.Sprintf("INNER JOIN %s ON %s", s.quoteJoinTable(table), s.quoteJoinCondition(condition)))
 	return s
 }
 
 func (s *selectBuilder) LeftJoin(table, condition string) SelectBuilder {
-	s.joins = append(s.joins, fmt.Sprintf("LEFT JOIN %s ON %s", s.quoteTableNameWithAlias(table), s.quoteJoinCondition(condition)))
+	s.joins = append(s.joins, fmt.Sprintf("LEFT JOIN %s ON %s", s.quoteJoinTable(table), s.quoteJoinCondition(condition)))
 	return s
 }
 
 func (s *selectBuilder) RightJoin(table, condition string) SelectBuilder {
-	s.joins = append(s.joins, fmt.Sprintf("RIGHT JOIN %s ON %s", s.quoteTableNameWithAlias(table), s.quoteJoinCondition(condition)))
+	s.joins = append(s.joins, fmt.Sprintf("RIGHT JOIN %s ON %s", s.quoteJoinTable(table), s.quoteJoinCondition(condition)))
 	return s
 }
 
 func (s *selectBuilder) CrossJoin(table string) SelectBuilder {
-	s.joins = append(s.joins, fmt.Sprintf("CROSS JOIN %s", s.quoteTableNameWithAlias(table)))
+	s.joins = append(s.joins, fmt.Sprintf("CROSS JOIN %s", s.quoteJoinTable(table)))
 	return s
 }
 
 func (s *selectBuilder) OuterJoin(table, condition string) SelectBuilder {
-	s.joins = append(s.joins, fmt.Sprintf("OUTER JOIN %s ON %s", s.quoteTableNameWithAlias(table), s.quoteJoinCondition(condition)))
+	s.joins = append(s.joins, fmt.Sprintf("OUTER JOIN %s ON %s", s.quoteJoinTable(table), s.quoteJoinCondition(condition)))
+	return s
+}
+
+// CrossApply - CROSS APPLY (подзапрос) alias, в PostgreSQL JOIN LATERAL.
+// Подзапрос может ссылаться на колонки внешнего запроса, например "top N per group"
+func (s *selectBuilder) CrossApply(subquery SelectBuilder, alias string) SelectBuilder {
+	return s.apply(dialect.ApplyCross, subquery, alias)
+}
+
+// OuterApply - OUTER APPLY (подзапрос) alias, в PostgreSQL LEFT JOIN LATERAL
+func (s *selectBuilder) OuterApply(subquery SelectBuilder, alias string) SelectBuilder {
+	return s.apply(dialect.ApplyOuter, subquery, alias)
+}
+
+func (s *selectBuilder) apply(kind string, subquery SelectBuilder, alias string) SelectBuilder {
+	subSQL, subArgs := subquery.ToSQL()
+	clause := s.dialect.FormatApply(kind, subSQL, alias)
+	if clause == "" {
+		// MySQL: используйте JOIN с производной таблицей или оконные функции
+		if s.err == nil {
+			s.err = fmt.Errorf("%s APPLY: %w", kind, ErrNotSupported)
+		}
+		return s
+	}
+	s.joins = append(s.joins, clause)
+	s.joinArgs = append(s.joinArgs, subArgs...)
 	return s
 }
 
@@ -474,7 +1093,7 @@ func (s *selectBuilder) OrderBy(columns ...string) SelectBuilder {
 		if column == "" {
 			continue
 		}
-		s.orders = append(s.orders, s.dialect.SelectOrderBy(column, false))
+		s.orders = append(s.orders, orderExpression(s.dialect.SelectOrderBy(column, false)))
 	}
 	return s
 }
@@ -484,14 +1103,15 @@ func (s *selectBuilder) OrderByDesc(columns ...string) SelectBuilder {
 		if column == "" {
 			continue
 		}
-		s.orders = append(s.orders, s.dialect.SelectOrderBy(column, true))
+		s.orders = append(s.orders, orderExpression(s.dialect.SelectOrderBy(column, true)))
 	}
 
 	return s
 }
 
+// OrderByRaw добавляет выражение сортировки как есть, случайный префикс "ORDER BY " убирается
 func (s *selectBuilder) OrderByRaw(expression string) SelectBuilder {
-	s.orders = append(s.orders, expression)
+	s.orders = append(s.orders, orderExpression(expression))
 	return s
 }
 
@@ -510,6 +1130,42 @@ func (s *selectBuilder) Having(condition string, args ...any) SelectBuilder {
 	return s
 }
 
+// HavingRaw - синоним Having для произвольных выражений
+func (s *selectBuilder) HavingRaw(condition string, args ...any) SelectBuilder {
+	return s.Having(condition, args...)
+}
+
+func (s *selectBuilder) OrHaving(condition string, args ...any) SelectBuilder {
+	s.havings = append(s.havings, "OR "+condition)
+	s.havingArgs = append(s.havingArgs, args...)
+	return s
+}
+
+// HavingGroup добавляет условия HAVING из fn в скобках: HAVING (a AND b)
+func (s *selectBuilder) HavingGroup(fn func(SelectBuilder) SelectBuilder) SelectBuilder {
+	groupBuilder := &selectBuilder{
+		db:         s.db,
+		dialect:    s.dialect,
+		ctx:        s.ctx,
+		havings:    make([]string, 0),
+		havingArgs: make([]any, 0),
+	}
+
+	builder := fn(groupBuilder)
+
+	if sb, ok := builder.(*selectBuilder); ok && len(sb.havings) > 0 {
+		group := fmt.Sprintf("(%s)", joinConditions(sb.havings))
+		// AND только если у нас уже есть условия
+		if len(s.havings) > 0 {
+			group = "AND " + group
+		}
+		s.havings = append(s.havings, group)
+		s.havingArgs = append(s.havingArgs, sb.havingArgs...)
+	}
+
+	return s
+}
+
 func (s *selectBuilder) Limit(limit int) SelectBuilder {
 	s.limit = &limit
 	return s
@@ -525,16 +1181,124 @@ func (s *selectBuilder) Page(page, perPage int) SelectBuilder {
 	return s.Limit(perPage).Offset(offset)
 }
 
+// ForUpdate - SELECT ... FOR UPDATE, блокировка выбранных строк на запись
+func (s *selectBuilder) ForUpdate() SelectBuilder {
+	s.lock = dialect.LockForUpdate
+	return s
+}
+
+// ForShare - разделяемая блокировка: FOR SHARE в PostgreSQL, LOCK IN SHARE MODE в MySQL
+func (s *selectBuilder) ForShare() SelectBuilder {
+	s.lock = dialect.LockForShare
+	return s
+}
+
+// LockInShareMode - синоним ForShare в терминах MySQL
+func (s *selectBuilder) LockInShareMode() SelectBuilder {
+	return s.ForShare()
+}
+
+func (s *selectBuilder) UseIndex(indexes ...string) SelectBuilder {
+	return s.addIndexHint(dialect.IndexHintUse, indexes)
+}
+
+func (s *selectBuilder) ForceIndex(index string) SelectBuilder {
+	return s.addIndexHint(dialect.IndexHintForce, []string{index})
+}
+
+func (s *selectBuilder) IgnoreIndex(indexes ...string) SelectBuilder {
+	return s.addIndexHint(dialect.IndexHintIgnore, indexes)
+}
+
+// Partition ограничивает чтение указанными партициями, в диалектах без поддержки игнорируется
+func (s *selectBuilder) Partition(partitions ...string) SelectBuilder {
+	s.partitions = append(s.partitions, partitions...)
+	return s
+}
+
+// addIndexHint сохраняет подсказку, если диалект ее поддерживает
+func (s *selectBuilder) addIndexHint(kind string, indexes []string) SelectBuilder {
+	if len(indexes) == 0 {
+		return s
+	}
+	if hint := s.dialect.IndexHint(kind, indexes); hint != "" {
+		s.indexHints = append(s.indexHints, hint)
+	}
+	return s
+}
+
+// UseSubqueryCount включает подсчет total в Paginate через подзапрос
+func (s *selectBuilder) UseSubqueryCount(enabled bool) SelectBuilder {
+	s.useSubqueryCount = enabled
+	return s
+}
+
 func (s *selectBuilder) Paginate(page, perPage int) (*PaginationResult, error) {
-	// Calculate offset
-	offset := (page - 1) * perPage
+	// С GROUP BY и UNION COUNT(*) считает не строки результата, поэтому нужен подзапрос
+	if s.useSubqueryCount || len(s.groups) > 0 || len(s.setOps) > 0 {
+		return s.PaginateWithSubqueryCount(page, perPage)
+	}
+
+	// Блокировка нужна только основному запросу, PostgreSQL не допускает FOR UPDATE с агрегатами
+	countBuilder := s.Clone().(*selectBuilder)
+	countBuilder.lock = ""
+	count, err := countBuilder.Count()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.paginate(page, perPage, count)
+}
 
-	// Get total count
-	count, err := s.Clone().Count()
+// PaginateWithSubqueryCount - как Paginate, но total считается запросом
+// SELECT COUNT(*) FROM (original_sql) AS _count, что корректно для GROUP BY
+func (s *selectBuilder) PaginateWithSubqueryCount(page, perPage int) (*PaginationResult, error) {
+	count, err := s.subqueryCount("*")
 	if err != nil {
 		return nil, err
 	}
 
+	return s.paginate(page, perPage, count)
+}
+
+// subqueryCount считает COUNT(column) по строкам исходного запроса, обернутого в подзапрос
+func (s *selectBuilder) subqueryCount(column string) (int64, error) {
+	countBuilder := s.Clone().(*selectBuilder)
+	countBuilder.orders = nil
+	countBuilder.offset = nil
+	countBuilder.lock = ""
+	maxLimit := 2147483647
+	countBuilder.limit = &maxLimit
+
+	if s.err != nil {
+		return 0, s.err
+	}
+	query, args := countBuilder.buildSQL()
+	countSQL := fmt.Sprintf("SELECT COUNT(%s) as count FROM (%s) AS _count", column, query)
+
+	// Log query if logger is set
+	var start time.Time
+	if s.logger != nil {
+		logQueryStart(s.logger, s.ctx, countSQL, args)
+		start = time.Now()
+	}
+
+	var count int64
+	err := s.db.GetContext(s.ctx, &count, countSQL, args...)
+
+	// Log query execution
+	if s.logger != nil {
+		duration := time.Since(start)
+		logQuery(s.logger, s.ctx, countSQL, args, duration, err)
+	}
+
+	return count, err
+}
+
+func (s *selectBuilder) paginate(page, perPage int, count int64) (*PaginationResult, error) {
+	// Calculate offset
+	offset := (page - 1) * perPage
+
 	// Calculate last page
 	lastPage := int((count + int64(perPage) - 1) / int64(perPage))
 
@@ -566,6 +1330,40 @@ func (s *selectBuilder) Paginate(page, perPage int) (*PaginationResult, error) {
 	}, nil
 }
 
+// SimplePaginate - пагинация без запроса COUNT(*): выбирается perPage+1 строк,
+// лишняя строка означает, что есть следующая страница
+func (s *selectBuilder) SimplePaginate(page, perPage int) (*SimplePaginationResult, error) {
+	offset := (page - 1) * perPage
+
+	s.Limit(perPage + 1).Offset(offset)
+
+	data, err := s.Rows()
+	if err != nil {
+		return nil, err
+	}
+
+	hasMore := len(data) > perPage
+	if hasMore {
+		data = data[:perPage]
+	}
+
+	from := 0
+	to := 0
+	if len(data) > 0 {
+		from = offset + 1
+		to = offset + len(data)
+	}
+
+	return &SimplePaginationResult{
+		Data:        data,
+		PerPage:     perPage,
+		CurrentPage: page,
+		HasMore:     hasMore,
+		From:        from,
+		To:          to,
+	}, nil
+}
+
 func (s *selectBuilder) KeysetPaginate(column string, lastValue any, perPage int, direction string) (*KeysetPaginationResult, error) {
 	// Validate direction
 	if direction != "asc" && direction != "desc" {
@@ -638,6 +1436,7 @@ func (s *selectBuilder) Clone() SelectBuilder {
 		db:      s.db,
 		dialect: s.dialect,
 		ctx:     s.ctx,
+		ctes:    make([]string, len(s.ctes)),
 		columns: make([]string, len(s.columns)),
 		table:   s.table,
 		joins:   make([]string, len(s.joins)),
@@ -647,8 +1446,25 @@ func (s *selectBuilder) Clone() SelectBuilder {
 		havings: make([]string, len(s.havings)),
 		limit:   s.limit,
 		offset:  s.offset,
-	}
 
+		useSubqueryCount: s.useSubqueryCount,
+		defaultSchema:    s.defaultSchema,
+		lazyFns:          make([]func(SelectBuilder) SelectBuilder, len(s.lazyFns)),
+	}
+
+	copy(clone.ctes, s.ctes)
+	clone.cteNames = append([]string(nil), s.cteNames...)
+	clone.windows = append([]string(nil), s.windows...)
+	clone.distinct = s.distinct
+	clone.distinctOn = append([]string(nil), s.distinctOn...)
+	clone.recursiveCTE = s.recursiveCTE
+	clone.setOps = append([]setOperation(nil), s.setOps...)
+	clone.err = s.err
+	clone.lock = s.lock
+	clone.indexHints = append([]string(nil), s.indexHints...)
+	clone.partitions = append([]string(nil), s.partitions...)
+	clone.hints = append([]string(nil), s.hints...)
+	copy(clone.lazyFns, s.lazyFns)
 	copy(clone.columns, s.columns)
 	copy(clone.joins, s.joins)
 	copy(clone.wheres, s.wheres)
@@ -657,6 +1473,24 @@ func (s *selectBuilder) Clone() SelectBuilder {
 	copy(clone.havings, s.havings)
 
 	// Copy args slices
+	clone.cteArgs = make([]any, len(s.cteArgs))
+	copy(clone.cteArgs, s.cteArgs)
+
+	if s.exprColumns != nil {
+		clone.exprColumns = make(map[string]bool, len(s.exprColumns))
+		for expr := range s.exprColumns {
+			clone.exprColumns[expr] = true
+		}
+	}
+	clone.columnArgs = make([]any, len(s.columnArgs))
+	copy(clone.columnArgs, s.columnArgs)
+
+	clone.fromValues = s.fromValues
+	clone.fromArgs = append([]any(nil), s.fromArgs...)
+
+	clone.joinArgs = make([]any, len(s.joinArgs))
+	copy(clone.joinArgs, s.joinArgs)
+
 	clone.whereArgs = make([]any, len(s.whereArgs))
 	copy(clone.whereArgs, s.whereArgs)
 
@@ -668,6 +1502,15 @@ func (s *selectBuilder) Clone() SelectBuilder {
 
 // quoteTableNameWithAlias экранирует имя таблицы с учетом возможного алиаса
 func (s *selectBuilder) quoteTableNameWithAlias(tableName string) string {
+	return s.quoteTableWithPartition(tableName, "")
+}
+
+// quoteTableWithPartition экранирует таблицу и ставит PARTITION (...) между именем и алиасом
+func (s *selectBuilder) quoteTableWithPartition(tableName, partition string) string {
+	if partition != "" {
+		partition = " " + partition
+	}
+
 	// Разделяем имя таблицы и алиас по ключевым словам
 	// Поддерживаем различные варианты: "table as alias", "table alias"
 	re := regexp.MustCompile(`(?i)^(.+?)\s+(as\s+)?(.+?)$`)
@@ -677,37 +1520,75 @@ func (s *selectBuilder) quoteTableNameWithAlias(tableName string) string {
 		// Найден алиас
 		table := strings.TrimSpace(matches[1])
 		alias := strings.TrimSpace(matches[3])
-		return fmt.Sprintf("%s as %s", s.dialect.QuoteIdentifier(table), alias)
+		return fmt.Sprintf("%s%s as %s", s.dialect.QuoteIdentifier(table), partition, alias)
 	}
 
 	// Нет алиаса, просто экранируем имя таблицы
-	return s.dialect.QuoteIdentifier(tableName)
+	return s.dialect.QuoteIdentifier(tableName) + partition
 }
 
 func (s *selectBuilder) buildSQL() (string, []any) {
+	if len(s.lazyFns) > 0 {
+		return s.buildLazySQL()
+	}
+
 	var queryParts []string
 	var args []any
 
+	// WITH
+	if len(s.ctes) > 0 {
+		withKeyword := "WITH"
+		if s.recursiveCTE {
+			withKeyword = "WITH RECURSIVE"
+		}
+		queryParts = append(queryParts, fmt.Sprintf("%s %s", withKeyword, strings.Join(s.ctes, ", ")))
+		args = append(args, s.cteArgs...)
+	}
+
 	// SELECT
+	selectKeyword := "SELECT"
+	if len(s.hints) > 0 && s.dialect.SupportOptimizerHints() {
+		selectKeyword = fmt.Sprintf("SELECT /*+ %s */", strings.Join(s.hints, " "))
+	}
+	if len(s.distinctOn) > 0 {
+		selectKeyword += fmt.Sprintf(" DISTINCT ON (%s)", strings.Join(quoteIdentifiers(s.dialect, s.distinctOn), ", "))
+	} else if s.distinct {
+		selectKeyword += " DISTINCT"
+	}
 	if len(s.columns) == 0 {
-		queryParts = append(queryParts, "SELECT *")
+		queryParts = append(queryParts, selectKeyword+" *")
 	} else {
 		quotedColumns := make([]string, len(s.columns))
 		for i, col := range s.columns {
+			if s.exprColumns[col] {
+				quotedColumns[i] = col
+				continue
+			}
 			quotedColumns[i] = s.dialect.QuoteIdentifier(col)
 		}
-		queryParts = append(queryParts, fmt.Sprintf("SELECT %s", strings.Join(quotedColumns, ", ")))
+		queryParts = append(queryParts, fmt.Sprintf("%s %s", selectKeyword, strings.Join(quotedColumns, ", ")))
+		args = append(args, s.columnArgs...)
 	}
 
 	// FROM
 	if s.table != "" {
 		// Экранируем имя таблицы с учетом возможного алиаса
-		queryParts = append(queryParts, fmt.Sprintf("FROM %s", s.quoteTableNameWithAlias(s.table)))
+		var partition string
+		if len(s.partitions) > 0 {
+			partition = s.dialect.PartitionSelection(s.partitions)
+		}
+		queryParts = append(queryParts, fmt.Sprintf("FROM %s", s.quoteTableWithPartition(s.qualifyTable(s.table), partition)))
+		// Подсказки индексов идут сразу после таблицы, до JOIN
+		queryParts = append(queryParts, s.indexHints...)
+	} else if s.fromValues != "" {
+		queryParts = append(queryParts, "FROM "+s.fromValues)
+		args = append(args, s.fromArgs...)
 	}
 
 	// JOIN
 	if len(s.joins) > 0 {
 		queryParts = append(queryParts, strings.Join(s.joins, " "))
+		args = append(args, s.joinArgs...)
 	}
 
 	// WHERE
@@ -746,25 +1627,25 @@ func (s *selectBuilder) buildSQL() (string, []any) {
 
 	// HAVING
 	if len(s.havings) > 0 {
-		queryParts = append(queryParts, fmt.Sprintf("HAVING %s", strings.Join(s.havings, " ")))
+		queryParts = append(queryParts, fmt.Sprintf("HAVING %s", joinConditions(s.havings)))
 		args = append(args, s.havingArgs...)
 	}
 
+	// WINDOW
+	if len(s.windows) > 0 {
+		queryParts = append(queryParts, "WINDOW "+strings.Join(s.windows, ", "))
+	}
+
+	// UNION / INTERSECT / EXCEPT: ORDER BY, LIMIT и OFFSET относятся ко всему результату
+	if len(s.setOps) > 0 {
+		compound, compoundArgs := s.buildSetOperations(strings.Join(queryParts, " "), args)
+		queryParts, args = []string{compound}, compoundArgs
+	}
+
 	// ORDER BY
 	if len(s.orders) > 0 {
-		// Для OrderByRaw выражения уже содержат "ORDER BY", для OrderBy и OrderByDesc - нет
-		// Нам нужно проверить, содержат ли выражения префикс "ORDER BY"
-		var orderParts []string
-		for _, order := range s.orders {
-			if strings.HasPrefix(strings.ToUpper(order), "ORDER BY") {
-				// Это выражение от OrderByRaw, используем как есть
-				orderParts = append(orderParts, order)
-			} else {
-				// Это выражение от OrderBy или OrderByDesc, добавляем префикс
-				orderParts = append(orderParts, "ORDER BY "+order)
-			}
-		}
-		queryParts = append(queryParts, strings.Join(orderParts, " "))
+		// Выражения хранятся без префикса: ORDER BY a, b DESC
+		queryParts = append(queryParts, "ORDER BY "+strings.Join(s.orders, ", "))
 	}
 
 	// LIMIT
@@ -777,7 +1658,15 @@ func (s *selectBuilder) buildSQL() (string, []any) {
 		queryParts = append(queryParts, s.dialect.SelectOffset(*s.offset))
 	}
 
-	return strings.Join(queryParts, " "), args
+	// FOR UPDATE / FOR SHARE / LOCK IN SHARE MODE
+	if s.lock != "" {
+		if lock := s.dialect.SelectLock(s.lock); lock != "" {
+			queryParts = append(queryParts, lock)
+		}
+	}
+
+	// Плейсхолдеры в формате драйвера ($1, $2... для PostgreSQL)
+	return s.dialect.Rebind(strings.Join(queryParts, " ")), args
 }
 
 //Exec Methods
@@ -786,18 +1675,67 @@ func (s *selectBuilder) ToSQL() (string, []any) {
 	return s.buildSQL()
 }
 
+// ToInsertSQL строит INSERT INTO targetTable (columns) SELECT ... из текущего запроса
+func (s *selectBuilder) ToInsertSQL(targetTable string, columns ...string) (string, []any) {
+	insert := &insertBuilder{
+		dialect:    s.dialect,
+		table:      targetTable,
+		columns:    columns,
+		fromSelect: s,
+	}
+	return insert.buildFromSelectSQL()
+}
+
+// NormalizedSQL возвращает канонический SQL без значений (см. NormalizeSQL)
+func (s *selectBuilder) NormalizedSQL() string {
+	sql, _ := s.buildSQL()
+	return NormalizeSQL(sql)
+}
+
+// Fingerprint возвращает хэш шаблона запроса (см. Fingerprint)
+func (s *selectBuilder) Fingerprint() string {
+	sql, _ := s.buildSQL()
+	return Fingerprint(sql)
+}
+
 func (s *selectBuilder) PrintSQL() SelectBuilder {
 	s.printSQL = true
 	return s
 }
 
+// PrettyPrint печатает SQL в многострочном виде (см. FormatSQL)
+func (s *selectBuilder) PrettyPrint() SelectBuilder {
+	s.printSQL = true
+	s.prettySQL = true
+	return s
+}
+
 func (s *selectBuilder) setLogger(logger Logger) {
 	s.logger = logger
 }
 
 func (s *selectBuilder) Explain() ([]map[string]any, error) {
+	return s.explain(false, "")
+}
+
+// ExplainFormat - EXPLAIN FORMAT=JSON (MySQL) / EXPLAIN (FORMAT JSON) (PostgreSQL).
+// Для JSON в каждую строку добавляется поле Plan с разобранным планом
+func (s *selectBuilder) ExplainFormat(format string) ([]map[string]any, error) {
+	return s.explain(false, format)
+}
+
+// ExplainAnalyze выполняет запрос и возвращает фактический план:
+// EXPLAIN ANALYZE в PostgreSQL, EXPLAIN ANALYZE FORMAT=JSON в MySQL 8+
+func (s *selectBuilder) ExplainAnalyze() ([]map[string]any, error) {
+	return s.explain(true, "")
+}
+
+func (s *selectBuilder) explain(analyze bool, format string) ([]map[string]any, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
 	sql, args := s.buildSQL()
-	explainSQL := fmt.Sprintf("EXPLAIN %s", sql)
+	explainSQL := fmt.Sprintf("%s %s", s.dialect.ExplainPrefix(analyze, format), sql)
 
 	rows, err := s.db.QueryxContext(s.ctx, explainSQL, args...)
 	if err != nil {
@@ -811,12 +1749,105 @@ func (s *selectBuilder) Explain() ([]map[string]any, error) {
 		if err := rows.MapScan(row); err != nil {
 			return nil, err
 		}
-		results = append(results, convertByteArrayToString(row))
+		results = append(results, withParsedPlan(convertByteArrayToString(row)))
 	}
 
 	return results, nil
 }
 
+// withParsedPlan добавляет поле Plan, если единственная колонка строки содержит JSON план
+func withParsedPlan(row map[string]any) map[string]any {
+	if len(row) != 1 {
+		return row
+	}
+	for _, value := range row {
+		text, ok := value.(string)
+		if !ok {
+			continue
+		}
+		text = strings.TrimSpace(text)
+		if !strings.HasPrefix(text, "{") && !strings.HasPrefix(text, "[") {
+			continue
+		}
+		var plan any
+		if err := json.Unmarshal([]byte(text), &plan); err == nil {
+			row["Plan"] = plan
+		}
+	}
+	return row
+}
+
+// Benchmark выполняет запрос runs раз, отбрасывая строки, и считает статистику
+func (s *selectBuilder) Benchmark(runs int) (BenchmarkResult, error) {
+	return s.BenchmarkContext(s.ctx, runs)
+}
+
+// BenchmarkContext - Benchmark с проверкой отмены контекста между запусками
+func (s *selectBuilder) BenchmarkContext(ctx context.Context, runs int) (BenchmarkResult, error) {
+	if runs <= 0 {
+		return BenchmarkResult{}, errors.New("runs must be greater than 0")
+	}
+	if s.err != nil {
+		return BenchmarkResult{}, s.err
+	}
+
+	sql, args := s.buildSQL()
+	durations := make([]time.Duration, 0, runs)
+
+	for i := 0; i < runs; i++ {
+		if err := ctx.Err(); err != nil {
+			return BenchmarkResult{}, err
+		}
+
+		start := time.Now()
+		rows, err := s.db.QueryContext(ctx, sql, args...)
+		if err != nil {
+			return BenchmarkResult{}, err
+		}
+		// Читаем все строки, чтобы учесть время передачи результата
+		for rows.Next() {
+		}
+		err = rows.Err()
+		rows.Close()
+		duration := time.Since(start)
+
+		if s.logger != nil {
+			logQuery(s.logger, ctx, sql, args, duration, err)
+		}
+		if err != nil {
+			return BenchmarkResult{}, err
+		}
+
+		durations = append(durations, duration)
+	}
+
+	return newBenchmarkResult(durations), nil
+}
+
+func newBenchmarkResult(durations []time.Duration) BenchmarkResult {
+	sorted := make([]time.Duration, len(durations))
+	copy(sorted, durations)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var total time.Duration
+	for _, d := range sorted {
+		total += d
+	}
+
+	median := sorted[len(sorted)/2]
+	if len(sorted)%2 == 0 {
+		median = (sorted[len(sorted)/2-1] + sorted[len(sorted)/2]) / 2
+	}
+
+	return BenchmarkResult{
+		Min:    sorted[0],
+		Max:    sorted[len(sorted)-1],
+		Mean:   total / time.Duration(len(sorted)),
+		Median: median,
+		Runs:   len(sorted),
+	}
+}
+
 func (s *selectBuilder) One(dest any) error {
 	// Проверяем, является ли dest *map[string]any
 	// В этом случае используем Row()
@@ -829,6 +1860,9 @@ func (s *selectBuilder) One(dest any) error {
 		*(dest.(*map[string]any)) = row
 		return nil
 	default:
+		if s.err != nil {
+			return s.err
+		}
 		sql, args := s.buildSQL()
 
 		// Print SQL if needed
@@ -838,12 +1872,16 @@ func (s *selectBuilder) One(dest any) error {
 			for _, arg := range args {
 				formattedSQL = strings.Replace(formattedSQL, s.dialect.PlaceholderFormat(), formatArg(arg), 1)
 			}
+			if s.prettySQL {
+				formattedSQL = FormatSQL(formattedSQL)
+			}
 			fmt.Println(formattedSQL)
 		}
 
 		// Log query if logger is set
 		var start time.Time
 		if s.logger != nil {
+			logQueryStart(s.logger, s.ctx, sql, args)
 			start = time.Now()
 		}
 
@@ -852,7 +1890,7 @@ func (s *selectBuilder) One(dest any) error {
 		// Log query execution
 		if s.logger != nil {
 			duration := time.Since(start)
-			s.logger.LogQuery(s.ctx, sql, args, duration, err)
+			logQuery(s.logger, s.ctx, sql, args, duration, err)
 		}
 
 		return err
@@ -883,6 +1921,9 @@ func (s *selectBuilder) All(dest any) error {
 		*(dest.(*[]map[string]any)) = rows
 		return nil
 	default:
+		if s.err != nil {
+			return s.err
+		}
 		sql, args := s.buildSQL()
 
 		// Print SQL if needed
@@ -892,12 +1933,16 @@ func (s *selectBuilder) All(dest any) error {
 			for _, arg := range args {
 				formattedSQL = strings.Replace(formattedSQL, s.dialect.PlaceholderFormat(), formatArg(arg), 1)
 			}
+			if s.prettySQL {
+				formattedSQL = FormatSQL(formattedSQL)
+			}
 			fmt.Println(formattedSQL)
 		}
 
 		// Log query if logger is set
 		var start time.Time
 		if s.logger != nil {
+			logQueryStart(s.logger, s.ctx, sql, args)
 			start = time.Now()
 		}
 
@@ -906,7 +1951,7 @@ func (s *selectBuilder) All(dest any) error {
 		// Log query execution
 		if s.logger != nil {
 			duration := time.Since(start)
-			s.logger.LogQuery(s.ctx, sql, args, duration, err)
+			logQuery(s.logger, s.ctx, sql, args, duration, err)
 		}
 
 		return err
@@ -928,6 +1973,9 @@ func (s *selectBuilder) Get(dest any) (bool, error) {
 }
 
 func (s *selectBuilder) Row() (map[string]any, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
 	query, args := s.buildSQL()
 
 	// Print SQL if needed
@@ -937,12 +1985,16 @@ func (s *selectBuilder) Row() (map[string]any, error) {
 		for _, arg := range args {
 			formattedSQL = strings.Replace(formattedSQL, s.dialect.PlaceholderFormat(), formatArg(arg), 1)
 		}
+		if s.prettySQL {
+			formattedSQL = FormatSQL(formattedSQL)
+		}
 		fmt.Println(formattedSQL)
 	}
 
 	// Log query if logger is set
 	var start time.Time
 	if s.logger != nil {
+		logQueryStart(s.logger, s.ctx, query, args)
 		start = time.Now()
 	}
 
@@ -951,7 +2003,7 @@ func (s *selectBuilder) Row() (map[string]any, error) {
 		// Log query execution
 		if s.logger != nil {
 			duration := time.Since(start)
-			s.logger.LogQuery(s.ctx, query, args, duration, err)
+			logQuery(s.logger, s.ctx, query, args, duration, err)
 		}
 		return nil, err
 	}
@@ -963,7 +2015,7 @@ func (s *selectBuilder) Row() (map[string]any, error) {
 			// Log query execution
 			if s.logger != nil {
 				duration := time.Since(start)
-				s.logger.LogQuery(s.ctx, query, args, duration, err)
+				logQuery(s.logger, s.ctx, query, args, duration, err)
 			}
 			return nil, err
 		}
@@ -971,7 +2023,7 @@ func (s *selectBuilder) Row() (map[string]any, error) {
 		// Log query execution
 		if s.logger != nil {
 			duration := time.Since(start)
-			s.logger.LogQuery(s.ctx, query, args, duration, nil)
+			logQuery(s.logger, s.ctx, query, args, duration, nil)
 		}
 
 		row = convertByteArrayToString(row)
@@ -981,13 +2033,16 @@ func (s *selectBuilder) Row() (map[string]any, error) {
 	// Log query execution
 	if s.logger != nil {
 		duration := time.Since(start)
-		s.logger.LogQuery(s.ctx, query, args, duration, sql.ErrNoRows)
+		logQuery(s.logger, s.ctx, query, args, duration, sql.ErrNoRows)
 	}
 
 	return nil, sql.ErrNoRows
 }
 
 func (s *selectBuilder) Rows() ([]map[string]any, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
 	sql, args := s.buildSQL()
 
 	// Print SQL if needed
@@ -997,12 +2052,16 @@ func (s *selectBuilder) Rows() ([]map[string]any, error) {
 		for _, arg := range args {
 			formattedSQL = strings.Replace(formattedSQL, s.dialect.PlaceholderFormat(), formatArg(arg), 1)
 		}
+		if s.prettySQL {
+			formattedSQL = FormatSQL(formattedSQL)
+		}
 		fmt.Println(formattedSQL)
 	}
 
 	// Log query if logger is set
 	var start time.Time
 	if s.logger != nil {
+		logQueryStart(s.logger, s.ctx, sql, args)
 		start = time.Now()
 	}
 
@@ -1011,7 +2070,7 @@ func (s *selectBuilder) Rows() ([]map[string]any, error) {
 		// Log query execution
 		if s.logger != nil {
 			duration := time.Since(start)
-			s.logger.LogQuery(s.ctx, sql, args, duration, err)
+			logQuery(s.logger, s.ctx, sql, args, duration, err)
 		}
 		return nil, err
 	}
@@ -1024,7 +2083,7 @@ func (s *selectBuilder) Rows() ([]map[string]any, error) {
 			// Log query execution
 			if s.logger != nil {
 				duration := time.Since(start)
-				s.logger.LogQuery(s.ctx, sql, args, duration, err)
+				logQuery(s.logger, s.ctx, sql, args, duration, err)
 			}
 			return nil, err
 		}
@@ -1034,13 +2093,16 @@ func (s *selectBuilder) Rows() ([]map[string]any, error) {
 	// Log query execution
 	if s.logger != nil {
 		duration := time.Since(start)
-		s.logger.LogQuery(s.ctx, sql, args, duration, nil)
+		logQuery(s.logger, s.ctx, sql, args, duration, nil)
 	}
 
 	return results, nil
 }
 
 func (s *selectBuilder) RowsMapKey(keyColumn string) (map[any]map[string]any, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
 	sql, args := s.buildSQL()
 
 	rows, err := s.db.QueryxContext(s.ctx, sql, args...)
@@ -1068,12 +2130,7 @@ func (s *selectBuilder) RowsMapKey(keyColumn string) (map[any]map[string]any, er
 }
 
 func (s *selectBuilder) Field(column string) (any, error) {
-	originalColumns := s.columns
-	s.columns = []string{column}
-
-	defer func() {
-		s.columns = originalColumns
-	}()
+	defer s.withColumns(column)()
 
 	row, err := s.Row()
 	if err != nil {
@@ -1088,13 +2145,40 @@ func (s *selectBuilder) Field(column string) (any, error) {
 	return value, nil
 }
 
-func (s *selectBuilder) Pluck(column string) ([]any, error) {
-	originalColumns := s.columns
-	s.columns = []string{column}
+func (s *selectBuilder) FieldInt64(column string) (int64, error) {
+	value, err := s.Field(column)
+	if err != nil {
+		return 0, err
+	}
+	return fieldAs(value, column, toInt64)
+}
 
-	defer func() {
-		s.columns = originalColumns
-	}()
+func (s *selectBuilder) FieldString(column string) (string, error) {
+	value, err := s.Field(column)
+	if err != nil {
+		return "", err
+	}
+	return fieldAs(value, column, toString)
+}
+
+func (s *selectBuilder) FieldFloat64(column string) (float64, error) {
+	value, err := s.Field(column)
+	if err != nil {
+		return 0, err
+	}
+	return fieldAs(value, column, toFloat64)
+}
+
+func (s *selectBuilder) FieldBool(column string) (bool, error) {
+	value, err := s.Field(column)
+	if err != nil {
+		return false, err
+	}
+	return fieldAs(value, column, toBool)
+}
+
+func (s *selectBuilder) Pluck(column string) ([]any, error) {
+	defer s.withColumns(column)()
 
 	rows, err := s.Rows()
 	if err != nil {
@@ -1113,17 +2197,50 @@ func (s *selectBuilder) Pluck(column string) ([]any, error) {
 	return results, nil
 }
 
+func (s *selectBuilder) PluckInt64(column string) ([]int64, error) {
+	values, err := s.Pluck(column)
+	if err != nil {
+		return nil, err
+	}
+	return pluckAs(values, column, toInt64)
+}
+
+func (s *selectBuilder) PluckString(column string) ([]string, error) {
+	values, err := s.Pluck(column)
+	if err != nil {
+		return nil, err
+	}
+	return pluckAs(values, column, toString)
+}
+
+func (s *selectBuilder) PluckFloat64(column string) ([]float64, error) {
+	values, err := s.Pluck(column)
+	if err != nil {
+		return nil, err
+	}
+	return pluckAs(values, column, toFloat64)
+}
+
+func (s *selectBuilder) PluckBool(column string) ([]bool, error) {
+	values, err := s.Pluck(column)
+	if err != nil {
+		return nil, err
+	}
+	return pluckAs(values, column, toBool)
+}
+
 func (s *selectBuilder) Count() (int64, error) {
 	return s.CountColumn("*")
 }
 
 func (s *selectBuilder) CountColumn(column string) (int64, error) {
-	originalColumns := s.columns
-	s.columns = []string{fmt.Sprintf("COUNT(%s) as count", column)}
+	// SELECT DISTINCT COUNT(*) посчитал бы все строки, а не уникальные,
+	// а с UNION COUNT(*) заменил бы колонки только левой части
+	if s.distinct || len(s.distinctOn) > 0 || len(s.setOps) > 0 {
+		return s.subqueryCount(column)
+	}
 
-	defer func() {
-		s.columns = originalColumns
-	}()
+	defer s.withColumns(fmt.Sprintf("COUNT(%s) as count", column))()
 
 	var result struct {
 		Count int64 `db:"count"`
@@ -1138,12 +2255,7 @@ func (s *selectBuilder) CountColumn(column string) (int64, error) {
 }
 
 func (s *selectBuilder) Sum(column string) (float64, error) {
-	originalColumns := s.columns
-	s.columns = []string{fmt.Sprintf("SUM(%s) as sum", column)}
-
-	defer func() {
-		s.columns = originalColumns
-	}()
+	defer s.withColumns(fmt.Sprintf("SUM(%s) as sum", column))()
 
 	var result struct {
 		Sum *float64 `db:"sum"`
@@ -1162,12 +2274,7 @@ func (s *selectBuilder) Sum(column string) (float64, error) {
 }
 
 func (s *selectBuilder) Avg(column string) (float64, error) {
-	originalColumns := s.columns
-	s.columns = []string{fmt.Sprintf("AVG(%s) as avg", column)}
-
-	defer func() {
-		s.columns = originalColumns
-	}()
+	defer s.withColumns(fmt.Sprintf("AVG(%s) as avg", column))()
 
 	var result struct {
 		Avg *float64 `db:"avg"`
@@ -1186,12 +2293,7 @@ func (s *selectBuilder) Avg(column string) (float64, error) {
 }
 
 func (s *selectBuilder) Max(column string) (any, error) {
-	originalColumns := s.columns
-	s.columns = []string{fmt.Sprintf("MAX(%s) as max", column)}
-
-	defer func() {
-		s.columns = originalColumns
-	}()
+	defer s.withColumns(fmt.Sprintf("MAX(%s) as max", column))()
 
 	var result struct {
 		Max any `db:"max"`
@@ -1206,12 +2308,7 @@ func (s *selectBuilder) Max(column string) (any, error) {
 }
 
 func (s *selectBuilder) Min(column string) (any, error) {
-	originalColumns := s.columns
-	s.columns = []string{fmt.Sprintf("MIN(%s) as min", column)}
-
-	defer func() {
-		s.columns = originalColumns
-	}()
+	defer s.withColumns(fmt.Sprintf("MIN(%s) as min", column))()
 
 	var result struct {
 		Min any `db:"min"`
@@ -1233,6 +2330,9 @@ func (s *selectBuilder) Exists() (bool, error) {
 		s.limit = originalLimit
 	}()
 
+	if s.err != nil {
+		return false, s.err
+	}
 	query, args := s.buildSQL()
 	checkSQL := fmt.Sprintf("SELECT EXISTS(%s) as _exists", query)
 
@@ -1243,12 +2343,16 @@ func (s *selectBuilder) Exists() (bool, error) {
 		for _, arg := range args {
 			formattedSQL = strings.Replace(formattedSQL, s.dialect.PlaceholderFormat(), formatArg(arg), 1)
 		}
+		if s.prettySQL {
+			formattedSQL = FormatSQL(formattedSQL)
+		}
 		fmt.Println(formattedSQL)
 	}
 
 	// Log query if logger is set
 	var start time.Time
 	if s.logger != nil {
+		logQueryStart(s.logger, s.ctx, checkSQL, args)
 		start = time.Now()
 	}
 
@@ -1261,7 +2365,7 @@ func (s *selectBuilder) Exists() (bool, error) {
 	// Log query execution
 	if s.logger != nil {
 		duration := time.Since(start)
-		s.logger.LogQuery(s.ctx, checkSQL, args, duration, err)
+		logQuery(s.logger, s.ctx, checkSQL, args, duration, err)
 	}
 
 	if err != nil {
@@ -1271,14 +2375,58 @@ func (s *selectBuilder) Exists() (bool, error) {
 	return result.Exists, nil
 }
 
-// Testing methods - for testing purposes only
+// Table возвращает таблицу из From (со схемой, если она задана)
+func (s *selectBuilder) Table() string {
+	return s.qualifyTable(s.table)
+}
+
+// WhereColumns возвращает экранированные колонки из условий WHERE (без подзапросов)
+func (s *selectBuilder) WhereColumns() []string {
+	where := stripSubqueries(maskStringLiterals(strings.Join(s.wheres, " ")))
+
+	var columns []string
+	seen := make(map[string]bool)
+	for _, m := range introspectWhereColRe.FindAllStringSubmatch(where, -1) {
+		column := m[1]
+		if introspectKeywords[strings.ToUpper(column)] || seen[column] {
+			continue
+		}
+		seen[column] = true
+		columns = append(columns, column)
+	}
+	return columns
+}
+
+// Columns возвращает копию списка колонок SELECT
 func (s *selectBuilder) Columns() []string {
-	// Create a copy of the slice to avoid external modification
 	columns := make([]string, len(s.columns))
 	copy(columns, s.columns)
 	return columns
 }
 
+// WhereArgs возвращает копию аргументов условий WHERE
+func (s *selectBuilder) WhereArgs() []any {
+	args := make([]any, len(s.whereArgs))
+	copy(args, s.whereArgs)
+	return args
+}
+
 func (s *selectBuilder) SetColumns(columns ...string) {
 	s.columns = columns
+	s.exprColumns = nil
+	s.columnArgs = nil
+}
+
+// withColumns временно заменяет список колонок (Count, Field, Pluck...).
+// Выражения AddExprColumn и их аргументы откладываются вместе с колонками,
+// иначе аргументы попадут в запрос без своих плейсхолдеров.
+// Возвращает функцию восстановления: defer s.withColumns(...)()
+func (s *selectBuilder) withColumns(columns ...string) func() {
+	originalColumns, originalExpr, originalArgs := s.columns, s.exprColumns, s.columnArgs
+	s.columns = columns
+	s.exprColumns = nil
+	s.columnArgs = nil
+	return func() {
+		s.columns, s.exprColumns, s.columnArgs = originalColumns, originalExpr, originalArgs
+	}
 }
@@ -0,0 +1,105 @@
+package querycraft
+
+import (
+	"fmt"
+	"strings"
+)
+
+// setOperation - UNION / INTERSECT / EXCEPT с подзапросом, собранным в момент вызова
+type setOperation struct {
+	operator string
+	query    string
+	args     []any
+
+	// У подзапроса свои ORDER BY или LIMIT, операнды нужно взять в скобки
+	ordered bool
+}
+
+// Union - SELECT ... UNION SELECT ..., дубликаты удаляются
+func (s *selectBuilder) Union(other SelectBuilder) SelectBuilder {
+	return s.addSetOperation("UNION", other)
+}
+
+// UnionAll - SELECT ... UNION ALL SELECT ..., сохраняет дубликаты
+func (s *selectBuilder) UnionAll(other SelectBuilder) SelectBuilder {
+	return s.addSetOperation("UNION", other).AllVariant()
+}
+
+// Intersect - (SELECT ...) INTERSECT (SELECT ...)
+func (s *selectBuilder) Intersect(other SelectBuilder) SelectBuilder {
+	return s.addSetOperation("INTERSECT", other)
+}
+
+// IntersectAll - (SELECT ...) INTERSECT ALL (SELECT ...), сохраняет дубликаты
+func (s *selectBuilder) IntersectAll(other SelectBuilder) SelectBuilder {
+	return s.addSetOperation("INTERSECT", other).AllVariant()
+}
+
+// Except - (SELECT ...) EXCEPT (SELECT ...)
+func (s *selectBuilder) Except(other SelectBuilder) SelectBuilder {
+	return s.addSetOperation("EXCEPT", other)
+}
+
+// ExceptAll - (SELECT ...) EXCEPT ALL (SELECT ...), сохраняет дубликаты
+func (s *selectBuilder) ExceptAll(other SelectBuilder) SelectBuilder {
+	return s.addSetOperation("EXCEPT", other).AllVariant()
+}
+
+// AllVariant переключает последнюю операцию над множествами на вариант ALL
+func (s *selectBuilder) AllVariant() SelectBuilder {
+	if len(s.setOps) == 0 {
+		return s
+	}
+	last := &s.setOps[len(s.setOps)-1]
+	if !strings.HasSuffix(last.operator, " ALL") {
+		last.operator += " ALL"
+	}
+	return s
+}
+
+// Err возвращает ошибку, отложенную при построении запроса.
+// Та же ошибка возвращается методами выполнения (All, Rows, Count...)
+func (s *selectBuilder) Err() error {
+	return s.err
+}
+
+func (s *selectBuilder) addSetOperation(operator string, other SelectBuilder) *selectBuilder {
+	// MySQL: вместо эмуляции через JOIN возвращаем ErrNotSupported при выполнении
+	if operator != "UNION" && !s.dialect.SupportsIntersectExcept() && s.err == nil {
+		s.err = fmt.Errorf("%s: %w", operator, ErrNotSupported)
+	}
+
+	op := setOperation{operator: operator}
+	op.query, op.args = other.ToSQL()
+	if sb, ok := other.(*selectBuilder); ok {
+		op.ordered = len(sb.orders) > 0 || sb.limit != nil || sb.offset != nil
+	}
+	s.setOps = append(s.setOps, op)
+	return s
+}
+
+// buildSetOperations соединяет базовый запрос (без ORDER BY и LIMIT) с операндами по порядку.
+// Операнды берутся в скобки, если у одного из них свои ORDER BY или LIMIT,
+// а также для INTERSECT и EXCEPT: (SELECT ...) INTERSECT (SELECT ...)
+func (s *selectBuilder) buildSetOperations(query string, args []any) (string, []any) {
+	wrap := false
+	for _, op := range s.setOps {
+		if op.ordered || !strings.HasPrefix(op.operator, "UNION") {
+			wrap = true
+		}
+	}
+
+	operand := func(sql string) string {
+		if wrap {
+			return "(" + sql + ")"
+		}
+		return sql
+	}
+
+	parts := []string{operand(query)}
+	for _, op := range s.setOps {
+		parts = append(parts, op.operator, operand(op.query))
+		args = append(args, op.args...)
+	}
+	return strings.Join(parts, " "), args
+}
@@ -0,0 +1,48 @@
+package bulk_tests
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/antibomberman/querycraft"
+	"github.com/antibomberman/querycraft/dialect"
+)
+
+func TestBulkDeleteSortsConditionColumns(t *testing.T) {
+	d := &dialect.MySQLDialect{}
+	conditions := []map[string]any{
+		{"user_id": 1, "role": "admin", "account_id": 10},
+		{"user_id": 2, "role": "editor", "account_id": 20},
+	}
+
+	// Порядок колонок не зависит от обхода map
+	for i := 0; i < 20; i++ {
+		query, args := d.BulkDelete("memberships", conditions)
+		assert.Equal(t, "DELETE FROM `memberships` WHERE (`account_id` = ? AND `role` = ? AND `user_id` = ?) OR (`account_id` = ? AND `role` = ? AND `user_id` = ?)", query)
+		assert.Equal(t, []any{10, "admin", 1, 20, "editor", 2}, args)
+	}
+
+	query, _ := (&dialect.PostgresDialect{}).BulkDelete("memberships", conditions[:1])
+	assert.Equal(t, `DELETE FROM "memberships" WHERE ("account_id" = $1 AND "role" = $2 AND "user_id" = $3)`, query)
+}
+
+func TestBulkDeleteBySlice(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `users` WHERE `id` IN (?, ?, ?)")).
+		WithArgs(1, 2, 3).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	bulk := querycraft.NewBulkBuilder(sqlx.NewDb(db, "sqlmock"), &dialect.MySQLDialect{})
+	assert.NoError(t, bulk.BulkDeleteBySlice("users", "id", []any{1, 2, 3}))
+
+	// Пустой список - запрос не выполняется
+	assert.NoError(t, bulk.BulkDeleteBySlice("users", "id", nil))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
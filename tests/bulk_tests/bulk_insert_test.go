@@ -0,0 +1,53 @@
+package bulk_tests
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/antibomberman/querycraft"
+	"github.com/antibomberman/querycraft/dialect"
+)
+
+func TestBulkInsertExec(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	rows := []map[string]any{
+		{"name": "John"},
+		{"name": "Jane"},
+		{"name": "Ann"},
+	}
+
+	// BatchSize 2: две пачки по 2 и 1 строке
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `users` (`name`) VALUES (?), (?)")).
+		WithArgs("John", "Jane").
+		WillReturnResult(sqlmock.NewResult(2, 2))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `users` (`name`) VALUES (?)")).
+		WithArgs("Ann").
+		WillReturnResult(sqlmock.NewResult(3, 1))
+
+	err = querycraft.NewBulkBuilder(sqlx.NewDb(db, "sqlmock"), &dialect.MySQLDialect{}).
+		BulkInsert("users", rows, querycraft.WithBatchSize(2))
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestBulkInsertExecPostgres(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "users" ("name") VALUES ($1), ($2)`)).
+		WithArgs("John", "Jane").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	err = querycraft.NewBulkBuilder(sqlx.NewDb(db, "sqlmock"), &dialect.PostgresDialect{}).
+		BulkInsert("users", []map[string]any{{"name": "John"}, {"name": "Jane"}})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,162 @@
+package delete_tests
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/antibomberman/querycraft"
+	"github.com/antibomberman/querycraft/dialect"
+	"github.com/antibomberman/querycraft/tests/test_utils"
+)
+
+func TestExecArchiveMySQL(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("SELECT 1 FROM `active_users` WHERE `last_login` < ? FOR UPDATE")).
+		WithArgs("2020-01-01").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `deleted_users` SELECT `active_users`.* FROM `active_users` WHERE `last_login` < ?")).
+		WithArgs("2020-01-01").
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `active_users` WHERE `last_login` < ?")).
+		WithArgs("2020-01-01").
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectCommit()
+
+	count, err := querycraft.NewDeleteBuilder(sqlx.NewDb(db, "sqlmock"), &dialect.MySQLDialect{}, "active_users").
+		Where("last_login", "<", "2020-01-01").
+		WithReturningIntoTable("deleted_users").
+		ExecArchive()
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExecArchiveMySQLRollback(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("SELECT 1 FROM `active_users` WHERE `id` = ? FOR UPDATE")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `deleted_users` (`id`, `email`) SELECT `id`, `email` FROM `active_users` WHERE `id` = ?")).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM `active_users`").
+		WillReturnError(errors.New("lock wait timeout"))
+	mock.ExpectRollback()
+
+	_, err = querycraft.NewDeleteBuilder(sqlx.NewDb(db, "sqlmock"), &dialect.MySQLDialect{}, "active_users").
+		Where("id", "=", 1).
+		WithReturningIntoTable("deleted_users", "id", "email").
+		ExecArchive()
+
+	assert.EqualError(t, err, "lock wait timeout")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExecArchivePostgres(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta(`WITH "deleted" AS (DELETE FROM "active_users" WHERE "last_login" < `) +
+		`.+` + regexp.QuoteMeta(` RETURNING "active_users".*) INSERT INTO "deleted_users" SELECT "deleted".* FROM "deleted"`)).
+		WithArgs("2020-01-01").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	count, err := querycraft.NewDeleteBuilder(sqlx.NewDb(db, "sqlmock"), &dialect.PostgresDialect{}, "active_users").
+		Where("last_login", "<", "2020-01-01").
+		WithReturningIntoTable("deleted_users").
+		ExecArchive()
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExecArchiveWithoutTable(t *testing.T) {
+	_, err := querycraft.NewDeleteBuilder(nil, &dialect.MySQLDialect{}, "users").ExecArchive()
+	assert.Error(t, err)
+}
+
+func TestExecArchiveMySQLDeletedCountMismatch(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("SELECT 1 FROM `active_users`").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("INSERT INTO `deleted_users`").WillReturnResult(sqlmock.NewResult(0, 2))
+	// Между INSERT и DELETE появилась еще одна подходящая строка
+	mock.ExpectExec("DELETE FROM `active_users`").WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectRollback()
+
+	_, err = querycraft.NewDeleteBuilder(sqlx.NewDb(db, "sqlmock"), &dialect.MySQLDialect{}, "active_users").
+		Where("last_login", "<", "2020-01-01").
+		WithReturningIntoTable("deleted_users").
+		ExecArchive()
+
+	assert.EqualError(t, err, "ExecArchive: archived 2 rows but deleted 3")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExecArchiveMySQLInsideTransaction(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("SELECT 1 FROM `active_users` WHERE `last_login` < ? ORDER BY `id` LIMIT 100 FOR UPDATE")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `deleted_users` SELECT `active_users`.* FROM `active_users` WHERE `last_login` < ? ORDER BY `id` LIMIT 100")).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `active_users` WHERE `last_login` < ? ORDER BY `id` LIMIT 100")).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	tx, err := sqlx.NewDb(db, "sqlmock").Beginx()
+	assert.NoError(t, err)
+
+	// Внутри *sqlx.Tx новая транзакция не открывается
+	count, err := querycraft.NewDeleteBuilder(tx, &dialect.MySQLDialect{}, "active_users").
+		Where("last_login", "<", "2020-01-01").
+		OrderBy("id").
+		Limit(100).
+		WithReturningIntoTable("deleted_users").
+		ExecArchive()
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExecArchiveLimitRequiresOrderBy(t *testing.T) {
+	db, _, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	_, err = querycraft.NewDeleteBuilder(sqlx.NewDb(db, "sqlmock"), &dialect.MySQLDialect{}, "active_users").
+		Limit(100).
+		WithReturningIntoTable("deleted_users").
+		ExecArchive()
+
+	assert.EqualError(t, err, "ExecArchive with Limit requires OrderBy")
+}
+
+func TestExecArchiveRequiresTransaction(t *testing.T) {
+	_, err := querycraft.NewDeleteBuilder(&test_utils.MockSQLXExecutor{}, &dialect.MySQLDialect{}, "active_users").
+		WithReturningIntoTable("deleted_users").
+		ExecArchive()
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot open a transaction")
+}
@@ -3,6 +3,9 @@ package delete_tests
 import (
 	"testing"
 
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+
 	"github.com/antibomberman/querycraft"
 	"github.com/antibomberman/querycraft/dialect"
 	"github.com/antibomberman/querycraft/tests/test_utils"
@@ -72,7 +75,7 @@ func TestDeleteJoin(t *testing.T) {
 	result := builder.Join("orders", "users.id = orders.user_id").Where("orders.status", "=", "cancelled")
 	sql, args := result.ToSQL()
 
-	expectedSQL := "DELETE FROM `users` JOIN `orders` ON `users`.`id` = `orders`.`user_id` WHERE `orders`.`status` = ?"
+	expectedSQL := "DELETE `users` FROM `users` JOIN `orders` ON `users`.`id` = `orders`.`user_id` WHERE `orders`.`status` = ?"
 	expectedArgs := []any{"cancelled"}
 
 	assert.Equal(t, expectedSQL, sql)
@@ -106,4 +109,238 @@ func TestDeleteWithOrderBy(t *testing.T) {
 	assert.Contains(t, sql, "ORDER BY `created_at`")
 	assert.Len(t, args, 1)
 	assert.Equal(t, false, args[0])
-}
\ No newline at end of file
+}
+
+func TestDeleteUsing(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+	builder := querycraft.NewDeleteBuilder(mockDB, &dialect.MySQLDialect{}, "users")
+
+	result := builder.Using("banned_users", "users.id = banned_users.user_id").Where("users.active", "=", false)
+	sql, args := result.ToSQL()
+
+	// В MySQL USING выражается многотабличным DELETE ... FROM ... JOIN
+	expectedSQL := "DELETE `users` FROM `users` JOIN `banned_users` ON `users`.`id` = `banned_users`.`user_id` WHERE `users`.`active` = ?"
+	expectedArgs := []any{false}
+
+	assert.Equal(t, expectedSQL, sql)
+	assert.Equal(t, expectedArgs, args)
+}
+
+func TestDeleteUsingWithJoinRejected(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+	builder := querycraft.NewDeleteBuilder(mockDB, &dialect.PostgresDialect{}, "users")
+
+	_, err := builder.Using("banned_users", "users.id = banned_users.user_id").
+		Join("orders", "orders.user_id = users.id").
+		Exec()
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Using cannot be combined with Join")
+}
+
+func TestDeleteWhereGroups(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+	builder := querycraft.NewDeleteBuilder(mockDB, &dialect.MySQLDialect{}, "sessions")
+
+	result := builder.
+		WhereGroup(func(q querycraft.DeleteBuilder) querycraft.DeleteBuilder {
+			return q.Where("expired", "=", true).Where("user_id", "=", 1)
+		}).
+		OrWhereGroup(func(q querycraft.DeleteBuilder) querycraft.DeleteBuilder {
+			return q.Where("revoked", "=", true).WhereIn("user_id", 2, 3)
+		})
+	sql, args := result.ToSQL()
+
+	expectedSQL := "DELETE FROM `sessions` WHERE (`expired` = ? AND `user_id` = ?) OR (`revoked` = ? AND `user_id` IN (?, ?))"
+	expectedArgs := []any{true, 1, true, 2, 3}
+
+	assert.Equal(t, expectedSQL, sql)
+	assert.Equal(t, expectedArgs, args)
+}
+
+func TestDeleteWhereGroupAfterWhere(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+	builder := querycraft.NewDeleteBuilder(mockDB, &dialect.MySQLDialect{}, "users")
+
+	result := builder.
+		Where("active", "=", false).
+		WhereGroup(func(q querycraft.DeleteBuilder) querycraft.DeleteBuilder {
+			return q.Where("role", "=", "guest").WhereRaw("OR `role` = ?", "bot")
+		})
+	sql, args := result.ToSQL()
+
+	expectedSQL := "DELETE FROM `users` WHERE `active` = ? AND (`role` = ? OR `role` = ?)"
+	expectedArgs := []any{false, "guest", "bot"}
+
+	assert.Equal(t, expectedSQL, sql)
+	assert.Equal(t, expectedArgs, args)
+}
+
+func TestDeleteWhereLike(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+	builder := querycraft.NewDeleteBuilder(mockDB, &dialect.MySQLDialect{}, "users")
+
+	sql, args := builder.WhereLike("email", "%@spam.com").WhereNotLike("name", "admin%").OrWhereLike("name", "bot%").ToSQL()
+
+	assert.Equal(t, "DELETE FROM `users` WHERE `email` LIKE ? AND `name` NOT LIKE ? OR `name` LIKE ?", sql)
+	assert.Equal(t, []any{"%@spam.com", "admin%", "bot%"}, args)
+}
+
+func TestDeleteWhereColumn(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+	builder := querycraft.NewDeleteBuilder(mockDB, &dialect.MySQLDialect{}, "sessions")
+
+	sql, _ := builder.WhereColumn("sessions.expires_at", "<", "sessions.created_at").OrWhereColumn("user_id", "=", "owner_id").ToSQL()
+
+	assert.Equal(t, "DELETE FROM `sessions` WHERE `sessions`.`expires_at` < `sessions`.`created_at` OR `user_id` = `owner_id`", sql)
+}
+
+func TestDeleteWhereVariants(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+	builder := querycraft.NewDeleteBuilder(mockDB, &dialect.MySQLDialect{}, "users")
+
+	sql, args := builder.
+		WhereNull("verified_at").
+		WhereNotNull("deleted_at").
+		WhereBetween("age", 0, 17).
+		WhereNotBetween("id", 1, 100).
+		WhereNotIn("role", "admin").
+		ToSQL()
+
+	assert.Equal(t, "DELETE FROM `users` WHERE `verified_at` IS NULL AND `deleted_at` IS NOT NULL AND `age` BETWEEN ? AND ? "+
+		"AND `id` NOT BETWEEN ? AND ? AND `role` NOT IN (?)", sql)
+	assert.Equal(t, []any{0, 17, 1, 100, "admin"}, args)
+}
+
+func TestDeleteOrWhere(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+	builder := querycraft.NewDeleteBuilder(mockDB, &dialect.MySQLDialect{}, "sessions")
+
+	sql, args := builder.
+		Where("expires_at", "<", "2024-01-01").
+		OrWhere("revoked", "=", true).
+		OrWhereEq("user_id", 0).
+		OrWhereIn("token", "a", "b").
+		OrWhereNull("user_agent").
+		OrWhereRaw("`hits` > ?", 1000).
+		ToSQL()
+
+	assert.Equal(t, "DELETE FROM `sessions` WHERE `expires_at` < ? OR `revoked` = ? OR `user_id` = ? OR `token` IN (?, ?) OR `user_agent` IS NULL OR `hits` > ?", sql)
+	assert.Equal(t, []any{"2024-01-01", true, 0, "a", "b", 1000}, args)
+}
+
+func TestDeleteWhen(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+	onlyGuests := true
+	olderThan := ""
+
+	sql, args := querycraft.NewDeleteBuilder(mockDB, &dialect.MySQLDialect{}, "users").
+		Where("active", "=", false).
+		When(onlyGuests, "role", "=", "guest").
+		When(olderThan != "", "created_at", "<", olderThan).
+		WhenFunc(onlyGuests, func(q querycraft.DeleteBuilder) querycraft.DeleteBuilder {
+			return q.WhereNull("email")
+		}).
+		ToSQL()
+
+	assert.Equal(t, "DELETE FROM `users` WHERE `active` = ? AND `role` = ? AND `email` IS NULL", sql)
+	assert.Equal(t, []any{false, "guest"}, args)
+}
+
+func TestDeleteJoinTypes(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+	cases := []struct {
+		name     string
+		build    func(querycraft.DeleteBuilder) querycraft.DeleteBuilder
+		expected string
+	}{
+		{
+			name: "inner",
+			build: func(b querycraft.DeleteBuilder) querycraft.DeleteBuilder {
+				return b.InnerJoin("orders o", "o.user_id = users.id")
+			},
+			expected: "DELETE `users` FROM `users` INNER JOIN `orders` as o ON `o`.`user_id` = `users`.`id` WHERE `users`.`active` = ?",
+		},
+		{
+			name: "left",
+			build: func(b querycraft.DeleteBuilder) querycraft.DeleteBuilder {
+				return b.LeftJoin("orders", "orders.user_id = users.id")
+			},
+			expected: "DELETE `users` FROM `users` LEFT JOIN `orders` ON `orders`.`user_id` = `users`.`id` WHERE `users`.`active` = ?",
+		},
+		{
+			name: "right",
+			build: func(b querycraft.DeleteBuilder) querycraft.DeleteBuilder {
+				return b.RightJoin("orders", "orders.user_id = users.id")
+			},
+			expected: "DELETE `users` FROM `users` RIGHT JOIN `orders` ON `orders`.`user_id` = `users`.`id` WHERE `users`.`active` = ?",
+		},
+		{
+			name:     "cross",
+			build:    func(b querycraft.DeleteBuilder) querycraft.DeleteBuilder { return b.CrossJoin("settings s") },
+			expected: "DELETE `users` FROM `users` CROSS JOIN `settings` as s WHERE `users`.`active` = ?",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			builder := querycraft.NewDeleteBuilder(mockDB, &dialect.MySQLDialect{}, "users")
+			sql, args := tc.build(builder).Where("users.active", "=", false).ToSQL()
+
+			assert.Equal(t, tc.expected, sql)
+			assert.Equal(t, []any{false}, args)
+		})
+	}
+}
+
+func TestDeleteJoinNotSupported(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	// В PostgreSQL и SQLite нет DELETE ... JOIN, для PostgreSQL есть Using
+	for _, d := range []dialect.Dialect{&dialect.PostgresDialect{}, &dialect.SQLiteDialect{}} {
+		_, err := querycraft.NewDeleteBuilder(sqlx.NewDb(db, "sqlmock"), d, "users").
+			Join("orders", "orders.user_id = users.id").
+			Where("orders.status", "=", "cancelled").
+			Exec()
+		assert.ErrorIs(t, err, querycraft.ErrNotSupported)
+	}
+
+	// Многотабличный DELETE в MySQL не допускает LIMIT
+	_, err = querycraft.NewDeleteBuilder(sqlx.NewDb(db, "sqlmock"), &dialect.MySQLDialect{}, "users").
+		Join("orders", "orders.user_id = users.id").
+		Limit(10).
+		Exec()
+	assert.ErrorIs(t, err, querycraft.ErrNotSupported)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteWhenFalseAddsNoWhere(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+
+	sql, args := querycraft.NewDeleteBuilder(mockDB, &dialect.MySQLDialect{}, "logs").
+		When(false, "level", "=", "debug").
+		WhenFunc(false, func(q querycraft.DeleteBuilder) querycraft.DeleteBuilder {
+			return q.Where("created_at", "<", "2024-01-01")
+		}).
+		ToSQL()
+
+	assert.Equal(t, "DELETE FROM `logs`", sql)
+	assert.Empty(t, args)
+}
+
+func TestDeleteWhenFuncTrue(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+
+	sql, args := querycraft.NewDeleteBuilder(mockDB, &dialect.MySQLDialect{}, "logs").
+		When(true, "level", "=", "debug").
+		WhenFunc(true, func(q querycraft.DeleteBuilder) querycraft.DeleteBuilder {
+			return q.Where("created_at", "<", "2024-01-01").OrWhereNull("created_at")
+		}).
+		ToSQL()
+
+	assert.Equal(t, "DELETE FROM `logs` WHERE `level` = ? AND `created_at` < ? OR `created_at` IS NULL", sql)
+	assert.Equal(t, []any{"debug", "2024-01-01"}, args)
+}
@@ -0,0 +1,55 @@
+package delete_tests
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/antibomberman/querycraft"
+	"github.com/antibomberman/querycraft/dialect"
+)
+
+func TestDeleteExec(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `sessions` WHERE `expired` = ?")).
+		WithArgs(true).
+		WillReturnResult(sqlmock.NewResult(0, 4))
+
+	result, err := querycraft.NewDeleteBuilder(sqlx.NewDb(db, "sqlmock"), &dialect.MySQLDialect{}, "sessions").
+		Where("expired", "=", true).
+		Exec()
+	assert.NoError(t, err)
+
+	affected, err := result.RowsAffected()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), affected)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteExecReturning(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`DELETE FROM "sessions" WHERE "user_id" = $1 RETURNING "id", "token"`)).
+		WithArgs(5).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "token"}).AddRow(1, "abc"))
+
+	var rows []struct {
+		ID    int64  `db:"id"`
+		Token string `db:"token"`
+	}
+	err = querycraft.NewDeleteBuilder(sqlx.NewDb(db, "sqlmock"), &dialect.PostgresDialect{}, "sessions").
+		Where("user_id", "=", 5).
+		Returning("id", "token").
+		ExecReturning(&rows)
+	assert.NoError(t, err)
+	assert.Equal(t, "abc", rows[0].Token)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
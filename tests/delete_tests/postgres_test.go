@@ -0,0 +1,149 @@
+package delete_tests
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/antibomberman/querycraft"
+	"github.com/antibomberman/querycraft/dialect"
+	"github.com/antibomberman/querycraft/tests/test_utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeleteWherePostgres(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+	builder := querycraft.NewDeleteBuilder(mockDB, &dialect.PostgresDialect{}, "users")
+
+	sql, args := builder.Where("active", "=", false).WhereIn("role", "guest", "bot").ToSQL()
+
+	assert.Equal(t, `DELETE FROM "users" WHERE "active" = $1 AND "role" IN ($2, $3)`, sql)
+	assert.Equal(t, []any{false, "guest", "bot"}, args)
+}
+
+func TestDeleteWithLimitPostgres(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	// В PostgreSQL нет DELETE ... LIMIT: без лимита удалились бы все подходящие строки,
+	// поэтому запрос не выполняется
+	_, err = querycraft.NewDeleteBuilder(sqlx.NewDb(db, "sqlmock"), &dialect.PostgresDialect{}, "users").
+		Where("active", "=", false).Limit(10).Exec()
+	assert.ErrorIs(t, err, querycraft.ErrNotSupported)
+
+	_, err = querycraft.NewDeleteBuilder(sqlx.NewDb(db, "sqlmock"), &dialect.PostgresDialect{}, "users").
+		Where("active", "=", false).OrderBy("id").Limit(10).ExecRowsAffected()
+	assert.ErrorIs(t, err, querycraft.ErrNotSupported)
+
+	var ids []int64
+	err = querycraft.NewDeleteBuilder(sqlx.NewDb(db, "sqlmock"), &dialect.PostgresDialect{}, "users").
+		Where("active", "=", false).Limit(10).ExecReturning(&ids)
+	assert.ErrorIs(t, err, querycraft.ErrNotSupported)
+
+	_, err = querycraft.NewDeleteBuilder(sqlx.NewDb(db, "sqlmock"), &dialect.PostgresDialect{}, "users").
+		Where("active", "=", false).OrderBy("id").Limit(10).
+		WithReturningIntoTable("users_archive").
+		ExecArchive()
+	assert.ErrorIs(t, err, querycraft.ErrNotSupported)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteUsingPostgres(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+	builder := querycraft.NewDeleteBuilder(mockDB, &dialect.PostgresDialect{}, "users")
+
+	sql, args := builder.Using("banned_users", "users.id = banned_users.user_id").Where("users.active", "=", false).ToSQL()
+
+	assert.Equal(t, `DELETE FROM "users" USING "banned_users" WHERE "users"."id" = "banned_users"."user_id" AND ("users"."active" = $1)`, sql)
+	assert.Equal(t, []any{false}, args)
+}
+
+func TestDeleteReturningPostgres(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+	builder := querycraft.NewDeleteBuilder(mockDB, &dialect.PostgresDialect{}, "sessions")
+
+	sql, args := builder.Where("expires_at", "<", "2024-01-01").Returning("*").ToSQL()
+
+	assert.Equal(t, `DELETE FROM "sessions" WHERE "expires_at" < $1 RETURNING *`, sql)
+	assert.Equal(t, []any{"2024-01-01"}, args)
+}
+
+func TestDeleteReturningDroppedOnMySQL(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+	builder := querycraft.NewDeleteBuilder(mockDB, &dialect.MySQLDialect{}, "sessions")
+
+	sql, _ := builder.Where("id", "=", 1).Returning("id").ToSQL()
+
+	assert.Equal(t, "DELETE FROM `sessions` WHERE `id` = ?", sql)
+}
+
+func TestDeleteWhereGroupsPostgres(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+	builder := querycraft.NewDeleteBuilder(mockDB, &dialect.PostgresDialect{}, "sessions")
+
+	sql, args := builder.
+		WhereGroup(func(q querycraft.DeleteBuilder) querycraft.DeleteBuilder {
+			return q.Where("expired", "=", true).Where("user_id", "=", 1)
+		}).
+		OrWhereGroup(func(q querycraft.DeleteBuilder) querycraft.DeleteBuilder {
+			return q.Where("revoked", "=", true).WhereIn("user_id", 2, 3)
+		}).
+		ToSQL()
+
+	assert.Equal(t, `DELETE FROM "sessions" WHERE ("expired" = $1 AND "user_id" = $2) OR ("revoked" = $3 AND "user_id" IN ($4, $5))`, sql)
+	assert.Equal(t, []any{true, 1, true, 2, 3}, args)
+}
+
+func TestDeleteWhereVariantsPostgres(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+	builder := querycraft.NewDeleteBuilder(mockDB, &dialect.PostgresDialect{}, "users")
+
+	sql, args := builder.
+		WhereNull("verified_at").
+		WhereNotNull("deleted_at").
+		WhereBetween("age", 0, 17).
+		WhereNotBetween("id", 1, 100).
+		WhereNotIn("role", "admin").
+		WhereLike("email", "%@spam.com").
+		ToSQL()
+
+	assert.Equal(t, `DELETE FROM "users" WHERE "verified_at" IS NULL AND "deleted_at" IS NOT NULL AND "age" BETWEEN $1 AND $2 `+
+		`AND "id" NOT BETWEEN $3 AND $4 AND "role" NOT IN ($5) AND "email" LIKE $6`, sql)
+	assert.Equal(t, []any{0, 17, 1, 100, "admin", "%@spam.com"}, args)
+}
+
+func TestDeleteOrWherePostgres(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+	builder := querycraft.NewDeleteBuilder(mockDB, &dialect.PostgresDialect{}, "sessions")
+
+	sql, args := builder.
+		Where("expires_at", "<", "2024-01-01").
+		OrWhere("revoked", "=", true).
+		OrWhereEq("user_id", 0).
+		OrWhereIn("token", "a", "b").
+		OrWhereNull("user_agent").
+		OrWhereRaw(`"hits" > ?`, 1000).
+		ToSQL()
+
+	assert.Equal(t, `DELETE FROM "sessions" WHERE "expires_at" < $1 OR "revoked" = $2 OR "user_id" = $3 OR "token" IN ($4, $5) OR "user_agent" IS NULL OR "hits" > $6`, sql)
+	assert.Equal(t, []any{"2024-01-01", true, 0, "a", "b", 1000}, args)
+}
+
+func TestDeleteWhenPostgres(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+
+	sql, args := querycraft.NewDeleteBuilder(mockDB, &dialect.PostgresDialect{}, "users").
+		Where("active", "=", false).
+		When(true, "role", "=", "guest").
+		When(false, "created_at", "<", "2024-01-01").
+		WhenFunc(true, func(q querycraft.DeleteBuilder) querycraft.DeleteBuilder {
+			return q.WhereNull("email")
+		}).
+		ToSQL()
+
+	assert.Equal(t, `DELETE FROM "users" WHERE "active" = $1 AND "role" = $2 AND "email" IS NULL`, sql)
+	assert.Equal(t, []any{false, "guest"}, args)
+}
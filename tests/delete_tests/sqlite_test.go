@@ -0,0 +1,29 @@
+package delete_tests
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/antibomberman/querycraft"
+	"github.com/antibomberman/querycraft/dialect"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeleteLimitSQLite(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	// Без SQLITE_ENABLE_UPDATE_DELETE_LIMIT нет DELETE ... LIMIT: запрос не выполняется
+	_, err = querycraft.NewDeleteBuilder(sqlx.NewDb(db, "sqlmock"), &dialect.SQLiteDialect{}, "logs").
+		Where("level", "=", "debug").OrderBy("id").Limit(1000).Exec()
+	assert.ErrorIs(t, err, querycraft.ErrNotSupported)
+
+	_, err = querycraft.NewDeleteBuilder(sqlx.NewDb(db, "sqlmock"), &dialect.SQLiteDialect{}, "logs").
+		Limit(1000).ExecRowsAffected()
+	assert.ErrorIs(t, err, querycraft.ErrNotSupported)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,34 @@
+package helpers_tests
+
+import (
+	"testing"
+
+	"github.com/antibomberman/querycraft"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatSQL(t *testing.T) {
+	sql := "SELECT `u`.`id`, COUNT(*) as count FROM `users` as u LEFT JOIN `orders` as o ON `u`.`id` = `o`.`user_id` WHERE `u`.`active` = ? GROUP BY `u`.`id` HAVING COUNT(*) > ? ORDER BY `count` DESC LIMIT 10 OFFSET 20"
+
+	expected := "SELECT `u`.`id`, COUNT(*) as count\n" +
+		"FROM `users` as u\n" +
+		"  LEFT JOIN `orders` as o ON `u`.`id` = `o`.`user_id`\n" +
+		"WHERE `u`.`active` = ?\n" +
+		"GROUP BY `u`.`id`\n" +
+		"HAVING COUNT(*) > ?\n" +
+		"ORDER BY `count` DESC\n" +
+		"LIMIT 10\n" +
+		"OFFSET 20"
+
+	assert.Equal(t, expected, querycraft.FormatSQL(sql))
+}
+
+func TestFormatSQLKeepsQuotedAndSubqueries(t *testing.T) {
+	sql := "SELECT * FROM `users` WHERE `name` = 'select from where' AND EXISTS (SELECT 1 FROM `orders` WHERE `orders`.`user_id` = `users`.`id`)"
+
+	expected := "SELECT *\n" +
+		"FROM `users`\n" +
+		"WHERE `name` = 'select from where' AND EXISTS (SELECT 1 FROM `orders` WHERE `orders`.`user_id` = `users`.`id`)"
+
+	assert.Equal(t, expected, querycraft.FormatSQL(sql))
+}
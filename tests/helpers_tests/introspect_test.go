@@ -0,0 +1,54 @@
+package helpers_tests
+
+import (
+	"testing"
+
+	"github.com/antibomberman/querycraft"
+	"github.com/antibomberman/querycraft/dialect"
+	"github.com/antibomberman/querycraft/tests/test_utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIntrospectSelect(t *testing.T) {
+	sql := "SELECT `u`.`id` FROM `users` as u LEFT JOIN `orders` as o ON `u`.`id` = `o`.`user_id` " +
+		"WHERE `u`.`tenant_id` = ? AND `u`.`name` LIKE 'a = b' AND `u`.`id` IN (SELECT `user_id` FROM `bans` WHERE `active` = 1) " +
+		"ORDER BY `u`.`id` LIMIT 10 OFFSET 20"
+
+	inspection := querycraft.Introspect(sql)
+
+	assert.Equal(t, "SELECT", inspection.Operation)
+	assert.Equal(t, []string{"users", "orders", "bans"}, inspection.Tables)
+	assert.Equal(t, []string{"u.tenant_id", "u.name", "u.id"}, inspection.WhereColumns)
+	assert.Equal(t, 10, *inspection.Limit)
+	assert.Equal(t, 20, *inspection.Offset)
+	assert.True(t, inspection.HasSubquery)
+}
+
+func TestIntrospectWriteOperations(t *testing.T) {
+	update := querycraft.Introspect("UPDATE `users` SET `name` = ? WHERE `id` = ?")
+	assert.Equal(t, "UPDATE", update.Operation)
+	assert.Equal(t, []string{"users"}, update.Tables)
+	assert.Equal(t, []string{"id"}, update.WhereColumns)
+	assert.Nil(t, update.Limit)
+	assert.False(t, update.HasSubquery)
+
+	del := querycraft.Introspect("DELETE FROM `sessions` WHERE `expires_at` < ? LIMIT 100")
+	assert.Equal(t, "DELETE", del.Operation)
+	assert.Equal(t, []string{"sessions"}, del.Tables)
+	assert.Equal(t, []string{"expires_at"}, del.WhereColumns)
+	assert.Equal(t, 100, *del.Limit)
+
+	insert := querycraft.Introspect("WITH `active` AS (SELECT * FROM `users`) INSERT INTO `archive` SELECT * FROM `active`")
+	assert.Equal(t, "INSERT", insert.Operation)
+	assert.Equal(t, []string{"users", "archive", "active"}, insert.Tables)
+}
+
+func TestSelectBuilderTableAndWhereColumns(t *testing.T) {
+	builder := querycraft.NewSelectBuilder(&test_utils.MockSQLXExecutor{}, &dialect.MySQLDialect{}, "*").
+		From("users").
+		Where("tenant_id", "=", 1).
+		WhereIn("role", "admin", "editor")
+
+	assert.Equal(t, "users", builder.Table())
+	assert.Equal(t, []string{"`tenant_id`", "`role`"}, builder.WhereColumns())
+}
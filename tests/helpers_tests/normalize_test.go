@@ -0,0 +1,66 @@
+package helpers_tests
+
+import (
+	"testing"
+
+	"github.com/antibomberman/querycraft"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeSQL(t *testing.T) {
+	tests := []struct {
+		name     string
+		sql      string
+		expected string
+	}{
+		{
+			name:     "literals and whitespace",
+			sql:      "SELECT *  FROM `users`\n\tWHERE `name` = 'O''Brien' AND `age` > 18 LIMIT 10",
+			expected: "SELECT * FROM `users` WHERE `name` = ? AND `age` > ? LIMIT ?",
+		},
+		{
+			name:     "in list",
+			sql:      "SELECT * FROM `users` WHERE `id` IN (?, ?, ?) AND `role` NOT IN (1,2)",
+			expected: "SELECT * FROM `users` WHERE `id` IN (?) AND `role` NOT IN (?)",
+		},
+		{
+			name:     "numbered placeholders",
+			sql:      "SELECT * FROM users WHERE id = $1 AND price < 9.99",
+			expected: "SELECT * FROM users WHERE id = ? AND price < ?",
+		},
+		{
+			name:     "identifiers untouched",
+			sql:      "SELECT `table1`.`col2` FROM table1 WHERE \"key 1\" = 'x'",
+			expected: "SELECT `table1`.`col2` FROM table1 WHERE \"key 1\" = ?",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, querycraft.NormalizeSQL(tt.sql))
+		})
+	}
+}
+
+func TestNormalizedSQL_SameShape(t *testing.T) {
+	first := querycraft.NewRaw(nil, "SELECT * FROM users WHERE id IN (1, 2, 3)")
+	second := querycraft.NewRaw(nil, "SELECT *   FROM users WHERE id IN (42)")
+
+	assert.Equal(t, first.NormalizedSQL(), second.NormalizedSQL())
+}
+
+func TestFingerprint(t *testing.T) {
+	a := querycraft.Fingerprint("SELECT * FROM `users` WHERE id = 1 AND role IN ('a', 'b') -- admin panel")
+	b := querycraft.Fingerprint("select *\n  from `users` /* api */ where ID = ? and role in (?, ?, ?)")
+	assert.Equal(t, a, b)
+	assert.Len(t, a, 16)
+
+	// Идентификаторы в кавычках чувствительны к регистру
+	assert.NotEqual(t, a, querycraft.Fingerprint("SELECT * FROM `Users` WHERE id = 1 AND role IN ('a')"))
+	assert.NotEqual(t, a, querycraft.Fingerprint("SELECT * FROM `users` WHERE id = 1"))
+
+	// Комментарий внутри строки не удаляется, но строка все равно заменяется на ?
+	assert.Equal(t,
+		querycraft.Fingerprint("SELECT '-- not a comment' FROM t"),
+		querycraft.Fingerprint("SELECT 'x' FROM t"))
+}
@@ -0,0 +1,31 @@
+package insert_tests
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/antibomberman/querycraft"
+	"github.com/antibomberman/querycraft/dialect"
+)
+
+func TestInsertExecReturnID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `users` (`name`, `email`) VALUES (?, ?)")).
+		WithArgs("John", "john@example.com").
+		WillReturnResult(sqlmock.NewResult(15, 1))
+
+	id, err := querycraft.NewInsertBuilder(sqlx.NewDb(db, "sqlmock"), &dialect.MySQLDialect{}, "users").
+		Columns("name", "email").
+		Values("John", "john@example.com").
+		ExecReturnID()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(15), id)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
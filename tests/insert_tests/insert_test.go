@@ -94,3 +94,47 @@ func TestInsertFromSelect(t *testing.T) {
 	assert.Equal(t, expectedSQL, sql)
 	assert.Equal(t, expectedArgs, args)
 }
+
+func TestInsertValuesMapsStableColumnOrder(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+	builder := querycraft.NewInsertBuilder(mockDB, &dialect.MySQLDialect{}, "users")
+
+	data := []map[string]any{
+		{"name": "John", "email": "john@example.com", "age": 30},
+		{"age": 25, "name": "Jane", "email": "jane@example.com"},
+		{"email": "bob@example.com", "age": 40, "name": "Bob"},
+		{"name": "Alice", "age": 35, "email": "alice@example.com"},
+		{"email": "eve@example.com", "name": "Eve", "age": 28},
+	}
+
+	result := builder.ValuesMaps(data)
+	sql, args := result.ToSQL()
+
+	expectedSQL := "INSERT INTO `users` (`age`, `email`, `name`) VALUES (?, ?, ?), (?, ?, ?), (?, ?, ?), (?, ?, ?), (?, ?, ?)"
+	expectedArgs := []any{
+		30, "john@example.com", "John",
+		25, "jane@example.com", "Jane",
+		40, "bob@example.com", "Bob",
+		35, "alice@example.com", "Alice",
+		28, "eve@example.com", "Eve",
+	}
+
+	assert.Equal(t, expectedSQL, sql)
+	assert.Equal(t, expectedArgs, args)
+}
+
+func TestInsertValuesMapsMissingKey(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+	builder := querycraft.NewInsertBuilder(mockDB, &dialect.MySQLDialect{}, "users")
+
+	data := []map[string]any{
+		{"name": "John", "email": "john@example.com"},
+		{"name": "Jane"},
+	}
+
+	_, args := builder.ValuesMaps(data).ToSQL()
+	assert.Equal(t, []any{"john@example.com", "John", nil, "Jane"}, args)
+
+	_, args = builder.WithMissingValueDefault("unknown").ToSQL()
+	assert.Equal(t, []any{"john@example.com", "John", "unknown", "Jane"}, args)
+}
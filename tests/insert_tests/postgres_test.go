@@ -0,0 +1,73 @@
+package insert_tests
+
+import (
+	"testing"
+
+	"github.com/antibomberman/querycraft"
+	"github.com/antibomberman/querycraft/dialect"
+	"github.com/antibomberman/querycraft/tests/test_utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInsertValuesPostgres(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+	builder := querycraft.NewInsertBuilder(mockDB, &dialect.PostgresDialect{}, "users")
+
+	sql, args := builder.Columns("name", "email").
+		Values("John", "john@example.com").
+		Values("Jane", "jane@example.com").
+		ToSQL()
+
+	assert.Equal(t, `INSERT INTO "users" ("name", "email") VALUES ($1, $2), ($3, $4)`, sql)
+	assert.Equal(t, []any{"John", "john@example.com", "Jane", "jane@example.com"}, args)
+}
+
+func TestInsertIgnorePostgres(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+	builder := querycraft.NewInsertBuilder(mockDB, &dialect.PostgresDialect{}, "users")
+
+	sql, _ := builder.Columns("name", "email").Values("John", "john@example.com").Ignore().ToSQL()
+
+	// В PostgreSQL нет INSERT IGNORE, используется ON CONFLICT DO NOTHING
+	assert.Equal(t, `INSERT INTO "users" ("name", "email") VALUES ($1, $2) ON CONFLICT DO NOTHING`, sql)
+}
+
+func TestInsertOnConflictDoNothingPostgres(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+	builder := querycraft.NewInsertBuilder(mockDB, &dialect.PostgresDialect{}, "users")
+
+	sql, _ := builder.Columns("name", "email").Values("John", "john@example.com").OnConflictDoNothing().ToSQL()
+	assert.Equal(t, `INSERT INTO "users" ("name", "email") VALUES ($1, $2) ON CONFLICT DO NOTHING`, sql)
+}
+
+func TestInsertFromSelectPostgres(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+	d := &dialect.PostgresDialect{}
+
+	selectBuilder := querycraft.NewSelectBuilder(mockDB, d, "name", "email").
+		From("temp_users").
+		Where("active", "=", true).
+		Where("age", ">", 18)
+
+	sql, args := querycraft.NewInsertBuilder(mockDB, d, "users").
+		Columns("name", "email").
+		FromSelect(selectBuilder).
+		ToSQL()
+
+	assert.Equal(t, `INSERT INTO "users" ("name", "email") SELECT "name", "email" FROM "temp_users" WHERE "active" = $1 AND "age" > $2`, sql)
+	assert.Equal(t, []any{true, 18}, args)
+}
+
+func TestInsertValuesMapPostgres(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+	builder := querycraft.NewInsertBuilder(mockDB, &dialect.PostgresDialect{}, "users")
+
+	sql, args := builder.ValuesMap(map[string]any{"name": "John", "email": "john@example.com"}).ToSQL()
+
+	// Для map порядок столбцов может быть разным, поэтому проверяем только структуру запроса
+	assert.Contains(t, sql, `INSERT INTO "users"`)
+	assert.Contains(t, sql, `"name"`)
+	assert.Contains(t, sql, `"email"`)
+	assert.Contains(t, sql, "VALUES ($1, $2)")
+	assert.Len(t, args, 2)
+}
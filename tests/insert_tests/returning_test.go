@@ -0,0 +1,78 @@
+package insert_tests
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/antibomberman/querycraft"
+	"github.com/antibomberman/querycraft/dialect"
+	"github.com/antibomberman/querycraft/tests/test_utils"
+)
+
+type returnedUser struct {
+	ID        int64  `db:"id"`
+	CreatedAt string `db:"created_at"`
+}
+
+func TestInsertReturningPostgres(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+
+	sql, args := querycraft.NewInsertBuilder(mockDB, &dialect.PostgresDialect{}, "users").
+		Columns("name", "email").
+		Values("John", "john@example.com").
+		Returning("id", "created_at").
+		ToSQL()
+
+	assert.Equal(t, `INSERT INTO "users" ("name", "email") VALUES ($1, $2) RETURNING "id", "created_at"`, sql)
+	assert.Equal(t, []any{"John", "john@example.com"}, args)
+}
+
+func TestInsertReturningDroppedOnMySQL(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+
+	sql, _ := querycraft.NewInsertBuilder(mockDB, &dialect.MySQLDialect{}, "users").
+		Columns("name", "email").
+		Values("John", "john@example.com").
+		Returning("*").
+		ToSQL()
+
+	assert.Equal(t, "INSERT INTO `users` (`name`, `email`) VALUES (?, ?)", sql)
+}
+
+func TestInsertExecReturning(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "users" ("name", "email") VALUES ($1, $2), ($3, $4) RETURNING *`)).
+		WithArgs("John", "john@example.com", "Jane", "jane@example.com").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).
+			AddRow(1, "2024-01-01").
+			AddRow(2, "2024-01-02"))
+
+	var users []returnedUser
+	err = querycraft.NewInsertBuilder(sqlx.NewDb(db, "sqlmock"), &dialect.PostgresDialect{}, "users").
+		Columns("name", "email").
+		Values("John", "john@example.com").
+		Values("Jane", "jane@example.com").
+		ExecReturning(&users)
+	assert.NoError(t, err)
+	assert.Equal(t, []returnedUser{{ID: 1, CreatedAt: "2024-01-01"}, {ID: 2, CreatedAt: "2024-01-02"}}, users)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInsertExecReturningNotSupported(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+
+	var users []returnedUser
+	err := querycraft.NewInsertBuilder(mockDB, &dialect.MySQLDialect{}, "users").
+		Columns("name", "email").
+		Values("John", "john@example.com").
+		ExecReturning(&users)
+
+	assert.ErrorIs(t, err, querycraft.ErrNotSupported)
+}
@@ -0,0 +1,101 @@
+package logger_tests
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/antibomberman/querycraft"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileLoggerLogQueryStart(t *testing.T) {
+	dir := t.TempDir()
+	options := querycraft.DefaultOptions()
+	options.LogEnabled = true
+	options.LogSaveToFile = true
+	options.LogDir = dir
+	options.LogLevel = querycraft.LogLevelDebug
+
+	logger := querycraft.NewFileLogger(options)
+	logger.LogQueryStart(context.Background(), "SELECT * FROM `users` WHERE `id` = ?", []any{1})
+
+	data, err := os.ReadFile(filepath.Join(dir, time.Now().Format("2006_01_02")+".log"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), "[START] Query: SELECT * FROM `users` WHERE `id` = '1'")
+}
+
+func TestFileLoggerLogQueryStartSkippedAboveDebug(t *testing.T) {
+	dir := t.TempDir()
+	options := querycraft.DefaultOptions()
+	options.LogEnabled = true
+	options.LogSaveToFile = true
+	options.LogDir = dir
+	options.LogLevel = querycraft.LogLevelInfo
+
+	logger := querycraft.NewFileLogger(options)
+	logger.LogQueryStart(context.Background(), "SELECT 1", nil)
+
+	data, _ := os.ReadFile(filepath.Join(dir, time.Now().Format("2006_01_02")+".log"))
+	assert.False(t, strings.Contains(string(data), "[START]"))
+}
+
+type attributedLogger struct {
+	queries []string
+	attrs   []querycraft.QueryAttributes
+}
+
+func (l *attributedLogger) LogQuery(ctx context.Context, query string, args []any, duration time.Duration, err error) {
+	l.queries = append(l.queries, query)
+}
+
+func (l *attributedLogger) LogQueryWithAttributes(ctx context.Context, query string, args []any, duration time.Duration, err error, attrs querycraft.QueryAttributes) {
+	l.queries = append(l.queries, query)
+	l.attrs = append(l.attrs, attrs)
+}
+
+func TestLoggerReceivesFingerprint(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	logger := &attributedLogger{}
+	qc, err := querycraft.New("mysql", db, querycraft.Options{})
+	assert.NoError(t, err)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE `users`").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE `users`").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	tx, err := qc.Begin()
+	assert.NoError(t, err)
+	tx.SetLogger(logger)
+	_, err = tx.Update("users").Set("name", "John").Where("id", "=", 1).Exec()
+	assert.NoError(t, err)
+	_, err = tx.Update("users").Set("name", "Jane").Where("id", "=", 2).Exec()
+	assert.NoError(t, err)
+
+	assert.Len(t, logger.attrs, 2)
+	assert.NotEmpty(t, logger.attrs[0].Fingerprint)
+	assert.Equal(t, logger.attrs[0].Fingerprint, logger.attrs[1].Fingerprint)
+}
+
+func TestFileLoggerWritesFingerprint(t *testing.T) {
+	dir := t.TempDir()
+	options := querycraft.DefaultOptions()
+	options.LogEnabled = true
+	options.LogSaveToFile = true
+	options.LogDir = dir
+	options.LogFormat = querycraft.LogFormatJSON
+
+	logger := querycraft.NewFileLogger(options)
+	logger.LogQueryWithAttributes(context.Background(), "SELECT 1", nil, time.Millisecond, nil, querycraft.QueryAttributes{Fingerprint: "abc123"})
+
+	data, err := os.ReadFile(filepath.Join(dir, time.Now().Format("2006_01_02")+".log"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"fingerprint":"abc123"`)
+}
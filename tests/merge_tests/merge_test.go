@@ -0,0 +1,46 @@
+package merge_tests
+
+import (
+	"testing"
+
+	"github.com/antibomberman/querycraft"
+	"github.com/antibomberman/querycraft/dialect"
+	"github.com/antibomberman/querycraft/tests/test_utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeToSQL(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+
+	sql, args := querycraft.NewMergeBuilder(mockDB, &dialect.MySQLDialect{}, "products p").
+		Using("product_updates u", "p.sku = u.sku").
+		WhenMatched(func(q querycraft.UpdateBuilder) querycraft.UpdateBuilder {
+			return q.Set("price", 10).Where("active", "=", true)
+		}).
+		WhenNotMatched(func(q querycraft.InsertBuilder) querycraft.InsertBuilder {
+			return q.Columns("sku", "price").Values("A-1", 10)
+		}).
+		WhenNotMatchedBySource(func(q querycraft.DeleteBuilder) querycraft.DeleteBuilder {
+			return q
+		}).
+		ToSQL()
+
+	expectedSQL := "MERGE INTO `products` AS p USING `product_updates` AS u ON `p`.`sku` = `u`.`sku` " +
+		"WHEN MATCHED AND `active` = ? THEN UPDATE SET `price` = ? " +
+		"WHEN NOT MATCHED THEN INSERT (`sku`, `price`) VALUES (?, ?) " +
+		"WHEN NOT MATCHED BY SOURCE THEN DELETE"
+
+	assert.Equal(t, expectedSQL, sql)
+	assert.Equal(t, []any{true, 10, "A-1", 10}, args)
+}
+
+func TestMergeNotSupportedByMySQL(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+
+	_, err := querycraft.NewMergeBuilder(mockDB, &dialect.MySQLDialect{}, "products").
+		Using("product_updates", "products.sku = product_updates.sku").
+		WhenNotMatchedBySource(func(q querycraft.DeleteBuilder) querycraft.DeleteBuilder { return q }).
+		Exec()
+
+	assert.ErrorIs(t, err, querycraft.ErrMergeNotSupported)
+}
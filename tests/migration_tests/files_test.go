@@ -0,0 +1,159 @@
+package migration_tests
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/antibomberman/querycraft"
+	"github.com/antibomberman/querycraft/dialect"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateWritesGoSkeletons(t *testing.T) {
+	dir := t.TempDir()
+	manager := querycraft.NewMigrationManager(nil, &dialect.MySQLDialect{})
+
+	assert.NoError(t, manager.Create("create_users_table", dir))
+	assert.NoError(t, manager.Create("create_orders_table", dir))
+
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	var files []string
+	for _, entry := range entries {
+		files = append(files, entry.Name())
+	}
+	assert.Len(t, files, 5)
+	assert.Contains(t, files, "register.go")
+
+	// Все файлы - один пакет migrations, Up и Down одной миграции в разных файлах
+	fset := token.NewFileSet()
+	methods := make(map[string][]string)
+	var base string
+	for _, file := range files {
+		parsed, err := parser.ParseFile(fset, filepath.Join(dir, file), nil, 0)
+		assert.NoError(t, err, file)
+		assert.Equal(t, "migrations", parsed.Name.Name)
+		if strings.HasSuffix(file, "_create_users_table.up.go") {
+			base = strings.TrimSuffix(file, ".up.go")
+		}
+		for _, decl := range parsed.Decls {
+			if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv != nil {
+				recv := fn.Recv.List[0].Type.(*ast.StarExpr).X.(*ast.Ident).Name
+				methods[recv] = append(methods[recv], fn.Name.Name)
+			}
+		}
+	}
+	assert.Len(t, methods, 2)
+	for _, names := range methods {
+		assert.ElementsMatch(t, []string{"Up", "Down"}, names)
+	}
+
+	register, err := os.ReadFile(filepath.Join(dir, "register.go"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(register), `{"`+base+`", &Migration`)
+
+	// Go миграции загружаются только через сгенерированный Register
+	assert.Error(t, manager.LoadMigrationsFromDir(dir))
+}
+
+func TestLoadMigrationsFromDirAcceptsRegisteredGoMigrations(t *testing.T) {
+	dir := t.TempDir()
+	manager := querycraft.NewMigrationManager(nil, &dialect.MySQLDialect{})
+	assert.NoError(t, manager.Create("create_users_table", dir))
+
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	for _, entry := range entries {
+		if name, ok := strings.CutSuffix(entry.Name(), ".up.go"); ok {
+			var ups []string
+			assert.NoError(t, manager.RegisterMigration(name, recordingMigration{name: name, ups: &ups}))
+		}
+	}
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "20240101000000_seed.up.sql"), []byte("INSERT INTO users (id) VALUES (1);\n"), 0644))
+
+	assert.NoError(t, manager.LoadMigrationsFromDir(dir))
+	assert.Len(t, manager.GetMigrations(), 2)
+}
+
+func TestCreateRequiresDir(t *testing.T) {
+	manager := querycraft.NewMigrationManager(nil, &dialect.MySQLDialect{})
+	assert.Error(t, manager.Create("create_users_table", ""))
+
+	dir := filepath.Join(t.TempDir(), "migrations")
+	assert.NoError(t, manager.SetMigrationsDir(dir).Create("create_users_table", ""))
+	_, err := os.Stat(dir)
+	assert.NoError(t, err)
+}
+
+func TestLoadMigrationsFromFS(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	manager := querycraft.NewMigrationManager(sqlx.NewDb(db, "sqlmock"), &dialect.MySQLDialect{})
+
+	fsys := fstest.MapFS{
+		"migrations/20240101000000_create_users.up.sql": {Data: []byte(
+			"-- create users\nCREATE TABLE users (\n  id INT PRIMARY KEY\n);\nCREATE INDEX users_id ON users (id);\n")},
+		"migrations/20240101000000_create_users.down.sql": {Data: []byte("DROP TABLE users;\n")},
+		"migrations/README.md":                            {Data: []byte("not a migration")},
+	}
+
+	assert.NoError(t, manager.LoadMigrationsFromFS(fsys, "migrations"))
+	migration, ok := manager.GetMigrations()["20240101000000_create_users"]
+	assert.True(t, ok)
+
+	schema := querycraft.NewSchemaBuilder(sqlx.NewDb(db, "sqlmock"), &dialect.MySQLDialect{})
+	mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE users (\n  id INT PRIMARY KEY\n)")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("CREATE INDEX users_id ON users (id)")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("DROP TABLE users")).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	assert.NoError(t, migration.Up(schema))
+	assert.NoError(t, migration.Down(schema))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLoadMigrationsFromFSKeepsBlocksTogether(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	manager := querycraft.NewMigrationManager(sqlx.NewDb(db, "sqlmock"), &dialect.PostgresDialect{})
+
+	function := "CREATE FUNCTION touch() RETURNS trigger AS $$\n" +
+		"BEGIN\n" +
+		"  NEW.updated_at = now();\n" +
+		"  RETURN NEW;\n" +
+		"END;\n" +
+		"$$ LANGUAGE plpgsql"
+	trigger := "CREATE TRIGGER users_audit AFTER UPDATE ON users FOR EACH ROW\n" +
+		"BEGIN\n" +
+		"  IF NEW.status <> OLD.status THEN\n" +
+		"    INSERT INTO audit (note) VALUES ('status; changed');\n" +
+		"  END IF;\n" +
+		"  UPDATE stats SET kind = CASE WHEN NEW.vip THEN 'vip' ELSE 'user' END;\n" +
+		"END"
+
+	fsys := fstest.MapFS{
+		"20240101000000_triggers.up.sql": {Data: []byte(
+			"BEGIN;\n" + function + ";\n" + trigger + ";\nCOMMIT;\n")},
+	}
+
+	assert.NoError(t, manager.LoadMigrationsFromFS(fsys, "."))
+	migration, ok := manager.GetMigrations()["20240101000000_triggers"]
+	assert.True(t, ok)
+
+	// BEGIN; - начало транзакции, а не блока; ";" внутри $$ и BEGIN ... END запрос не делит
+	schema := querycraft.NewSchemaBuilder(sqlx.NewDb(db, "sqlmock"), &dialect.PostgresDialect{})
+	for _, stmt := range []string{"BEGIN", function, trigger, "COMMIT"} {
+		mock.ExpectExec("^" + regexp.QuoteMeta(stmt) + "$").WillReturnResult(sqlmock.NewResult(0, 0))
+	}
+
+	assert.NoError(t, migration.Up(schema))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,55 @@
+package migration_tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/antibomberman/querycraft"
+	"github.com/antibomberman/querycraft/dialect"
+	"github.com/antibomberman/querycraft/tests/test_utils"
+	"github.com/stretchr/testify/assert"
+)
+
+type UserProfile struct {
+	ID        int64     `db:"id"`
+	Email     string    `db:"email" schema:"size:100;unique"`
+	Bio       *string   `db:"bio" schema:"type:text"`
+	Balance   float64   `db:"balance" schema:"precision:12;scale:2;default:0"`
+	Active    bool      `db:"active" schema:"default:true"`
+	CreatedAt time.Time `db:"created_at"`
+	Internal  string    `db:"-"`
+}
+
+func TestCreateFromSchema(t *testing.T) {
+	dir := t.TempDir()
+	manager := querycraft.NewMigrationManager(&test_utils.MockSQLXExecutor{}, &dialect.MySQLDialect{}).
+		SetMigrationsDir(dir)
+
+	source, err := manager.CreateFromSchema("create_user_profiles_table", UserProfile{})
+	assert.NoError(t, err)
+
+	assert.Contains(t, source, "type CreateUserProfilesTable struct{}")
+	assert.Contains(t, source, `schema.CreateTable("user_profiles", func(table querycraft.TableBuilder) {`)
+	assert.Contains(t, source, "\t\ttable.ID()\n")
+	assert.Contains(t, source, `table.String("email", 100).Unique()`)
+	assert.Contains(t, source, `table.Text("bio").Nullable()`)
+	assert.Contains(t, source, `table.Decimal("balance", 12, 2).Default(0)`)
+	assert.Contains(t, source, `table.Boolean("active").Default(true)`)
+	assert.Contains(t, source, `table.Timestamp("created_at")`)
+	assert.Contains(t, source, `return schema.DropTable("user_profiles")`)
+	assert.NotContains(t, source, "Internal")
+	assert.Contains(t, source, "CREATE TABLE `user_profiles`")
+
+	written, err := os.ReadFile(filepath.Join(dir, "create_user_profiles_table.go"))
+	assert.NoError(t, err)
+	assert.Equal(t, source, string(written))
+}
+
+func TestCreateFromSchema_NotStruct(t *testing.T) {
+	manager := querycraft.NewMigrationManager(&test_utils.MockSQLXExecutor{}, &dialect.MySQLDialect{})
+
+	_, err := manager.CreateFromSchema("bad", 42)
+	assert.Error(t, err)
+}
@@ -0,0 +1,307 @@
+package migration_tests
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/antibomberman/querycraft"
+	"github.com/antibomberman/querycraft/dialect"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+// migrationsColumns - колонки актуальной таблицы migrations
+var migrationsColumns = []string{"id", "name", "batch", "version", "checksum", "created_at"}
+
+// expectMigrationsTable ожидает CREATE TABLE IF NOT EXISTS и проверку колонок существующей таблицы
+func expectMigrationsTable(mock sqlmock.Sqlmock, columns ...string) {
+	if len(columns) == 0 {
+		columns = migrationsColumns
+	}
+	rows := sqlmock.NewRows([]string{"Name", "Type"})
+	for _, column := range columns {
+		rows.AddRow(column, "varchar")
+	}
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS migrations").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("FROM information_schema.columns").WillReturnRows(rows)
+}
+
+type versionedMigration struct {
+	version string
+	downs   *[]string
+}
+
+func (m versionedMigration) Up(schema querycraft.SchemaBuilder) error { return nil }
+
+func (m versionedMigration) Down(schema querycraft.SchemaBuilder) error {
+	*m.downs = append(*m.downs, m.version)
+	return nil
+}
+
+func (m versionedMigration) Version() string { return m.version }
+
+func TestGetMigrationVersion(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	manager := querycraft.NewMigrationManager(sqlx.NewDb(db, "sqlmock"), &dialect.MySQLDialect{})
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COALESCE(version, '') FROM migrations WHERE name = ?")).
+		WithArgs("create_users").
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow("v2.1.0"))
+
+	version, err := manager.GetMigrationVersion("create_users")
+	assert.NoError(t, err)
+	assert.Equal(t, "v2.1.0", version)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRollbackToVersion(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	manager := querycraft.NewMigrationManager(sqlx.NewDb(db, "sqlmock"), &dialect.MySQLDialect{})
+
+	var downs []string
+	for name, version := range map[string]string{
+		"create_users":  "v2.0.0",
+		"add_email":     "v2.1.0",
+		"add_phone":     "v2.10.0",
+		"create_orders": "v3.0.0",
+	} {
+		assert.NoError(t, manager.RegisterMigration(name, versionedMigration{version: version, downs: &downs}))
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT name, COALESCE(version, '') FROM migrations ORDER BY created_at DESC, id DESC")).
+		WillReturnRows(sqlmock.NewRows([]string{"name", "version"}).
+			AddRow("create_orders", "v3.0.0").
+			AddRow("add_phone", "v2.10.0").
+			AddRow("add_email", "v2.1.0").
+			AddRow("create_users", "v2.0.0"))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM migrations WHERE name = ?")).
+		WithArgs("create_orders").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM migrations WHERE name = ?")).
+		WithArgs("add_phone").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	assert.NoError(t, manager.RollbackToVersion("v2.1.0"))
+	assert.Equal(t, []string{"v3.0.0", "v2.10.0"}, downs)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRollbackRevertsBatchInReverseOrder(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	manager := querycraft.NewMigrationManager(sqlx.NewDb(db, "sqlmock"), &dialect.MySQLDialect{})
+
+	var downs []string
+	for _, name := range []string{"create_users", "add_email", "add_phone"} {
+		assert.NoError(t, manager.RegisterMigration(name, versionedMigration{version: name, downs: &downs}))
+	}
+
+	// Миграции одного batch применены в одну секунду: порядок задает id
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COALESCE(MAX(batch), 0) FROM migrations")).
+		WillReturnRows(sqlmock.NewRows([]string{"batch"}).AddRow(2))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT name FROM migrations WHERE batch = ? ORDER BY created_at ASC, id ASC")).
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows([]string{"name"}).
+			AddRow("create_users").
+			AddRow("add_email").
+			AddRow("add_phone"))
+	for _, name := range []string{"add_phone", "add_email"} {
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM migrations WHERE name = ?")).
+			WithArgs(name).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+
+	assert.NoError(t, manager.Rollback(2))
+	assert.Equal(t, []string{"add_phone", "add_email"}, downs)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStatusReportsMissingMigrations(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	manager := querycraft.NewMigrationManager(sqlx.NewDb(db, "sqlmock"), &dialect.MySQLDialect{})
+
+	var downs []string
+	assert.NoError(t, manager.RegisterMigration("create_users", versionedMigration{downs: &downs}))
+	assert.NoError(t, manager.RegisterMigration("create_orders", versionedMigration{downs: &downs}))
+
+	now := time.Now()
+	expectMigrationsTable(mock)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT name, batch, created_at, COALESCE(checksum, '') FROM migrations ORDER BY created_at ASC, id ASC")).
+		WillReturnRows(sqlmock.NewRows([]string{"name", "batch", "created_at", "checksum"}).
+			AddRow("create_legacy_table", 1, now, "").
+			AddRow("create_users", 1, now, ""))
+
+	statuses, err := manager.Status()
+	assert.NoError(t, err)
+	assert.Len(t, statuses, 3)
+
+	byName := make(map[string]querycraft.MigrationStatus)
+	for _, status := range statuses {
+		byName[status.Name] = status
+	}
+
+	assert.True(t, byName["create_users"].Applied)
+	assert.False(t, byName["create_users"].Missing)
+	assert.False(t, byName["create_orders"].Applied)
+	assert.False(t, byName["create_orders"].Missing)
+	assert.True(t, byName["create_legacy_table"].Applied)
+	assert.True(t, byName["create_legacy_table"].Missing)
+	assert.Equal(t, 1, byName["create_legacy_table"].Batch)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+type recordingMigration struct {
+	name string
+	ups  *[]string
+}
+
+func (m recordingMigration) Up(schema querycraft.SchemaBuilder) error {
+	*m.ups = append(*m.ups, m.name)
+	return nil
+}
+
+func (m recordingMigration) Down(schema querycraft.SchemaBuilder) error { return nil }
+
+func TestMigrationsRunInRegistrationOrder(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	manager := querycraft.NewMigrationManager(sqlx.NewDb(db, "sqlmock"), &dialect.MySQLDialect{})
+
+	var ups []string
+	names := []string{"c_create_orders", "a_create_users", "b_add_email"}
+	for _, name := range names {
+		assert.NoError(t, manager.RegisterMigration(name, recordingMigration{name: name, ups: &ups}))
+	}
+
+	expectMigrationsTable(mock)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT name, batch, created_at, COALESCE(checksum, '') FROM migrations ORDER BY created_at ASC, id ASC")).
+		WillReturnRows(sqlmock.NewRows([]string{"name", "batch", "created_at", "checksum"}))
+
+	statuses, err := manager.Status()
+	assert.NoError(t, err)
+	var listed []string
+	for _, status := range statuses {
+		listed = append(listed, status.Name)
+	}
+	assert.Equal(t, names, listed)
+
+	expectMigrationsTable(mock)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT name FROM migrations ORDER BY created_at ASC, id ASC")).
+		WillReturnRows(sqlmock.NewRows([]string{"name"}))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT name, COALESCE(checksum, '') FROM migrations")).
+		WillReturnRows(sqlmock.NewRows([]string{"name", "checksum"}))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COALESCE(MAX(batch), 0) + 1 FROM migrations")).
+		WillReturnRows(sqlmock.NewRows([]string{"batch"}).AddRow(1))
+	for _, name := range names {
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO migrations (name, batch, version, checksum) VALUES (?, ?, ?, ?)")).
+			WithArgs(name, 1, nil, nil).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+
+	assert.NoError(t, manager.Up())
+	assert.Equal(t, names, ups)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+type hashedMigration struct {
+	hash string
+}
+
+func (m hashedMigration) Up(schema querycraft.SchemaBuilder) error   { return nil }
+func (m hashedMigration) Down(schema querycraft.SchemaBuilder) error { return nil }
+func (m hashedMigration) Hash() string                               { return m.hash }
+
+func TestUpStoresChecksum(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	manager := querycraft.NewMigrationManager(sqlx.NewDb(db, "sqlmock"), &dialect.MySQLDialect{})
+	assert.NoError(t, manager.RegisterMigration("create_users", hashedMigration{hash: "abc"}))
+
+	expectMigrationsTable(mock)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT name FROM migrations ORDER BY created_at ASC, id ASC")).
+		WillReturnRows(sqlmock.NewRows([]string{"name"}))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT name, COALESCE(checksum, '') FROM migrations")).
+		WillReturnRows(sqlmock.NewRows([]string{"name", "checksum"}))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COALESCE(MAX(batch), 0) + 1 FROM migrations")).
+		WillReturnRows(sqlmock.NewRows([]string{"batch"}).AddRow(1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO migrations (name, batch, version, checksum) VALUES (?, ?, ?, ?)")).
+		WithArgs("create_users", 1, nil, "abc").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	assert.NoError(t, manager.Up())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestChecksumMismatch(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	manager := querycraft.NewMigrationManager(sqlx.NewDb(db, "sqlmock"), &dialect.MySQLDialect{})
+	assert.NoError(t, manager.RegisterMigration("create_users", hashedMigration{hash: "changed"}))
+
+	expectMigrationsTable(mock)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT name FROM migrations ORDER BY created_at ASC, id ASC")).
+		WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("create_users"))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT name, COALESCE(checksum, '') FROM migrations")).
+		WillReturnRows(sqlmock.NewRows([]string{"name", "checksum"}).AddRow("create_users", "original"))
+
+	err = manager.Up()
+	assert.ErrorIs(t, err, querycraft.ErrChecksumMismatch)
+	assert.Contains(t, err.Error(), "create_users")
+
+	expectMigrationsTable(mock)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT name, batch, created_at, COALESCE(checksum, '') FROM migrations ORDER BY created_at ASC, id ASC")).
+		WillReturnRows(sqlmock.NewRows([]string{"name", "batch", "created_at", "checksum"}).
+			AddRow("create_users", 1, time.Now(), "original"))
+
+	_, err = manager.Status()
+	assert.ErrorIs(t, err, querycraft.ErrChecksumMismatch)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrationsTableAddsVersionColumn(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	manager := querycraft.NewMigrationManager(sqlx.NewDb(db, "sqlmock"), &dialect.MySQLDialect{})
+
+	// Таблица, созданная до появления колонки version
+	expectMigrationsTable(mock, "id", "name", "batch", "checksum", "created_at")
+	mock.ExpectExec(regexp.QuoteMeta("ALTER TABLE migrations ADD COLUMN version VARCHAR(64) NULL")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT name, batch, created_at, COALESCE(checksum, '') FROM migrations ORDER BY created_at ASC, id ASC")).
+		WillReturnRows(sqlmock.NewRows([]string{"name", "batch", "created_at", "checksum"}))
+
+	_, err = manager.Status()
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrationsTableUpgradesBaselineTable(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	manager := querycraft.NewMigrationManager(sqlx.NewDb(db, "sqlmock"), &dialect.MySQLDialect{})
+	assert.NoError(t, manager.RegisterMigration("create_users", hashedMigration{hash: "abc"}))
+
+	// Исходная таблица из 4 колонок: без version и checksum
+	expectMigrationsTable(mock, "id", "name", "batch", "created_at")
+	mock.ExpectExec(regexp.QuoteMeta("ALTER TABLE migrations ADD COLUMN version VARCHAR(64) NULL")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("ALTER TABLE migrations ADD COLUMN checksum VARCHAR(64) NULL")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT name FROM migrations ORDER BY created_at ASC, id ASC")).
+		WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("create_legacy"))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT name, COALESCE(checksum, '') FROM migrations")).
+		WillReturnRows(sqlmock.NewRows([]string{"name", "checksum"}).AddRow("create_legacy", ""))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COALESCE(MAX(batch), 0) + 1 FROM migrations")).
+		WillReturnRows(sqlmock.NewRows([]string{"batch"}).AddRow(2))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO migrations (name, batch, version, checksum) VALUES (?, ?, ?, ?)")).
+		WithArgs("create_users", 2, nil, "abc").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	assert.NoError(t, manager.Up())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,136 @@
+package migration_tests
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/antibomberman/querycraft"
+	"github.com/antibomberman/querycraft/dialect"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+type createTableMigration struct {
+	err error
+}
+
+func (m createTableMigration) Up(schema querycraft.SchemaBuilder) error {
+	if m.err != nil {
+		return m.err
+	}
+	return schema.CreateTable("users", func(table querycraft.TableBuilder) {
+		table.String("name").NotNull()
+	})
+}
+
+func (m createTableMigration) Down(schema querycraft.SchemaBuilder) error {
+	return schema.DropTable("users")
+}
+
+func expectPendingMigrations(mock sqlmock.Sqlmock) {
+	expectMigrationsTable(mock)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT name FROM migrations ORDER BY created_at ASC, id ASC")).
+		WillReturnRows(sqlmock.NewRows([]string{"name"}))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT name, COALESCE(checksum, '') FROM migrations")).
+		WillReturnRows(sqlmock.NewRows([]string{"name", "checksum"}))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COALESCE(MAX(batch), 0) + 1 FROM migrations")).
+		WillReturnRows(sqlmock.NewRows([]string{"batch"}).AddRow(1))
+}
+
+func TestTransactionalMigrationCommits(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	manager := querycraft.NewMigrationManager(sqlx.NewDb(db, "sqlmock"), &dialect.PostgresDialect{},
+		querycraft.WithTransactionalMigrations(true))
+	assert.NoError(t, manager.RegisterMigration("create_users", createTableMigration{}))
+
+	expectPendingMigrations(mock)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`CREATE TABLE "users"`)).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO migrations (name, batch, version, checksum) VALUES ($1, $2, $3, $4)")).
+		WithArgs("create_users", 1, nil, nil).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	assert.NoError(t, manager.Up())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostgresMigrationsTable(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	manager := querycraft.NewMigrationManager(sqlx.NewDb(db, "sqlmock"), &dialect.PostgresDialect{})
+
+	mock.ExpectExec(regexp.QuoteMeta("id BIGSERIAL PRIMARY KEY,")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("FROM information_schema.columns").
+		WillReturnRows(sqlmock.NewRows([]string{"Name", "Type"}).
+			AddRow("id", "bigint").AddRow("name", "character varying").AddRow("batch", "integer").
+			AddRow("version", "character varying").AddRow("checksum", "character varying").
+			AddRow("created_at", "timestamp without time zone"))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT name, batch, created_at, COALESCE(checksum, '') FROM migrations ORDER BY created_at ASC, id ASC")).
+		WillReturnRows(sqlmock.NewRows([]string{"name", "batch", "created_at", "checksum"}))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COALESCE(MAX(batch), 0) FROM migrations")).
+		WillReturnRows(sqlmock.NewRows([]string{"batch"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT name FROM migrations WHERE batch = $1 ORDER BY created_at ASC, id ASC")).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("create_users"))
+
+	_, err = manager.Status()
+	assert.NoError(t, err)
+	current, err := manager.Current()
+	assert.NoError(t, err)
+	assert.Equal(t, "create_users", current)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTransactionalMigrationsFromOptions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	qc, err := querycraft.New("postgres", db, querycraft.Options{TransactionalMigrations: true})
+	assert.NoError(t, err)
+	assert.NoError(t, qc.Migration().RegisterMigration("create_users", createTableMigration{}))
+
+	expectPendingMigrations(mock)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`CREATE TABLE "users"`)).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO migrations")).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	assert.NoError(t, qc.Migration().Up())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTransactionalMigrationRollsBackOnError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	manager := querycraft.NewMigrationManager(sqlx.NewDb(db, "sqlmock"), &dialect.PostgresDialect{},
+		querycraft.WithTransactionalMigrations(true))
+	failure := errors.New("boom")
+	assert.NoError(t, manager.RegisterMigration("create_users", createTableMigration{err: failure}))
+
+	expectPendingMigrations(mock)
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	err = manager.Up()
+	assert.ErrorIs(t, err, failure)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTransactionalMigrationSkippedForMySQL(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	manager := querycraft.NewMigrationManager(sqlx.NewDb(db, "sqlmock"), &dialect.MySQLDialect{},
+		querycraft.WithTransactionalMigrations(true))
+	assert.NoError(t, manager.RegisterMigration("create_users", createTableMigration{}))
+
+	// Без BEGIN/COMMIT: DDL в MySQL выполняет неявный COMMIT
+	expectPendingMigrations(mock)
+	mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE `users`")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO migrations")).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	assert.NoError(t, manager.Up())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,142 @@
+package querycraft_tests
+
+import (
+	"database/sql"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	_ "github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/antibomberman/querycraft"
+)
+
+func TestNewFromDBDetectsMySQL(t *testing.T) {
+	db, err := sql.Open("mysql", "user:password@tcp(127.0.0.1:3306)/test")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	qc, err := querycraft.NewFromDB(db)
+	assert.NoError(t, err)
+	assert.NotNil(t, qc)
+	assert.Equal(t, "mysql", qc.GetDB().DriverName())
+}
+
+func TestNewFromDBUnknownDriver(t *testing.T) {
+	db, _, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	qc, err := querycraft.NewFromDB(db)
+	assert.Nil(t, qc)
+	assert.ErrorIs(t, err, querycraft.ErrUnknownDriver)
+	assert.Contains(t, err.Error(), "mysql")
+}
+
+func TestNewPostgres(t *testing.T) {
+	for _, driver := range []string{"postgres", "pgx"} {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+
+		qc, err := querycraft.New(driver, db)
+		assert.NoError(t, err, driver)
+
+		query, args := qc.Select("id", "name").
+			From("users").
+			Where("age", ">", 18).
+			WhereIn("role", "admin", "editor").
+			Limit(10).
+			Offset(20).
+			ToSQL()
+
+		assert.Equal(t, `SELECT "id", "name" FROM "users" WHERE "age" > $1 AND "role" IN ($2, $3) LIMIT 10 OFFSET 20`, query)
+		assert.Equal(t, []any{18, "admin", "editor"}, args)
+
+		mock.ExpectQuery(`SELECT "id" FROM "users" WHERE "id" = \$1`).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+		row, err := qc.Select("id").From("users").Where("id", "=", 1).Row()
+		assert.NoError(t, err)
+		assert.EqualValues(t, 1, row["id"])
+		assert.NoError(t, mock.ExpectationsWereMet())
+
+		db.Close()
+	}
+}
+
+func TestNewUnsupportedDriver(t *testing.T) {
+	db, _, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	qc, err := querycraft.New("oracle", db)
+	assert.Nil(t, qc)
+	assert.EqualError(t, err, "unsupported driver: oracle")
+
+	// lib/pq регистрирует драйвер под именем "postgres", имени "pq" нет
+	qc, err = querycraft.New("pq", db)
+	assert.Nil(t, qc)
+	assert.EqualError(t, err, "unsupported driver: pq")
+}
+
+func TestPostgresSubqueryPlaceholders(t *testing.T) {
+	db, _, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	qc, err := querycraft.New("postgres", db)
+	assert.NoError(t, err)
+
+	// Подзапрос уже пронумерован, внешний запрос перенумеровывает все заново
+	sub := qc.Select("user_id").From("orders").Where("total", ">", 100)
+	query, args := qc.Select("name").
+		From("users").
+		Where("active", "=", true).
+		WhereExists(sub).
+		Where("name", "<>", "it's ?").
+		ToSQL()
+
+	assert.Equal(t, `SELECT "name" FROM "users" WHERE "active" = $1 AND EXISTS (SELECT "user_id" FROM "orders" WHERE "total" > $2) AND "name" <> $3`, query)
+	assert.Equal(t, []any{true, 100, "it's ?"}, args)
+}
+
+func TestNewSQLite(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	qc, err := querycraft.New("sqlite3", db)
+	assert.NoError(t, err)
+
+	query, args := qc.Select("id", "name").
+		From("users").
+		Where("age", ">", 18).
+		Limit(10).
+		Offset(20).
+		ToSQL()
+
+	assert.Equal(t, `SELECT "id", "name" FROM "users" WHERE "age" > ? LIMIT 10 OFFSET 20`, query)
+	assert.Equal(t, []any{18}, args)
+
+	query, _ = qc.Insert("users").Columns("name", "email").Values("John", "john@example.com").Ignore().ToSQL()
+	assert.Equal(t, `INSERT OR IGNORE INTO "users" ("name", "email") VALUES (?, ?)`, query)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) > 0 FROM sqlite_master WHERE type = 'table' AND name = 'users'")).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(1))
+
+	has, err := qc.Schema().HasTable("users")
+	assert.NoError(t, err)
+	assert.True(t, has)
+
+	mock.ExpectExec(regexp.QuoteMeta(`CREATE TABLE "posts" ("id" INTEGER PRIMARY KEY, "title" VARCHAR(255) NOT NULL)`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = qc.Schema().CreateTable("posts", func(table querycraft.TableBuilder) {
+		table.ID()
+		table.String("title").NotNull()
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,38 @@
+package querycraft_tests
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/antibomberman/querycraft"
+)
+
+func TestRebindAndBindNamed(t *testing.T) {
+	db, _, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	qc, err := querycraft.New("mysql", db)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "SELECT * FROM users WHERE id = ?", qc.Rebind("SELECT * FROM users WHERE id = ?"))
+
+	query, args, err := qc.BindNamed("SELECT * FROM users WHERE name = :name AND age > :age",
+		map[string]any{"name": "John", "age": 18})
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM users WHERE name = ? AND age > ?", query)
+	assert.Equal(t, []any{"John", 18}, args)
+}
+
+func TestRawRebind(t *testing.T) {
+	db, _, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	raw := querycraft.NewRaw(sqlx.NewDb(db, "postgres"), "SELECT * FROM users WHERE id = ? AND role = ?", 1, "admin").Rebind()
+
+	assert.Equal(t, "SELECT * FROM users WHERE id = $1 AND role = $2", raw.Query())
+}
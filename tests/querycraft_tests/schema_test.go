@@ -0,0 +1,34 @@
+package querycraft_tests
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/antibomberman/querycraft"
+)
+
+func TestWithSchema(t *testing.T) {
+	db, err := sql.Open("mysql", "user:password@tcp(127.0.0.1:3306)/test")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	qc, err := querycraft.New("mysql", db)
+	assert.NoError(t, err)
+
+	reporting := qc.WithSchema("reporting")
+
+	sql, _ := reporting.Select("id").From("users").ToSQL()
+	assert.Equal(t, "SELECT `id` FROM `reporting`.`users`", sql)
+
+	sql, _ = reporting.Select("id").From("audit.users").ToSQL()
+	assert.Equal(t, "SELECT `id` FROM `audit`.`users`", sql)
+
+	sql, _ = reporting.Delete("users").Where("id", "=", 1).ToSQL()
+	assert.Equal(t, "DELETE FROM `reporting`.`users` WHERE `id` = ?", sql)
+
+	// Исходный экземпляр не изменяется
+	sql, _ = qc.Select("id").From("users").ToSQL()
+	assert.Equal(t, "SELECT `id` FROM `users`", sql)
+}
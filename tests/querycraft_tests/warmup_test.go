@@ -0,0 +1,74 @@
+package querycraft_tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/antibomberman/querycraft"
+)
+
+func TestWarmUp(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+	mock.MatchExpectationsInOrder(false)
+
+	for i := 0; i < 3; i++ {
+		mock.ExpectExec("SELECT 1").WillReturnResult(sqlmock.NewResult(0, 0))
+	}
+
+	qc, err := querycraft.New("mysql", db)
+	assert.NoError(t, err)
+
+	assert.NoError(t, qc.WarmUp(context.Background(), 3))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWarmUpOnNewFails(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec("SELECT 1").WillReturnError(errors.New("connection refused"))
+
+	qc, err := querycraft.New("mysql", db, querycraft.DefaultOptions().WithWarmUpOnNew(1))
+	assert.Nil(t, qc)
+	assert.ErrorContains(t, err, "connection refused")
+}
+
+func TestWarmUpCappedAtMaxOpenConns(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+	db.SetMaxOpenConns(2)
+	mock.MatchExpectationsInOrder(false)
+
+	for i := 0; i < 2; i++ {
+		mock.ExpectExec("SELECT 1").WillReturnResult(sqlmock.NewResult(0, 0))
+	}
+
+	qc, err := querycraft.New("mysql", db)
+	assert.NoError(t, err)
+
+	// Больше MaxOpenConns держать нельзя: без ограничения вызов завис бы
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	assert.NoError(t, qc.WarmUp(ctx, 5))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWarmUpNegativeConnections(t *testing.T) {
+	db, _, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	qc, err := querycraft.New("mysql", db)
+	assert.NoError(t, err)
+
+	assert.Error(t, qc.WarmUp(context.Background(), -1))
+}
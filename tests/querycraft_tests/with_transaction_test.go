@@ -0,0 +1,181 @@
+package querycraft_tests
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/antibomberman/querycraft"
+)
+
+func TestWithTransactionCommit(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE `accounts`").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	qc, err := querycraft.New("mysql", db)
+	assert.NoError(t, err)
+
+	err = qc.WithTransaction(context.Background(), func(tx querycraft.Transaction) error {
+		_, err := tx.Update("accounts").Set("balance", 100).Where("id", "=", 1).Exec()
+		return err
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+type rollbackLogger struct {
+	queries []string
+	errs    []error
+}
+
+func (l *rollbackLogger) LogQuery(ctx context.Context, query string, args []any, duration time.Duration, err error) {
+	l.queries = append(l.queries, query)
+	l.errs = append(l.errs, err)
+}
+
+func TestWithTransactionRollback(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	rollbackErr := errors.New("connection lost")
+	mock.ExpectBegin()
+	mock.ExpectRollback().WillReturnError(rollbackErr)
+
+	qc, err := querycraft.New("mysql", db)
+	assert.NoError(t, err)
+	logger := &rollbackLogger{}
+	qc.(interface {
+		SetLogger(querycraft.Logger) querycraft.QueryCraft
+	}).SetLogger(logger)
+
+	failure := errors.New("insufficient funds")
+	err = qc.WithTransaction(context.Background(), func(tx querycraft.Transaction) error {
+		return failure
+	})
+	assert.ErrorIs(t, err, failure)
+	assert.Equal(t, []string{"ROLLBACK"}, logger.queries)
+	assert.ErrorIs(t, logger.errs[0], rollbackErr)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWithTransactionOptions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectCommit()
+
+	qc, err := querycraft.New("mysql", db)
+	assert.NoError(t, err)
+
+	err = qc.WithTransaction(context.Background(), func(tx querycraft.Transaction) error {
+		return nil
+	}, querycraft.TxOptions{IsolationLevel: sql.LevelSerializable})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestBeginTxReadOnly(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT COUNT").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(42))
+	mock.ExpectCommit()
+
+	qc, err := querycraft.New("mysql", db)
+	assert.NoError(t, err)
+
+	tx, err := qc.BeginTx(querycraft.TxOptions{IsolationLevel: sql.LevelRepeatableRead, ReadOnly: true})
+	assert.NoError(t, err)
+	count, err := tx.Select().From("orders").Count()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(42), count)
+	assert.NoError(t, tx.Commit())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWithTransactionUsesCallerContext(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	qc, err := querycraft.New("mysql", db)
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	calls := 0
+	err = qc.WithTransaction(ctx, func(tx querycraft.Transaction) error {
+		calls++
+		return nil
+	}, querycraft.TxOptions{IsolationLevel: sql.LevelReadUncommitted})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 0, calls)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWithTransactionRetryOnDeadlock(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	deadlock := &mysql.MySQLError{Number: 1213, Message: "Deadlock found when trying to get lock"}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE `stock`").WillReturnError(deadlock)
+	mock.ExpectRollback()
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE `stock`").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	qc, err := querycraft.New("mysql", db, querycraft.Options{DeadlockRetryBackoff: time.Millisecond})
+	assert.NoError(t, err)
+
+	calls := 0
+	err = qc.WithTransactionRetry(context.Background(), 3, func(tx querycraft.Transaction) error {
+		calls++
+		_, err := tx.Update("stock").Decrement("qty").Where("id", "=", 1).Exec()
+		return err
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWithTransactionRetryStopsOnOtherErrors(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	qc, err := querycraft.New("mysql", db)
+	assert.NoError(t, err)
+
+	calls := 0
+	failure := &mysql.MySQLError{Number: 1062, Message: "Duplicate entry"}
+	err = qc.WithTransactionRetry(context.Background(), 3, func(tx querycraft.Transaction) error {
+		calls++
+		return failure
+	})
+	assert.ErrorIs(t, err, failure)
+	assert.Equal(t, 1, calls)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,55 @@
+package raw_tests
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/antibomberman/querycraft"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchExec(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO users (name) VALUES (?)")).
+		WithArgs("John").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE users SET active = 1")).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	results, err := querycraft.NewBatch(sqlxDB).
+		Add("INSERT INTO users (name) VALUES (?)", "John").
+		Add("UPDATE users SET active = 1").
+		Exec(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, results, 2)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestBatchExec_StopsOnFirstError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM sessions")).
+		WillReturnResult(sqlmock.NewResult(0, 5))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM missing")).
+		WillReturnError(errors.New("table missing"))
+
+	results, err := querycraft.NewBatch(sqlxDB).
+		Add("DELETE FROM sessions").
+		Add("DELETE FROM missing").
+		Add("DELETE FROM tokens").
+		Exec(context.Background())
+
+	assert.Error(t, err)
+	assert.Len(t, results, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
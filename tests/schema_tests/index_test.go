@@ -0,0 +1,63 @@
+package schema_tests
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/antibomberman/querycraft"
+	"github.com/antibomberman/querycraft/dialect"
+)
+
+func TestSchemaBuilder_CreateIndexMySQL(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+
+	schema := querycraft.NewSchemaBuilder(sqlx.NewDb(db, "sqlmock"), &dialect.MySQLDialect{})
+
+	mock.ExpectExec(regexp.QuoteMeta("CREATE INDEX `users_last_name_first_name_index` ON `users` (`last_name`, `first_name`)")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("CREATE UNIQUE INDEX `uq_email` ON `users` (`email`) USING HASH")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("ALTER TABLE `users` DROP INDEX `uq_email`")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	assert.NoError(t, schema.CreateIndex("users", []string{"last_name", "first_name"}))
+	assert.NoError(t, schema.CreateIndex("users", []string{"email"}, querycraft.IndexUnique(), querycraft.IndexName("uq_email"), querycraft.IndexUsing("HASH")))
+	assert.NoError(t, schema.DropIndex("users", "uq_email"))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSchemaBuilder_CreateIndexPartialNotSupportedMySQL(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+
+	schema := querycraft.NewSchemaBuilder(sqlx.NewDb(db, "sqlmock"), &dialect.MySQLDialect{})
+
+	err = schema.CreateIndex("users", []string{"email"}, querycraft.IndexPartial("deleted_at IS NULL"))
+	assert.True(t, errors.Is(err, querycraft.ErrNotSupported))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSchemaBuilder_CreateIndexPostgres(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+
+	schema := querycraft.NewSchemaBuilder(sqlx.NewDb(db, "sqlmock"), &dialect.PostgresDialect{})
+
+	mock.ExpectExec(regexp.QuoteMeta(`CREATE UNIQUE INDEX "users_email_unique" ON "users" ("email") WHERE deleted_at IS NULL`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(`CREATE INDEX "docs_body_index" ON "docs" USING GIN ("body")`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(`DROP INDEX "docs_body_index"`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	assert.NoError(t, schema.CreateIndex("users", []string{"email"}, querycraft.IndexUnique(), querycraft.IndexPartial("deleted_at IS NULL")))
+	assert.NoError(t, schema.CreateIndex("docs", []string{"body"}, querycraft.IndexUsing("GIN")))
+	assert.NoError(t, schema.DropIndex("docs", "docs_body_index"))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -3,6 +3,7 @@ package schema_tests
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"regexp"
 	"testing"
 
@@ -130,3 +131,898 @@ func TestSchemaBuilder_WithContext(t *testing.T) {
 	_, ok := schemaWithCtx.(querycraft.SchemaBuilder)
 	assert.True(t, ok)
 }
+
+func TestSchemaBuilder_ForeignKeyActions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	d := &dialect.MySQLDialect{}
+	schema := querycraft.NewSchemaBuilder(sqlxDB, d)
+
+	expectedSQL := regexp.QuoteMeta("CREATE TABLE `posts` (`id` BIGINT UNSIGNED PRIMARY KEY AUTO_INCREMENT, `user_id` BIGINT NOT NULL, CONSTRAINT `posts_user_id_foreign` FOREIGN KEY (`user_id`) REFERENCES `users`(`id`) ON DELETE CASCADE ON UPDATE RESTRICT)")
+
+	mock.ExpectExec(expectedSQL).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = schema.CreateTable("posts", func(table querycraft.TableBuilder) {
+		table.ID()
+		table.BigInteger("user_id").NotNull()
+		table.ForeignKey("user_id", "users", "id").OnDelete("CASCADE").OnUpdate("RESTRICT")
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSchemaBuilder_GetTableSize(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	d := &dialect.MySQLDialect{}
+	schema := querycraft.NewSchemaBuilder(sqlxDB, d)
+
+	columns := []string{"name", "data_bytes", "index_bytes", "total_bytes", "row_count"}
+	mock.ExpectQuery(regexp.QuoteMeta(d.TableSizeQuery("users"))).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow("users", 16384, 8192, 24576, 120))
+
+	size, err := schema.GetTableSize("users")
+	assert.NoError(t, err)
+	assert.Equal(t, querycraft.TableSizeInfo{Name: "users", DataBytes: 16384, IndexBytes: 8192, TotalBytes: 24576, RowCount: 120}, size)
+
+	mock.ExpectQuery(regexp.QuoteMeta(d.DatabaseSizeQuery())).
+		WillReturnRows(sqlmock.NewRows([]string{"size"}).AddRow(65536))
+
+	total, err := schema.GetDatabaseSize()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(65536), total)
+
+	mock.ExpectQuery(regexp.QuoteMeta(d.TopTablesBySizeQuery(2))).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow("orders", 32768, 16384, 49152, 900).
+			AddRow("users", 16384, 8192, 24576, 120))
+
+	top, err := schema.GetTopTablesBySize(2)
+	assert.NoError(t, err)
+	assert.Len(t, top, 2)
+	assert.Equal(t, "orders", top[0].Name)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSchemaBuilder_GetIndexSize(t *testing.T) {
+	for _, d := range []dialect.Dialect{&dialect.MySQLDialect{}, &dialect.PostgresDialect{}} {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+
+		schema := querycraft.NewSchemaBuilder(sqlx.NewDb(db, "sqlmock"), d)
+
+		query := d.IndexSizeQuery("users", "users_email_unique")
+		assert.Contains(t, query, "'users_email_unique'")
+		mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WillReturnRows(sqlmock.NewRows([]string{"size"}).AddRow(16384))
+
+		size, err := schema.GetIndexSize("users", "users_email_unique")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(16384), size)
+		assert.NoError(t, mock.ExpectationsWereMet())
+		db.Close()
+	}
+}
+
+func TestSchemaBuilder_SpatialColumns(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	schema := querycraft.NewSchemaBuilder(sqlxDB, &dialect.MySQLDialect{})
+
+	expectedSQL := regexp.QuoteMeta("CREATE TABLE `places` (`id` BIGINT UNSIGNED PRIMARY KEY AUTO_INCREMENT, " +
+		"`location` POINT SRID 4326 NOT NULL, `route` LINESTRING NULL, `area` POLYGON NULL, `shape` GEOMETRY NULL, " +
+		"SPATIAL INDEX `places_location_spatial` (`location`))")
+	mock.ExpectExec(expectedSQL).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = schema.CreateTable("places", func(table querycraft.TableBuilder) {
+		table.ID()
+		table.Point("location").SRID(4326).NotNull()
+		table.LineString("route").Nullable()
+		table.Polygon("area").Nullable()
+		table.Geometry("shape").Nullable()
+		table.AddSpatialIndex("location")
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSchemaBuilder_SpatialIndexPostgres(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	schema := querycraft.NewSchemaBuilder(sqlxDB, &dialect.PostgresDialect{})
+
+	// В PostgreSQL нет инлайн индексов, GiST индекс создается отдельным запросом
+	mock.ExpectExec(regexp.QuoteMeta(`CREATE TABLE "places" ("id" BIGSERIAL PRIMARY KEY, "location" GEOMETRY(POINT,4326) NOT NULL)`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(`CREATE INDEX "places_location_spatial" ON "places" USING GIST ("location")`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = schema.CreateTable("places", func(table querycraft.TableBuilder) {
+		table.ID()
+		table.Point("location").SRID(4326).NotNull()
+		table.AddSpatialIndex("location")
+	})
+	assert.NoError(t, err)
+
+	mock.ExpectExec(regexp.QuoteMeta(`ALTER TABLE "places" ADD COLUMN "area" GEOMETRY(POLYGON) NULL`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(`CREATE INDEX "places_area_spatial" ON "places" USING GIST ("area")`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = schema.AlterTable("places", func(table querycraft.TableBuilder) {
+		table.Polygon("area").Nullable()
+		table.AddSpatialIndex("area")
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSchemaBuilder_DropTableCascade(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	schema := querycraft.NewSchemaBuilder(sqlxDB, &dialect.MySQLDialect{})
+
+	mock.ExpectExec(regexp.QuoteMeta("SET FOREIGN_KEY_CHECKS = 0")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("DROP TABLE `users`")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("SET FOREIGN_KEY_CHECKS = 1")).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	assert.NoError(t, schema.DropTableCascade("users"))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSchemaBuilder_DropTableRestrictListsDependents(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	d := &dialect.MySQLDialect{}
+	schema := querycraft.NewSchemaBuilder(sqlxDB, d)
+
+	mock.ExpectExec(regexp.QuoteMeta("DROP TABLE `users` RESTRICT")).
+		WillReturnError(errors.New("Cannot drop table 'users' referenced by a foreign key constraint"))
+	mock.ExpectQuery(regexp.QuoteMeta(d.DependentTablesQuery("users"))).
+		WillReturnRows(sqlmock.NewRows([]string{"table_name"}).AddRow("orders").AddRow("posts"))
+
+	err = schema.DropTable("users")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "referenced by: orders, posts")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDependentTablesQueryEscapesTableName(t *testing.T) {
+	for _, d := range []dialect.Dialect{&dialect.MySQLDialect{}, &dialect.PostgresDialect{}, &dialect.SQLiteDialect{}} {
+		query := d.DependentTablesQuery("o'brien")
+		assert.Contains(t, query, "'o''brien'")
+		assert.NotContains(t, query, "'o'brien'")
+	}
+}
+
+func TestSchemaBuilder_ColumnShortcuts(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	schema := querycraft.NewSchemaBuilder(sqlxDB, &dialect.MySQLDialect{})
+
+	mock.ExpectExec(regexp.QuoteMeta("ALTER TABLE `users` ADD COLUMN `phone` VARCHAR(20) NULL DEFAULT NULL")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("ALTER TABLE `users` RENAME COLUMN `phone` TO `mobile`")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("ALTER TABLE `users` DROP COLUMN `mobile`")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	assert.NoError(t, schema.AddColumn("users", "phone", "VARCHAR(20)", "NULL", "DEFAULT NULL"))
+	assert.NoError(t, schema.RenameColumn("users", "phone", "mobile"))
+	assert.NoError(t, schema.DropColumn("users", "mobile"))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSchemaBuilder_CompositePrimaryKey(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	schema := querycraft.NewSchemaBuilder(sqlxDB, &dialect.MySQLDialect{})
+
+	// PRIMARY KEY идет первым и сохраняет порядок колонок, затем UNIQUE KEY, KEY и FOREIGN KEY
+	expectedSQL := regexp.QuoteMeta("CREATE TABLE `order_items` (`order_id` BIGINT, `product_id` BIGINT, `sku` VARCHAR(255), `position` INT, " +
+		"PRIMARY KEY (`order_id`, `product_id`), " +
+		"UNIQUE KEY `order_items_sku_unique` (`sku`), " +
+		"KEY `order_items_position_index` (`position`), " +
+		"CONSTRAINT `order_items_order_id_foreign` FOREIGN KEY (`order_id`) REFERENCES `orders`(`id`))")
+	mock.ExpectExec("^" + expectedSQL + "$").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = schema.CreateTable("order_items", func(table querycraft.TableBuilder) {
+		table.BigInteger("order_id")
+		table.BigInteger("product_id")
+		table.ForeignKey("order_id", "orders", "id")
+		table.String("sku").Unique()
+		table.Integer("position").Index()
+		table.PrimaryKey("order_id", "product_id")
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSchemaBuilder_GetConstraints(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	schema := querycraft.NewSchemaBuilder(sqlx.NewDb(db, "sqlmock"), &dialect.MySQLDialect{})
+
+	columns := []string{"constraint_name", "constraint_type", "column_name", "referenced_table_name", "referenced_column_name", "check_clause"}
+	rows := func() *sqlmock.Rows {
+		return sqlmock.NewRows(columns).
+			AddRow("PRIMARY", "PRIMARY KEY", "order_id", nil, nil, nil).
+			AddRow("PRIMARY", "PRIMARY KEY", "product_id", nil, nil, nil).
+			AddRow("items_order_fk", "FOREIGN KEY", "order_id", "orders", "id", nil).
+			AddRow("items_qty_check", "CHECK", nil, nil, nil, "(`qty` > 0)")
+	}
+	mock.ExpectQuery("FROM information_schema.table_constraints tc .* tc.table_name = 'order_items'").WillReturnRows(rows())
+	mock.ExpectQuery("FROM information_schema.table_constraints").WillReturnRows(rows())
+	mock.ExpectQuery("FROM information_schema.table_constraints").WillReturnRows(rows())
+
+	constraints, err := schema.GetConstraints("order_items")
+	assert.NoError(t, err)
+	assert.Len(t, constraints, 3)
+
+	assert.Equal(t, "PRIMARY KEY", constraints[0].Type)
+	assert.Equal(t, []string{"order_id", "product_id"}, constraints[0].Columns)
+	assert.Nil(t, constraints[0].ReferencedTable)
+
+	assert.Equal(t, "FOREIGN KEY", constraints[1].Type)
+	assert.Equal(t, "orders", *constraints[1].ReferencedTable)
+	assert.Equal(t, []string{"id"}, constraints[1].ReferencedColumns)
+
+	assert.Equal(t, "CHECK", constraints[2].Type)
+	assert.Empty(t, constraints[2].Columns)
+	assert.Equal(t, "(`qty` > 0)", *constraints[2].CheckClause)
+
+	fk, err := schema.GetConstraint("order_items", "items_order_fk")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"order_id"}, fk.Columns)
+
+	_, err = schema.GetConstraint("order_items", "missing")
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSchemaBuilder_IndexesWithoutInlineKey(t *testing.T) {
+	tests := []struct {
+		name       string
+		dialect    dialect.Dialect
+		createSQL  string
+		indexSQL   string
+		alterSQL   string
+		alterIndex []string
+	}{
+		{
+			name:    "postgres",
+			dialect: &dialect.PostgresDialect{},
+			createSQL: `CREATE TABLE "order_items" ("order_id" BIGINT, "sku" VARCHAR(255), "position" INT, ` +
+				`PRIMARY KEY ("order_id"), CONSTRAINT "order_items_sku_unique" UNIQUE ("sku"))`,
+			indexSQL: `CREATE INDEX "order_items_position_index" ON "order_items" ("position")`,
+			alterSQL: `ALTER TABLE "order_items" ADD COLUMN "code" VARCHAR(255)`,
+			alterIndex: []string{
+				`CREATE UNIQUE INDEX "order_items_code_unique" ON "order_items" ("code")`,
+				`CREATE INDEX "order_items_position_sku" ON "order_items" ("position", "sku")`,
+			},
+		},
+		{
+			name:    "sqlite",
+			dialect: &dialect.SQLiteDialect{},
+			createSQL: `CREATE TABLE "order_items" ("order_id" BIGINT, "sku" VARCHAR(255), "position" INT, ` +
+				`PRIMARY KEY ("order_id"), CONSTRAINT "order_items_sku_unique" UNIQUE ("sku"))`,
+			indexSQL: `CREATE INDEX "order_items_position_index" ON "order_items" ("position")`,
+			alterSQL: `ALTER TABLE "order_items" ADD COLUMN "code" VARCHAR(255)`,
+			alterIndex: []string{
+				`CREATE UNIQUE INDEX "order_items_code_unique" ON "order_items" ("code")`,
+				`CREATE INDEX "order_items_position_sku" ON "order_items" ("position", "sku")`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			schema := querycraft.NewSchemaBuilder(sqlx.NewDb(db, "sqlmock"), tt.dialect)
+
+			// UNIQUE остается ограничением таблицы, обычный индекс создается отдельным запросом
+			mock.ExpectExec("^" + regexp.QuoteMeta(tt.createSQL) + "$").WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectExec("^" + regexp.QuoteMeta(tt.indexSQL) + "$").WillReturnResult(sqlmock.NewResult(0, 0))
+
+			err = schema.CreateTable("order_items", func(table querycraft.TableBuilder) {
+				table.BigInteger("order_id")
+				table.String("sku").Unique()
+				table.Integer("position").Index()
+				table.PrimaryKey("order_id")
+			})
+			assert.NoError(t, err)
+
+			// В ALTER TABLE нет ADD UNIQUE KEY / ADD INDEX, оба индекса создаются через CREATE INDEX
+			mock.ExpectExec("^" + regexp.QuoteMeta(tt.alterSQL) + "$").WillReturnResult(sqlmock.NewResult(0, 0))
+			for _, stmt := range tt.alterIndex {
+				mock.ExpectExec("^" + regexp.QuoteMeta(stmt) + "$").WillReturnResult(sqlmock.NewResult(0, 0))
+			}
+
+			err = schema.AlterTable("order_items", func(table querycraft.TableBuilder) {
+				table.String("code").Unique()
+				table.AddIndex("order_items_position_sku", "position", "sku")
+			})
+			assert.NoError(t, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSchemaBuilder_CreateTablePostgres(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	schema := querycraft.NewSchemaBuilder(sqlxDB, &dialect.PostgresDialect{})
+
+	// BIGSERIAL уже автоинкрементный, AUTO_INCREMENT не добавляется
+	expectedSQL := regexp.QuoteMeta(`CREATE TABLE "users" ("id" BIGSERIAL PRIMARY KEY, "name" VARCHAR(255) NOT NULL)`)
+	mock.ExpectExec(expectedSQL).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = schema.CreateTable("users", func(table querycraft.TableBuilder) {
+		table.ID()
+		table.String("name").NotNull()
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSchemaBuilder_HasQueriesPostgres(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	schema := querycraft.NewSchemaBuilder(sqlxDB, &dialect.PostgresDialect{})
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) > 0 FROM information_schema.tables WHERE table_schema = current_schema() AND table_name = 'users'")).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) > 0 FROM information_schema.columns WHERE table_schema = current_schema() AND table_name = 'users' AND column_name = 'email'")).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) > 0 FROM pg_indexes WHERE schemaname = current_schema() AND tablename = 'users' AND indexname = 'users_email_unique'")).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+	has, err := schema.HasTable("users")
+	assert.NoError(t, err)
+	assert.True(t, has)
+
+	has, err = schema.HasColumn("users", "email")
+	assert.NoError(t, err)
+	assert.True(t, has)
+
+	has, err = schema.HasIndex("users", "users_email_unique")
+	assert.NoError(t, err)
+	assert.False(t, has)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSchemaBuilder_NumericAndBinaryColumns(t *testing.T) {
+	tests := []struct {
+		name     string
+		dialect  dialect.Dialect
+		expected string
+	}{
+		{
+			name:    "mysql",
+			dialect: &dialect.MySQLDialect{},
+			expected: "CREATE TABLE `samples` (`a` SMALLINT NOT NULL, `b` TINYINT NOT NULL, `c` MEDIUMINT NOT NULL, " +
+				"`d` FLOAT NOT NULL, `e` DOUBLE NOT NULL, `f` CHAR(2) NOT NULL, `g` BINARY(16) NOT NULL, `h` BLOB NULL)",
+		},
+		{
+			name:    "postgres",
+			dialect: &dialect.PostgresDialect{},
+			expected: `CREATE TABLE "samples" ("a" SMALLINT NOT NULL, "b" SMALLINT NOT NULL, "c" INTEGER NOT NULL, ` +
+				`"d" REAL NOT NULL, "e" DOUBLE PRECISION NOT NULL, "f" CHAR(2) NOT NULL, "g" BYTEA NOT NULL, "h" BYTEA NULL)`,
+		},
+		{
+			name:    "sqlite",
+			dialect: &dialect.SQLiteDialect{},
+			expected: `CREATE TABLE "samples" ("a" INTEGER NOT NULL, "b" INTEGER NOT NULL, "c" INTEGER NOT NULL, ` +
+				`"d" REAL NOT NULL, "e" REAL NOT NULL, "f" CHAR(2) NOT NULL, "g" BLOB NOT NULL, "h" BLOB NULL)`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+
+			schema := querycraft.NewSchemaBuilder(sqlx.NewDb(db, "sqlmock"), tt.dialect)
+			mock.ExpectExec(regexp.QuoteMeta(tt.expected)).WillReturnResult(sqlmock.NewResult(0, 0))
+
+			err = schema.CreateTable("samples", func(table querycraft.TableBuilder) {
+				table.SmallInteger("a").NotNull()
+				table.TinyInteger("b").NotNull()
+				table.MediumInteger("c").NotNull()
+				table.Float("d").NotNull()
+				table.Double("e").NotNull()
+				table.Char("f", 2).NotNull()
+				table.Binary("g", 16).NotNull()
+				table.Blob("h").Nullable()
+			})
+
+			assert.NoError(t, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSchemaBuilder_EnumAndSetColumns(t *testing.T) {
+	tests := []struct {
+		name     string
+		dialect  dialect.Dialect
+		expected string
+	}{
+		{
+			name:     "mysql",
+			dialect:  &dialect.MySQLDialect{},
+			expected: "CREATE TABLE `posts` (`status` ENUM('draft', 'it''s live') NOT NULL, `tags` SET('a', 'b''c') NULL)",
+		},
+		{
+			name:    "postgres",
+			dialect: &dialect.PostgresDialect{},
+			expected: `CREATE TABLE "posts" ("status" VARCHAR(255) CHECK ("status" IN ('draft', 'it''s live')) NOT NULL, ` +
+				`"tags" TEXT[] CHECK ("tags" <@ ARRAY['a', 'b''c']::TEXT[]) NULL)`,
+		},
+		{
+			name:     "sqlite",
+			dialect:  &dialect.SQLiteDialect{},
+			expected: `CREATE TABLE "posts" ("status" TEXT CHECK ("status" IN ('draft', 'it''s live')) NOT NULL, "tags" TEXT NULL)`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+
+			schema := querycraft.NewSchemaBuilder(sqlx.NewDb(db, "sqlmock"), tt.dialect)
+			mock.ExpectExec(regexp.QuoteMeta(tt.expected)).WillReturnResult(sqlmock.NewResult(0, 0))
+
+			err = schema.CreateTable("posts", func(table querycraft.TableBuilder) {
+				table.Enum("status", "draft", "it's live").NotNull()
+				table.Set("tags", "a", "b'c").Nullable()
+			})
+
+			assert.NoError(t, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSchemaBuilder_UUIDColumns(t *testing.T) {
+	tests := []struct {
+		name     string
+		dialect  dialect.Dialect
+		expected string
+	}{
+		{
+			name:     "mysql",
+			dialect:  &dialect.MySQLDialect{},
+			expected: "CREATE TABLE `tokens` (`id` CHAR(36) NOT NULL PRIMARY KEY, `owner_id` CHAR(36) NULL)",
+		},
+		{
+			name:     "postgres",
+			dialect:  &dialect.PostgresDialect{},
+			expected: `CREATE TABLE "tokens" ("id" UUID NOT NULL PRIMARY KEY, "owner_id" UUID NULL)`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+
+			schema := querycraft.NewSchemaBuilder(sqlx.NewDb(db, "sqlmock"), tt.dialect)
+			mock.ExpectExec(regexp.QuoteMeta(tt.expected)).WillReturnResult(sqlmock.NewResult(0, 0))
+
+			err = schema.CreateTable("tokens", func(table querycraft.TableBuilder) {
+				table.UUIDPrimary()
+				table.UUID("owner_id").Nullable()
+			})
+
+			assert.NoError(t, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSchemaBuilder_GeneratedColumns(t *testing.T) {
+	tests := []struct {
+		name     string
+		dialect  dialect.Dialect
+		expected string
+	}{
+		{
+			name:    "mysql",
+			dialect: &dialect.MySQLDialect{},
+			expected: "CREATE TABLE `orders` (`total` DECIMAL(10, 2) GENERATED ALWAYS AS (price * qty) STORED NULL, " +
+				"`label` VARCHAR(64) GENERATED ALWAYS AS (UPPER(code)) VIRTUAL NOT NULL)",
+		},
+		{
+			name:    "postgres",
+			dialect: &dialect.PostgresDialect{},
+			expected: `CREATE TABLE "orders" ("total" DECIMAL(10, 2) GENERATED ALWAYS AS (price * qty) STORED NULL, ` +
+				`"label" VARCHAR(64) GENERATED ALWAYS AS (UPPER(code)) STORED NOT NULL)`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+
+			schema := querycraft.NewSchemaBuilder(sqlx.NewDb(db, "sqlmock"), tt.dialect)
+			mock.ExpectExec(regexp.QuoteMeta(tt.expected)).WillReturnResult(sqlmock.NewResult(0, 0))
+
+			err = schema.CreateTable("orders", func(table querycraft.TableBuilder) {
+				table.Generated("total", "DECIMAL(10, 2)", "price * qty", true).Nullable().Default(0)
+				table.Generated("label", "VARCHAR(64)", "UPPER(code)", false).AutoIncrement().NotNull()
+			})
+
+			assert.NoError(t, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSchemaBuilder_CommentsMySQL(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+
+	schema := querycraft.NewSchemaBuilder(sqlx.NewDb(db, "sqlmock"), &dialect.MySQLDialect{})
+
+	expectedSQL := regexp.QuoteMeta("CREATE TABLE `users` (`name` VARCHAR(255) NOT NULL COMMENT 'user''s name') COMMENT = 'Registered users'")
+	mock.ExpectExec(expectedSQL).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = schema.CreateTable("users", func(table querycraft.TableBuilder) {
+		table.String("name").NotNull().Comment("user's name")
+		table.TableComment("Registered users")
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSchemaBuilder_CommentsPostgres(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+
+	schema := querycraft.NewSchemaBuilder(sqlx.NewDb(db, "sqlmock"), &dialect.PostgresDialect{})
+
+	mock.ExpectExec(regexp.QuoteMeta(`CREATE TABLE "users" ("name" VARCHAR(255) NOT NULL)`)).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(`COMMENT ON TABLE "users" IS 'Registered users'`)).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(`COMMENT ON COLUMN "users"."name" IS 'user''s name'`)).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = schema.CreateTable("users", func(table querycraft.TableBuilder) {
+		table.String("name").NotNull().Comment("user's name")
+		table.TableComment("Registered users")
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSchemaBuilder_AlterTableCommentPostgres(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+
+	schema := querycraft.NewSchemaBuilder(sqlx.NewDb(db, "sqlmock"), &dialect.PostgresDialect{})
+
+	// Только комментарий - ALTER TABLE не выполняется
+	mock.ExpectExec(regexp.QuoteMeta(`COMMENT ON TABLE "users" IS 'Accounts'`)).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = schema.AlterTable("users", func(table querycraft.TableBuilder) {
+		table.TableComment("Accounts")
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSchemaBuilder_ModifyColumnMySQL(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+
+	schema := querycraft.NewSchemaBuilder(sqlx.NewDb(db, "sqlmock"), &dialect.MySQLDialect{})
+
+	expectedSQL := regexp.QuoteMeta("ALTER TABLE `users` MODIFY COLUMN `age` BIGINT NOT NULL, MODIFY COLUMN `bio` TEXT NULL")
+	mock.ExpectExec(expectedSQL).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = schema.AlterTable("users", func(table querycraft.TableBuilder) {
+		table.ModifyColumn("age").Type("BIGINT").NotNull()
+		table.ModifyColumn("bio").Type("TEXT").Nullable()
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSchemaBuilder_ModifyColumnPostgres(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+
+	schema := querycraft.NewSchemaBuilder(sqlx.NewDb(db, "sqlmock"), &dialect.PostgresDialect{})
+
+	expectedSQL := regexp.QuoteMeta(`ALTER TABLE "users" ALTER COLUMN "age" TYPE BIGINT, ALTER COLUMN "age" SET NOT NULL, ` +
+		`ALTER COLUMN "age" SET DEFAULT 0, ALTER COLUMN "bio" DROP NOT NULL`)
+	mock.ExpectExec(expectedSQL).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = schema.AlterTable("users", func(table querycraft.TableBuilder) {
+		table.ModifyColumn("age").Type("BIGINT").NotNull().Default(0)
+		table.ModifyColumn("bio").Nullable()
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSchemaBuilder_ModifyColumnNotSupported(t *testing.T) {
+	tests := []struct {
+		name    string
+		dialect dialect.Dialect
+		modify  func(table querycraft.TableBuilder)
+	}{
+		{
+			name:    "sqlite",
+			dialect: &dialect.SQLiteDialect{},
+			modify: func(table querycraft.TableBuilder) {
+				table.ModifyColumn("age").Type("BIGINT").NotNull()
+			},
+		},
+		{
+			// MODIFY COLUMN без типа переопределит колонку некорректно
+			name:    "mysql without type",
+			dialect: &dialect.MySQLDialect{},
+			modify: func(table querycraft.TableBuilder) {
+				table.ModifyColumn("age").NotNull()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			schema := querycraft.NewSchemaBuilder(sqlx.NewDb(db, "sqlmock"), tt.dialect)
+
+			err = schema.AlterTable("users", tt.modify)
+			assert.ErrorIs(t, err, querycraft.ErrNotSupported)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSchemaBuilder_AlterTableRenameColumn(t *testing.T) {
+	tests := []struct {
+		name     string
+		dialect  dialect.Dialect
+		expected []string
+	}{
+		{
+			name:    "mysql",
+			dialect: &dialect.MySQLDialect{},
+			expected: []string{
+				"ALTER TABLE `users` ADD COLUMN `age` INT NULL",
+				"ALTER TABLE `users` RENAME COLUMN `phone` TO `mobile`",
+			},
+		},
+		{
+			name:    "postgres",
+			dialect: &dialect.PostgresDialect{},
+			expected: []string{
+				`ALTER TABLE "users" ADD COLUMN "age" INT NULL`,
+				`ALTER TABLE "users" RENAME COLUMN "phone" TO "mobile"`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+
+			schema := querycraft.NewSchemaBuilder(sqlx.NewDb(db, "sqlmock"), tt.dialect)
+			for _, query := range tt.expected {
+				mock.ExpectExec(regexp.QuoteMeta(query)).WillReturnResult(sqlmock.NewResult(0, 0))
+			}
+
+			err = schema.AlterTable("users", func(table querycraft.TableBuilder) {
+				table.Integer("age").Nullable()
+				table.RenameColumn("phone", "mobile")
+			})
+
+			assert.NoError(t, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSchemaBuilder_IfNotExistsAndIfExists(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+
+	schema := querycraft.NewSchemaBuilder(sqlx.NewDb(db, "sqlmock"), &dialect.MySQLDialect{})
+
+	mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE IF NOT EXISTS `users` (`name` VARCHAR(255) NOT NULL)")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("DROP TABLE IF EXISTS `users`")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = schema.CreateTableIfNotExists("users", func(table querycraft.TableBuilder) {
+		table.String("name").NotNull()
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, schema.DropTableIfExists("users"))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSchemaBuilder_GetForeignKeys(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	schema := querycraft.NewSchemaBuilder(sqlx.NewDb(db, "sqlmock"), &dialect.MySQLDialect{})
+
+	columns := []string{"constraint_name", "column_name", "referenced_table_name", "referenced_column_name", "delete_rule", "update_rule"}
+	mock.ExpectQuery("FROM information_schema.key_column_usage kcu .* kcu.table_name = 'order_items'").
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow("items_order_fk", "order_id", "orders", "id", "CASCADE", "NO ACTION").
+			AddRow("items_product_fk", "product_id", "products", "id", "SET NULL", "CASCADE"))
+
+	keys, err := schema.GetForeignKeys("order_items")
+	assert.NoError(t, err)
+	assert.Equal(t, []querycraft.ForeignKeyInfo{
+		{Name: "items_order_fk", Column: "order_id", RefTable: "orders", RefColumn: "id", OnDelete: "CASCADE", OnUpdate: "NO ACTION"},
+		{Name: "items_product_fk", Column: "product_id", RefTable: "products", RefColumn: "id", OnDelete: "SET NULL", OnUpdate: "CASCADE"},
+	}, keys)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSchemaBuilder_GetForeignKeysPostgres(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	schema := querycraft.NewSchemaBuilder(sqlx.NewDb(db, "sqlmock"), &dialect.PostgresDialect{})
+
+	columns := []string{"conname", "attname", "relname", "attname", "on_delete", "on_update"}
+	mock.ExpectQuery("FROM pg_constraint c .* t.relname = 'order_items' AND c.contype = 'f'").
+		WillReturnRows(sqlmock.NewRows(columns).AddRow("items_order_fk", "order_id", "orders", "id", "RESTRICT", "NO ACTION"))
+
+	keys, err := schema.GetForeignKeys("order_items")
+	assert.NoError(t, err)
+	assert.Len(t, keys, 1)
+	assert.Equal(t, "RESTRICT", keys[0].OnDelete)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSchemaBuilder_ForeignKeyActionConstants(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+
+	schema := querycraft.NewSchemaBuilder(sqlx.NewDb(db, "sqlmock"), &dialect.PostgresDialect{})
+
+	expectedSQL := regexp.QuoteMeta(`CREATE TABLE "comments" ("post_id" BIGINT NULL, "author_id" BIGINT NOT NULL, ` +
+		`CONSTRAINT "comments_post_id_foreign" FOREIGN KEY ("post_id") REFERENCES "posts"("id") ON DELETE SET NULL ON UPDATE CASCADE, ` +
+		`CONSTRAINT "comments_author_id_foreign" FOREIGN KEY ("author_id") REFERENCES "users"("id") ON DELETE NO ACTION)`)
+	mock.ExpectExec(expectedSQL).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = schema.CreateTable("comments", func(table querycraft.TableBuilder) {
+		table.BigInteger("post_id").Nullable()
+		table.BigInteger("author_id").NotNull()
+		table.ForeignKey("post_id", "posts", "id").OnDelete(querycraft.SetNullAction).OnUpdate(querycraft.CascadeAction)
+		table.ForeignKey("author_id", "users", "id").OnDelete(querycraft.NoActionAction)
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSchemaBuilder_CheckConstraints(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+
+	schema := querycraft.NewSchemaBuilder(sqlx.NewDb(db, "sqlmock"), &dialect.MySQLDialect{})
+
+	mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE `items` (`qty` INT NOT NULL, CONSTRAINT `items_qty_check` CHECK (qty > 0))")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("ALTER TABLE `items` ADD CONSTRAINT `items_qty_max` CHECK (qty < 1000), DROP CHECK `items_qty_check`")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = schema.CreateTable("items", func(table querycraft.TableBuilder) {
+		table.Integer("qty").NotNull()
+		table.Check("items_qty_check", "qty > 0")
+	})
+	assert.NoError(t, err)
+
+	err = schema.AlterTable("items", func(table querycraft.TableBuilder) {
+		table.Check("items_qty_max", "qty < 1000")
+		table.DropCheck("items_qty_check")
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSchemaBuilder_CheckConstraintsNotSupported(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	// SQLite принимает CHECK только в CREATE TABLE
+	sqlite := querycraft.NewSchemaBuilder(sqlxDB, &dialect.SQLiteDialect{})
+	mock.ExpectExec(regexp.QuoteMeta(`CREATE TABLE "items" ("qty" INT NOT NULL, CONSTRAINT "items_qty_check" CHECK (qty > 0))`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = sqlite.CreateTable("items", func(table querycraft.TableBuilder) {
+		table.Integer("qty").NotNull()
+		table.Check("items_qty_check", "qty > 0")
+	})
+	assert.NoError(t, err)
+
+	err = sqlite.AlterTable("items", func(table querycraft.TableBuilder) {
+		table.Check("items_qty_max", "qty < 1000")
+	})
+	assert.ErrorIs(t, err, querycraft.ErrNotSupported)
+
+	err = sqlite.AlterTable("items", func(table querycraft.TableBuilder) {
+		table.DropCheck("items_qty_check")
+	})
+	assert.ErrorIs(t, err, querycraft.ErrNotSupported)
+
+	// MySQL до 8.0.16 не проверяет CHECK, ограничение не пропускается молча
+	legacy := querycraft.NewSchemaBuilder(sqlxDB, &dialect.MySQLDialect{LegacyChecks: true})
+	err = legacy.CreateTable("items", func(table querycraft.TableBuilder) {
+		table.Integer("qty").NotNull()
+		table.Check("items_qty_check", "qty > 0")
+	})
+	assert.ErrorIs(t, err, querycraft.ErrNotSupported)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSchemaBuilder_DropCheckPostgres(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+
+	schema := querycraft.NewSchemaBuilder(sqlx.NewDb(db, "sqlmock"), &dialect.PostgresDialect{})
+
+	mock.ExpectExec(regexp.QuoteMeta(`ALTER TABLE "items" DROP CONSTRAINT "items_qty_check"`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = schema.AlterTable("items", func(table querycraft.TableBuilder) {
+		table.DropCheck("items_qty_check")
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
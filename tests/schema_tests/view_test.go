@@ -0,0 +1,71 @@
+package schema_tests
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/antibomberman/querycraft"
+	"github.com/antibomberman/querycraft/dialect"
+)
+
+func TestSchemaBuilder_CreateView(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	d := &dialect.MySQLDialect{}
+	schema := querycraft.NewSchemaBuilder(sqlxDB, d)
+	query := querycraft.NewSelectBuilder(sqlxDB, d, "id", "name").From("users").WhereRaw("active = 1")
+
+	mock.ExpectExec(regexp.QuoteMeta("CREATE VIEW `active_users` AS SELECT `id`, `name` FROM `users` WHERE active = 1")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("CREATE OR REPLACE VIEW `active_users` AS SELECT `id`, `name` FROM `users` WHERE active = 1")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta(d.HasViewQuery("active_users"))).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectExec(regexp.QuoteMeta("DROP VIEW `active_users`")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	assert.NoError(t, schema.CreateView("active_users", query))
+	assert.NoError(t, schema.CreateOrReplaceView("active_users", query))
+
+	exists, err := schema.HasView("active_users")
+	assert.NoError(t, err)
+	assert.True(t, exists)
+
+	assert.NoError(t, schema.DropView("active_users"))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSchemaBuilder_CreateViewRejectsArgs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	d := &dialect.MySQLDialect{}
+	schema := querycraft.NewSchemaBuilder(sqlxDB, d)
+	query := querycraft.NewSelectBuilder(sqlxDB, d, "id").From("users").Where("active", "=", 1)
+
+	assert.Error(t, schema.CreateView("active_users", query))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSchemaBuilder_CreateOrReplaceViewSQLite(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	d := &dialect.SQLiteDialect{}
+	schema := querycraft.NewSchemaBuilder(sqlxDB, d)
+	query := querycraft.NewSelectBuilder(sqlxDB, d, "id").From("users")
+
+	mock.ExpectExec(regexp.QuoteMeta(`DROP VIEW IF EXISTS "user_ids"`)).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(`CREATE VIEW "user_ids" AS SELECT "id" FROM "users"`)).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	assert.NoError(t, schema.CreateOrReplaceView("user_ids", query))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
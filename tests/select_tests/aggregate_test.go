@@ -1,8 +1,12 @@
 package select_tests
 
 import (
+	"regexp"
 	"testing"
 
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+
 	. "github.com/antibomberman/querycraft"
 	"github.com/antibomberman/querycraft/dialect"
 	"github.com/antibomberman/querycraft/tests/test_utils"
@@ -82,3 +86,34 @@ func TestMin(t *testing.T) {
 		Min(string) (any, error)
 	})
 }
+
+func TestAggregatesSingleQuery(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	builder := NewSelectBuilder(sqlx.NewDb(db, "sqlmock"), &dialect.MySQLDialect{}).
+		From("orders").
+		Where("status", "=", "paid").
+		OrderBy("created_at").
+		Limit(10)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) AS `total`, SUM(`amount`) AS `revenue`, AVG(`rating`) AS `rating`, MAX(`amount`) AS `largest` FROM `orders` WHERE `status` = ?")).
+		WithArgs("paid").
+		WillReturnRows(sqlmock.NewRows([]string{"total", "revenue", "rating", "largest"}).
+			AddRow(int64(3), "150.50", 4.5, nil))
+
+	result, err := builder.Aggregates().
+		Count("total").
+		Sum("amount", "revenue").
+		Avg("rating", "rating").
+		Max("amount", "largest").
+		Exec()
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), result.Int64("total"))
+	assert.Equal(t, 150.5, result.Float64("revenue"))
+	assert.Equal(t, 4.5, result.Float64("rating"))
+	assert.Nil(t, result.Get("largest"))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,52 @@
+package select_tests
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/antibomberman/querycraft"
+	"github.com/antibomberman/querycraft/dialect"
+)
+
+func TestBenchmark(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	builder := querycraft.NewSelectBuilder(sqlx.NewDb(db, "sqlmock"), &dialect.MySQLDialect{}, "id").
+		From("users").
+		Where("active", "=", 1)
+
+	for i := 0; i < 3; i++ {
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT `id` FROM `users` WHERE `active` = ?")).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+	}
+
+	result, err := builder.Benchmark(3)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, result.Runs)
+	assert.True(t, result.Min <= result.Median && result.Median <= result.Max)
+	assert.True(t, result.Min <= result.Mean && result.Mean <= result.Max)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestBenchmarkContextCancelled(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	builder := querycraft.NewSelectBuilder(sqlx.NewDb(db, "sqlmock"), &dialect.MySQLDialect{}, "id").From("users")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = builder.BenchmarkContext(ctx, 5)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
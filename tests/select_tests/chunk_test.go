@@ -0,0 +1,116 @@
+package select_tests
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChunkById(t *testing.T) {
+	builder, mock, closeDB := newExecSelect(t, "id", "name")
+	defer closeDB()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT `id`, `name` FROM `users` WHERE `active` = ? ORDER BY `id` LIMIT 3")).
+		WithArgs(true).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).
+			AddRow(1, "a").AddRow(2, "b").AddRow(3, "c"))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT `id`, `name` FROM `users` WHERE `active` = ? AND `id` > ? ORDER BY `id` LIMIT 3")).
+		WithArgs(true, int64(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(3, "c"))
+
+	var sizes []int
+	err := builder.From("users").Where("active", "=", true).OrderByDesc("name").
+		ChunkById(2, "id", func(rows []map[string]any) error {
+			sizes = append(sizes, len(rows))
+			return nil
+		})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2, 1}, sizes)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestChunkByIdEmpty(t *testing.T) {
+	builder, mock, closeDB := newExecSelect(t, "id")
+	defer closeDB()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT `id` FROM `users` ORDER BY `id` LIMIT 11")).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	calls := 0
+	err := builder.From("users").ChunkById(10, "id", func(rows []map[string]any) error {
+		calls++
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, calls)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestChunkByIdStopsOnError(t *testing.T) {
+	builder, mock, closeDB := newExecSelect(t, "id")
+	defer closeDB()
+
+	mock.ExpectQuery("SELECT `id` FROM `users`").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+
+	stop := errors.New("stop")
+	err := builder.From("users").ChunkById(1, "id", func(rows []map[string]any) error {
+		return stop
+	})
+
+	assert.ErrorIs(t, err, stop)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestChunkByIdValidation(t *testing.T) {
+	builder, _, closeDB := newExecSelect(t, "id")
+	defer closeDB()
+
+	noop := func(rows []map[string]any) error { return nil }
+
+	assert.Error(t, builder.From("users").ChunkById(0, "id", noop))
+	assert.Error(t, builder.From("users").ChunkById(10, "", noop))
+
+	union := builder.Clone().From("users").Union(builder.Clone().From("admins"))
+	assert.Error(t, union.ChunkById(10, "id", noop))
+}
+
+func TestChunkByIdMissingColumn(t *testing.T) {
+	builder, mock, closeDB := newExecSelect(t, "name")
+	defer closeDB()
+
+	mock.ExpectQuery("SELECT `name` FROM `users`").
+		WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("a").AddRow("b"))
+
+	err := builder.From("users").ChunkById(1, "id", func(rows []map[string]any) error { return nil })
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+}
+
+func TestChunkByIdGroupsOrWhere(t *testing.T) {
+	builder, mock, closeDB := newExecSelect(t, "id")
+	defer closeDB()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT `id` FROM `users` WHERE `role` = ? OR `role` = ? ORDER BY `id` LIMIT 2")).
+		WithArgs("admin", "editor").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT `id` FROM `users` WHERE (`role` = ? OR `role` = ?) AND `id` > ? ORDER BY `id` LIMIT 2")).
+		WithArgs("admin", "editor", int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+
+	calls := 0
+	err := builder.From("users").Where("role", "=", "admin").OrWhere("role", "=", "editor").
+		ChunkById(1, "id", func(rows []map[string]any) error {
+			calls++
+			return nil
+		})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
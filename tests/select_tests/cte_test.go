@@ -0,0 +1,105 @@
+package select_tests
+
+import (
+	"testing"
+
+	"github.com/antibomberman/querycraft"
+	"github.com/antibomberman/querycraft/dialect"
+	"github.com/antibomberman/querycraft/tests/test_utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithCTE(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+	d := &dialect.MySQLDialect{}
+
+	active := querycraft.NewSelectBuilder(mockDB, d, "id", "name").From("users").Where("active", "=", true)
+	builder := querycraft.NewSelectBuilder(mockDB, d, "*").
+		With("active_users", active).
+		From("active_users").
+		Where("id", ">", 10)
+	sql, args := builder.ToSQL()
+
+	expectedSQL := "WITH `active_users` AS (SELECT `id`, `name` FROM `users` WHERE `active` = ?) SELECT * FROM `active_users` WHERE `id` > ?"
+	expectedArgs := []any{true, 10}
+
+	assert.Equal(t, expectedSQL, sql)
+	assert.Equal(t, expectedArgs, args)
+}
+
+func TestWithCTEOptionIgnoredOnMySQL(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+	d := &dialect.MySQLDialect{}
+
+	options := []querycraft.CTEOption{querycraft.CTEDefault, querycraft.CTEMaterialize, querycraft.CTENotMaterialize}
+	for _, opt := range options {
+		sub := querycraft.NewSelectBuilder(mockDB, d, "id").From("users")
+		sql, _ := querycraft.NewSelectBuilder(mockDB, d, "*").With("u", sub, opt).From("u").ToSQL()
+
+		assert.Equal(t, "WITH `u` AS (SELECT `id` FROM `users`) SELECT * FROM `u`", sql)
+	}
+}
+
+func TestWithMultipleCTEs(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+	d := &dialect.MySQLDialect{}
+
+	paid := querycraft.NewSelectBuilder(mockDB, d, "user_id", "SUM(total) as total").
+		From("orders").
+		Where("status", "=", "paid").
+		GroupBy("user_id")
+	vip := querycraft.NewSelectBuilder(mockDB, d, "user_id").
+		From("paid").
+		Where("total", ">", 1000)
+
+	sql, args := querycraft.NewSelectBuilder(mockDB, d, "u.name").
+		With("paid", paid).
+		With("vip", vip).
+		WithSchema("shop").
+		From("users u").
+		Join("vip", "vip.user_id = u.id").
+		Where("u.active", "=", true).
+		ToSQL()
+
+	// Имена CTE не получают схему, аргументы CTE идут первыми в порядке объявления
+	expectedSQL := "WITH `paid` AS (SELECT `user_id`, SUM(total) as total FROM `orders` WHERE `status` = ? GROUP BY `user_id`), " +
+		"`vip` AS (SELECT `user_id` FROM `paid` WHERE `total` > ?) " +
+		"SELECT `u`.`name` FROM `shop`.`users` as u INNER JOIN `vip` ON `vip`.`user_id` = `u`.`id` WHERE `u`.`active` = ?"
+	assert.Equal(t, expectedSQL, sql)
+	assert.Equal(t, []any{"paid", 1000, true}, args)
+}
+
+func TestWithRecursive(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+	d := &dialect.PostgresDialect{}
+
+	tree := querycraft.NewSelectBuilder(mockDB, d, "id", "parent_id").
+		From("categories").
+		Where("id", "=", 1).
+		UnionAll(querycraft.NewSelectBuilder(mockDB, d, "c.id", "c.parent_id").
+			From("categories c").
+			Join("tree t", "c.parent_id = t.id"))
+
+	sql, args := querycraft.NewSelectBuilder(mockDB, d, "id").
+		WithRecursive("tree", tree).
+		From("tree").
+		ToSQL()
+
+	expectedSQL := `WITH RECURSIVE "tree" AS (SELECT "id", "parent_id" FROM "categories" WHERE "id" = $1 UNION ALL ` +
+		`SELECT "c"."id", "c"."parent_id" FROM "categories" as c INNER JOIN "tree" as t ON "c"."parent_id" = "t"."id") SELECT "id" FROM "tree"`
+	assert.Equal(t, expectedSQL, sql)
+	assert.Equal(t, []any{1}, args)
+}
+
+func TestWithPropagatesSubqueryError(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+	d := &dialect.MySQLDialect{}
+
+	// DISTINCT ON не поддерживается MySQL, ошибка подзапроса переходит в основной запрос
+	latest := querycraft.NewSelectBuilder(mockDB, d, "user_id").From("orders").DistinctOn("user_id")
+	builder := querycraft.NewSelectBuilder(mockDB, d, "*").
+		With("latest", latest).
+		From("latest")
+
+	assert.ErrorIs(t, builder.Err(), querycraft.ErrNotSupported)
+}
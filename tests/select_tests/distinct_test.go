@@ -0,0 +1,48 @@
+package select_tests
+
+import (
+	"testing"
+
+	"github.com/antibomberman/querycraft"
+	"github.com/antibomberman/querycraft/dialect"
+	"github.com/antibomberman/querycraft/tests/test_utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDistinct(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+	sql, args := querycraft.NewSelectBuilder(mockDB, &dialect.MySQLDialect{}, "age").
+		Distinct().
+		From("users").
+		Where("active", "=", true).
+		ToSQL()
+
+	assert.Equal(t, "SELECT DISTINCT `age` FROM `users` WHERE `active` = ?", sql)
+	assert.Equal(t, []any{true}, args)
+}
+
+func TestDistinctOnPostgres(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+	builder := querycraft.NewSelectBuilder(mockDB, &dialect.PostgresDialect{}, "user_id", "total").
+		DistinctOn("user_id").
+		From("orders").
+		OrderBy("user_id").
+		OrderByDesc("created_at")
+
+	sql, _ := builder.ToSQL()
+
+	assert.NoError(t, builder.Err())
+	assert.Equal(t, `SELECT DISTINCT ON ("user_id") "user_id", "total" FROM "orders" ORDER BY "user_id", "created_at" DESC`, sql)
+}
+
+func TestDistinctOnNotSupportedMySQL(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+	builder := querycraft.NewSelectBuilder(mockDB, &dialect.MySQLDialect{}, "user_id").
+		DistinctOn("user_id").
+		From("orders")
+
+	assert.ErrorIs(t, builder.Err(), querycraft.ErrNotSupported)
+
+	_, err := builder.Rows()
+	assert.ErrorIs(t, err, querycraft.ErrNotSupported)
+}
@@ -0,0 +1,193 @@
+package select_tests
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/antibomberman/querycraft"
+	"github.com/antibomberman/querycraft/dialect"
+)
+
+type execUser struct {
+	ID   int64  `db:"id"`
+	Name string `db:"name"`
+}
+
+func newExecSelect(t *testing.T, columns ...string) (querycraft.SelectBuilder, sqlmock.Sqlmock, func()) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	builder := querycraft.NewSelectBuilder(sqlx.NewDb(db, "sqlmock"), &dialect.MySQLDialect{}, columns...)
+	return builder, mock, func() { db.Close() }
+}
+
+func TestSelectOneExec(t *testing.T) {
+	builder, mock, closeDB := newExecSelect(t, "id", "name")
+	defer closeDB()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT `id`, `name` FROM `users` WHERE `id` = ? LIMIT 1")).
+		WithArgs(7).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(7, "John"))
+
+	var user execUser
+	err := builder.From("users").Where("id", "=", 7).Limit(1).One(&user)
+	assert.NoError(t, err)
+	assert.Equal(t, execUser{ID: 7, Name: "John"}, user)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSelectAllExec(t *testing.T) {
+	builder, mock, closeDB := newExecSelect(t, "id", "name")
+	defer closeDB()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT `id`, `name` FROM `users` WHERE `active` = ? ORDER BY `id`")).
+		WithArgs(true).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "John").AddRow(2, "Jane"))
+
+	var users []execUser
+	err := builder.From("users").Where("active", "=", true).OrderBy("id").All(&users)
+	assert.NoError(t, err)
+	assert.Equal(t, []execUser{{ID: 1, Name: "John"}, {ID: 2, Name: "Jane"}}, users)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSelectCountExec(t *testing.T) {
+	builder, mock, closeDB := newExecSelect(t)
+	defer closeDB()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) as count FROM `users` WHERE `active` = ?")).
+		WithArgs(true).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(42))
+
+	count, err := builder.From("users").Where("active", "=", true).Count()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(42), count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSelectPaginateExec(t *testing.T) {
+	builder, mock, closeDB := newExecSelect(t, "id", "name")
+	defer closeDB()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) as count FROM `users` WHERE `active` = ?")).
+		WithArgs(true).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(5))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT `id`, `name` FROM `users` WHERE `active` = ? LIMIT 2 OFFSET 2")).
+		WithArgs(true).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(3, "Ann").AddRow(4, "Bob"))
+
+	result, err := builder.From("users").Where("active", "=", true).Paginate(2, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), result.Total)
+	assert.Equal(t, 2, result.CurrentPage)
+	assert.Equal(t, 3, result.LastPage)
+	assert.Equal(t, 3, result.From)
+	assert.Equal(t, 4, result.To)
+	assert.Len(t, result.Data, 2)
+	assert.Equal(t, "Ann", result.Data[0]["name"])
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSelectFromPartitionExec(t *testing.T) {
+	builder, mock, closeDB := newExecSelect(t, "id", "name")
+	defer closeDB()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT `id`, `name` FROM `users` PARTITION (`p0`, `p1`) WHERE `id` > ?")).
+		WithArgs(0).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "John").AddRow(2, "Jane"))
+
+	var users []execUser
+	err := builder.From("users").Partition("p0", "p1").Where("id", ">", 0).All(&users)
+	assert.NoError(t, err)
+	assert.Len(t, users, 2)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSelectRawArgsDroppedByCount(t *testing.T) {
+	builder, mock, closeDB := newExecSelect(t, "id")
+	defer closeDB()
+
+	builder.From("products").SelectRaw("price * ? AS total", 1.2).Where("active", "=", 1)
+
+	// Аргументы выражений не должны попадать в COUNT без своих колонок
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) as count FROM `products` WHERE `active` = ?")).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	count, err := builder.Count()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+
+	// Колонки и аргументы восстанавливаются после Count
+	sql, args := builder.ToSQL()
+	assert.Equal(t, "SELECT `id`, price * ? AS total FROM `products` WHERE `active` = ?", sql)
+	assert.Equal(t, []any{1.2, 1}, args)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSelectRawArgsWithPaginate(t *testing.T) {
+	builder, mock, closeDB := newExecSelect(t, "id")
+	defer closeDB()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) as count FROM `products` WHERE `active` = ?")).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT `id`, price * ? AS total FROM `products` WHERE `active` = ? LIMIT 10 OFFSET 0")).
+		WithArgs(1.2, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "total"}).AddRow(1, 12))
+
+	result, err := builder.From("products").SelectRaw("price * ? AS total", 1.2).Where("active", "=", 1).Paginate(1, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), result.Total)
+	assert.Len(t, result.Data, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSelectRawArgsDroppedByPluck(t *testing.T) {
+	builder, mock, closeDB := newExecSelect(t, "id")
+	defer closeDB()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT `name` FROM `products` WHERE `active` = ?")).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("apple"))
+
+	names, err := builder.From("products").SelectRaw("price * ? AS total", 1.2).Where("active", "=", 1).Pluck("name")
+	assert.NoError(t, err)
+	assert.Equal(t, []any{"apple"}, names)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSelectCountDistinctExec(t *testing.T) {
+	builder, mock, closeDB := newExecSelect(t, "city")
+	defer closeDB()
+
+	// DISTINCT применяется к строкам подзапроса, а не к COUNT(*)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) as count FROM (SELECT DISTINCT `city` FROM `users` WHERE `active` = ? LIMIT 2147483647) AS _count")).
+		WithArgs(true).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	count, err := builder.Distinct().From("users").Where("active", "=", true).Count()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSelectPaginateDistinctExec(t *testing.T) {
+	builder, mock, closeDB := newExecSelect(t, "city")
+	defer closeDB()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) as count FROM (SELECT DISTINCT `city` FROM `users` LIMIT 2147483647) AS _count")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT DISTINCT `city` FROM `users` ORDER BY `city` LIMIT 2 OFFSET 2")).
+		WillReturnRows(sqlmock.NewRows([]string{"city"}).AddRow("Oslo"))
+
+	result, err := builder.Distinct().From("users").OrderBy("city").Paginate(2, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), result.Total)
+	assert.Equal(t, 2, result.LastPage)
+	assert.Len(t, result.Data, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
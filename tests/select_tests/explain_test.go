@@ -0,0 +1,81 @@
+package select_tests
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/antibomberman/querycraft"
+	"github.com/antibomberman/querycraft/dialect"
+)
+
+func TestExplainFormatJSONMySQL(t *testing.T) {
+	builder, mock, closeDB := newExecSelect(t, "id")
+	defer closeDB()
+
+	mock.ExpectQuery(regexp.QuoteMeta("EXPLAIN FORMAT=JSON SELECT `id` FROM `users` WHERE `id` = ?")).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"EXPLAIN"}).
+			AddRow(`{"query_block": {"select_id": 1, "table": {"table_name": "users", "access_type": "const"}}}`))
+
+	rows, err := builder.From("users").Where("id", "=", 1).ExplainFormat(dialect.ExplainFormatJSON)
+	assert.NoError(t, err)
+	assert.Len(t, rows, 1)
+
+	plan, ok := rows[0]["Plan"].(map[string]any)
+	assert.True(t, ok)
+	block := plan["query_block"].(map[string]any)
+	assert.Equal(t, float64(1), block["select_id"])
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExplainAnalyzeMySQL(t *testing.T) {
+	builder, mock, closeDB := newExecSelect(t, "id")
+	defer closeDB()
+
+	mock.ExpectQuery(regexp.QuoteMeta("EXPLAIN ANALYZE FORMAT=JSON SELECT `id` FROM `users`")).
+		WillReturnRows(sqlmock.NewRows([]string{"EXPLAIN"}).AddRow(`{"query": "users", "actual_rows": 3}`))
+
+	rows, err := builder.From("users").ExplainAnalyze()
+	assert.NoError(t, err)
+	assert.Equal(t, float64(3), rows[0]["Plan"].(map[string]any)["actual_rows"])
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExplainAnalyzePostgres(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`EXPLAIN ANALYZE SELECT * FROM "users" WHERE "id" = $1`)).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"QUERY PLAN"}).
+			AddRow("Index Scan using users_pkey on users (actual time=0.010..0.011 rows=1 loops=1)"))
+
+	rows, err := querycraft.NewSelectBuilder(sqlx.NewDb(db, "sqlmock"), &dialect.PostgresDialect{}, "*").
+		From("users").Where("id", "=", 1).ExplainAnalyze()
+	assert.NoError(t, err)
+	assert.Len(t, rows, 1)
+	// Текстовый план не разбирается
+	assert.NotContains(t, rows[0], "Plan")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExplainFormatJSONPostgres(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`EXPLAIN (FORMAT JSON) SELECT * FROM "users"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"QUERY PLAN"}).AddRow([]byte(`[{"Plan": {"Node Type": "Seq Scan"}}]`)))
+
+	rows, err := querycraft.NewSelectBuilder(sqlx.NewDb(db, "sqlmock"), &dialect.PostgresDialect{}, "*").
+		From("users").ExplainFormat(dialect.ExplainFormatJSON)
+	assert.NoError(t, err)
+	plans := rows[0]["Plan"].([]any)
+	assert.Equal(t, "Seq Scan", plans[0].(map[string]any)["Plan"].(map[string]any)["Node Type"])
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,78 @@
+package select_tests
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFieldInt64(t *testing.T) {
+	builder, mock, closeDB := newExecSelect(t)
+	defer closeDB()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT `age` FROM `users` WHERE `id` = ?")).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"age"}).AddRow([]byte("42")))
+
+	age, err := builder.From("users").Where("id", "=", 1).FieldInt64("age")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(42), age)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFieldString(t *testing.T) {
+	builder, mock, closeDB := newExecSelect(t)
+	defer closeDB()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT `name` FROM `users` WHERE `id` = ?")).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow([]byte("John")))
+
+	name, err := builder.From("users").Where("id", "=", 1).FieldString("name")
+	assert.NoError(t, err)
+	assert.Equal(t, "John", name)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFieldFloat64(t *testing.T) {
+	builder, mock, closeDB := newExecSelect(t)
+	defer closeDB()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT `price` FROM `products` WHERE `id` = ?")).
+		WithArgs(3).
+		WillReturnRows(sqlmock.NewRows([]string{"price"}).AddRow("19.5"))
+
+	price, err := builder.From("products").Where("id", "=", 3).FieldFloat64("price")
+	assert.NoError(t, err)
+	assert.Equal(t, 19.5, price)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFieldBool(t *testing.T) {
+	builder, mock, closeDB := newExecSelect(t)
+	defer closeDB()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT `active` FROM `users` WHERE `id` = ?")).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"active"}).AddRow(int64(1)))
+
+	active, err := builder.From("users").Where("id", "=", 1).FieldBool("active")
+	assert.NoError(t, err)
+	assert.True(t, active)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFieldInt64ConversionError(t *testing.T) {
+	builder, mock, closeDB := newExecSelect(t)
+	defer closeDB()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT `name` FROM `users`")).
+		WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("John"))
+
+	value, err := builder.From("users").FieldInt64("name")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "column name")
+	assert.Equal(t, int64(0), value)
+}
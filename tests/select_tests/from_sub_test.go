@@ -0,0 +1,46 @@
+package select_tests
+
+import (
+	"testing"
+
+	"github.com/antibomberman/querycraft"
+	"github.com/antibomberman/querycraft/dialect"
+	"github.com/antibomberman/querycraft/tests/test_utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromSub(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+	d := &dialect.MySQLDialect{}
+
+	totals := querycraft.NewSelectBuilder(mockDB, d, "user_id").
+		SelectRaw("SUM(amount) AS total").
+		From("orders").
+		Where("status", "=", "paid").
+		GroupBy("user_id")
+
+	sql, args := querycraft.NewSelectBuilder(mockDB, d, "u.name", "t.total").
+		FromSub(totals, "t").
+		Join("users u", "u.id = t.user_id").
+		Where("t.total", ">", 100).
+		ToSQL()
+
+	assert.Equal(t, "SELECT `u`.`name`, `t`.`total` FROM (SELECT `user_id`, SUM(amount) AS total FROM `orders` WHERE `status` = ? GROUP BY `user_id`) AS `t` "+
+		"INNER JOIN `users` as u ON `u`.`id` = `t`.`user_id` WHERE `t`.`total` > ?", sql)
+	assert.Equal(t, []any{"paid", 100}, args)
+}
+
+func TestFromSubPostgres(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+	d := &dialect.PostgresDialect{}
+
+	recent := querycraft.NewSelectBuilder(mockDB, d, "*").From("events").Where("created_at", ">", "2024-01-01")
+
+	sql, args := querycraft.NewSelectBuilder(mockDB, d, "*").
+		FromSub(recent, "e").
+		Where("e.kind", "=", "login").
+		ToSQL()
+
+	assert.Equal(t, `SELECT * FROM (SELECT * FROM "events" WHERE "created_at" > $1) AS "e" WHERE "e"."kind" = $2`, sql)
+	assert.Equal(t, []any{"2024-01-01", "login"}, args)
+}
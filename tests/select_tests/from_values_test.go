@@ -0,0 +1,40 @@
+package select_tests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/antibomberman/querycraft"
+	"github.com/antibomberman/querycraft/dialect"
+)
+
+func TestFromValuesPostgres(t *testing.T) {
+	rows := []map[string]any{
+		{"id": 1, "name": "a"},
+		{"id": 2, "name": "b"},
+	}
+
+	query, args := querycraft.NewSelectBuilder(nil, &dialect.PostgresDialect{}, "t.id", "u.email").
+		FromValues(rows, "t").
+		Join("users u", "u.id = t.id").
+		Where("u.active", "=", true).
+		ToSQL()
+
+	assert.Equal(t, `SELECT "t"."id", "u"."email" FROM (VALUES ($1, $2), ($3, $4)) AS "t"("id", "name") INNER JOIN "users" as u ON "u"."id" = "t"."id" WHERE "u"."active" = $5`, query)
+	assert.Equal(t, []any{1, "a", 2, "b", true}, args)
+}
+
+func TestFromValuesMySQL(t *testing.T) {
+	rows := []map[string]any{
+		{"name": "a", "id": 1},
+		{"id": 2},
+	}
+
+	query, args := querycraft.NewSelectBuilder(nil, &dialect.MySQLDialect{}).
+		FromValues(rows, "t").
+		ToSQL()
+
+	assert.Equal(t, "SELECT * FROM (SELECT ? AS `id`, ? AS `name` UNION ALL SELECT ?, ?) AS `t`", query)
+	assert.Equal(t, []any{1, "a", 2, nil}, args)
+}
@@ -0,0 +1,110 @@
+package select_tests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/antibomberman/querycraft"
+	"github.com/antibomberman/querycraft/dialect"
+)
+
+func TestForEachGrouped(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT `user_id`, `total` FROM `orders`").
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "total"}).
+			AddRow(2, 10).
+			AddRow(1, 20).
+			AddRow(2, 30))
+
+	var keys []any
+	var sizes []int
+	err = querycraft.NewSelectBuilder(sqlx.NewDb(db, "sqlmock"), &dialect.MySQLDialect{}, "user_id", "total").
+		From("orders").
+		ForEachGrouped("user_id", func(key any, rows []map[string]any) error {
+			keys = append(keys, key)
+			sizes = append(sizes, len(rows))
+			return nil
+		})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []any{int64(2), int64(1)}, keys)
+	assert.Equal(t, []int{2, 1}, sizes)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestForEachGroupedStopsOnError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT").
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(1).AddRow(2))
+
+	stop := errors.New("stop")
+	calls := 0
+	err = querycraft.NewSelectBuilder(sqlx.NewDb(db, "sqlmock"), &dialect.MySQLDialect{}, "user_id").
+		From("orders").
+		ForEachGrouped("user_id", func(key any, rows []map[string]any) error {
+			calls++
+			return stop
+		})
+
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, 1, calls)
+}
+
+func TestForEachGroupedChunk(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	// Группа user_id=2 разбита между порциями
+	mock.ExpectQuery("SELECT `o`.`user_id`, `o`.`total` FROM `orders` as o ORDER BY `o`.`user_id`, `o`.`id` LIMIT 2$").
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "total"}).AddRow(1, 10).AddRow(2, 20))
+	mock.ExpectQuery("LIMIT 2 OFFSET 2").
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "total"}).AddRow(2, 30).AddRow(3, 40))
+	mock.ExpectQuery("LIMIT 2 OFFSET 4").
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "total"}))
+
+	groups := map[int64]int{}
+	var order []int64
+	err = querycraft.NewSelectBuilder(sqlx.NewDb(db, "sqlmock"), &dialect.MySQLDialect{}, "o.user_id", "o.total").
+		From("orders as o").
+		OrderByDesc("o.total").
+		ForEachGroupedChunk("o.user_id", "o.id", 2, func(key any, rows []map[string]any) error {
+			order = append(order, key.(int64))
+			groups[key.(int64)] = len(rows)
+			return nil
+		})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1, 2, 3}, order)
+	assert.Equal(t, map[int64]int{1: 1, 2: 2, 3: 1}, groups)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestForEachGroupedChunkInvalidSize(t *testing.T) {
+	builder := querycraft.NewSelectBuilder(nil, &dialect.MySQLDialect{}, "id").From("orders")
+	err := builder.ForEachGroupedChunk("user_id", "id", 0, func(key any, rows []map[string]any) error { return nil })
+	assert.Error(t, err)
+
+	err = builder.ForEachGroupedChunk("user_id", "", 2, func(key any, rows []map[string]any) error { return nil })
+	assert.Error(t, err)
+}
+
+func TestForEachGroupedChunkRejectsLimit(t *testing.T) {
+	builder := querycraft.NewSelectBuilder(nil, &dialect.MySQLDialect{}, "user_id").From("orders").Limit(10)
+	err := builder.ForEachGroupedChunk("user_id", "id", 2, func(key any, rows []map[string]any) error { return nil })
+	assert.ErrorContains(t, err, "Limit/Offset")
+
+	builder = querycraft.NewSelectBuilder(nil, &dialect.MySQLDialect{}, "user_id").From("orders").Offset(5)
+	err = builder.ForEachGroupedChunk("user_id", "id", 2, func(key any, rows []map[string]any) error { return nil })
+	assert.ErrorContains(t, err, "Limit/Offset")
+}
@@ -0,0 +1,94 @@
+package select_tests
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/antibomberman/querycraft"
+)
+
+func TestIterate(t *testing.T) {
+	builder, mock, closeDB := newExecSelect(t, "id", "name")
+	defer closeDB()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT `id`, `name` FROM `users` ORDER BY `id`")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).
+			AddRow(1, []byte("John")).
+			AddRow(2, []byte("Jane")))
+
+	var names []any
+	err := builder.From("users").OrderBy("id").Iterate(func(row map[string]any) error {
+		names = append(names, row["name"])
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []any{"John", "Jane"}, names)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIterateStopIteration(t *testing.T) {
+	builder, mock, closeDB := newExecSelect(t, "id")
+	defer closeDB()
+
+	mock.ExpectQuery("SELECT `id` FROM `users`").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2).AddRow(3)).
+		RowsWillBeClosed()
+
+	calls := 0
+	err := builder.From("users").Iterate(func(row map[string]any) error {
+		calls++
+		if calls == 2 {
+			return querycraft.ErrStopIteration
+		}
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIterateReturnsCallbackError(t *testing.T) {
+	builder, mock, closeDB := newExecSelect(t, "id")
+	defer closeDB()
+
+	mock.ExpectQuery("SELECT `id` FROM `users`").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+
+	failed := errors.New("failed")
+	calls := 0
+	err := builder.From("users").Iterate(func(row map[string]any) error {
+		calls++
+		return failed
+	})
+
+	assert.ErrorIs(t, err, failed)
+	assert.Equal(t, 1, calls)
+}
+
+func TestIterateContextCanceled(t *testing.T) {
+	builder, mock, closeDB := newExecSelect(t, "id")
+	defer closeDB()
+
+	mock.ExpectQuery("SELECT `id` FROM `users`").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2).AddRow(3))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	calls := 0
+	err := builder.WithContext(ctx).From("users").Iterate(func(row map[string]any) error {
+		calls++
+		cancel()
+		return nil
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, calls)
+}
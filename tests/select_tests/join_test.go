@@ -93,3 +93,62 @@ func TestOuterJoin(t *testing.T) {
 	assert.Equal(t, expectedSQL, sql)
 	assert.Equal(t, expectedArgs, args)
 }
+
+func TestSelectWithSchema(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+
+	sql, _ := querycraft.NewSelectBuilder(mockDB, &dialect.MySQLDialect{}, "e.id", "u.name").
+		WithSchema("analytics").
+		From("events as e").
+		LeftJoin("users as u", "u.id = e.user_id").
+		Join("billing.accounts a", "a.user_id = u.id").
+		ToSQL()
+
+	assert.Equal(t, "SELECT `e`.`id`, `u`.`name` FROM `analytics`.`events` as e "+
+		"LEFT JOIN `analytics`.`users` as u ON `u`.`id` = `e`.`user_id` "+
+		"INNER JOIN `billing`.`accounts` as a ON `a`.`user_id` = `u`.`id`", sql)
+
+	// Схема может быть задана после From
+	sql, _ = querycraft.NewSelectBuilder(mockDB, &dialect.PostgresDialect{}).
+		From("events").
+		WithSchema("analytics").
+		ToSQL()
+	assert.Equal(t, `SELECT * FROM "analytics"."events"`, sql)
+}
+
+func TestCrossApplyPostgres(t *testing.T) {
+	d := &dialect.PostgresDialect{}
+	latest := querycraft.NewSelectBuilder(nil, d, "o.total").
+		From("orders o").
+		WhereRaw("o.user_id = u.id").
+		Where("o.status", "=", "paid").
+		OrderByDesc("o.created_at").
+		Limit(3)
+
+	query, args := querycraft.NewSelectBuilder(nil, d, "u.name").
+		From("users u").
+		CrossApply(latest, "last_orders").
+		Where("u.active", "=", true).
+		ToSQL()
+
+	assert.Contains(t, query, `JOIN LATERAL (SELECT "o"."total" FROM "orders" as o WHERE o.user_id = u.id AND "o"."status" = $1`)
+	assert.Contains(t, query, `LIMIT 3) AS "last_orders" ON TRUE WHERE "u"."active" = $2`)
+	assert.Equal(t, []any{"paid", true}, args)
+
+	query, _ = querycraft.NewSelectBuilder(nil, d, "u.name").
+		From("users u").
+		OuterApply(querycraft.NewSelectBuilder(nil, d, "id").From("orders"), "o").
+		ToSQL()
+	assert.Contains(t, query, `LEFT JOIN LATERAL (SELECT "id" FROM "orders") AS "o" ON TRUE`)
+}
+
+func TestCrossApplyNotSupportedMySQL(t *testing.T) {
+	d := &dialect.MySQLDialect{}
+	builder := querycraft.NewSelectBuilder(nil, d, "u.name").
+		From("users u").
+		CrossApply(querycraft.NewSelectBuilder(nil, d, "id").From("orders"), "o")
+
+	assert.ErrorIs(t, builder.Err(), querycraft.ErrNotSupported)
+	query, _ := builder.ToSQL()
+	assert.NotContains(t, query, "APPLY")
+}
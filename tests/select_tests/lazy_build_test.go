@@ -0,0 +1,43 @@
+package select_tests
+
+import (
+	"testing"
+
+	. "github.com/antibomberman/querycraft"
+	"github.com/antibomberman/querycraft/dialect"
+	"github.com/antibomberman/querycraft/tests/test_utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLazyBuild(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+
+	// Middleware регистрирует условие до того, как приложение построит запрос
+	builder := NewSelectBuilder(mockDB, &dialect.MySQLDialect{}, "*").
+		LazyBuild(func(q SelectBuilder) SelectBuilder {
+			return q.Where("tenant_id", "=", 7)
+		})
+
+	builder.From("orders").Where("status", "=", "paid")
+
+	sql, args := builder.ToSQL()
+	assert.Equal(t, "SELECT * FROM `orders` WHERE `status` = ? AND `tenant_id` = ?", sql)
+	assert.Equal(t, []any{"paid", 7}, args)
+
+	// Повторный вызов не дублирует условия
+	sql2, args2 := builder.ToSQL()
+	assert.Equal(t, sql, sql2)
+	assert.Equal(t, args, args2)
+}
+
+func TestLazyBuildOrder(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+
+	sql, _ := NewSelectBuilder(mockDB, &dialect.MySQLDialect{}, "*").
+		From("orders").
+		LazyBuild(func(q SelectBuilder) SelectBuilder { return q.Where("a", "=", 1) }).
+		LazyBuild(func(q SelectBuilder) SelectBuilder { return q.Where("b", "=", 2) }).
+		ToSQL()
+
+	assert.Equal(t, "SELECT * FROM `orders` WHERE `a` = ? AND `b` = ?", sql)
+}
@@ -0,0 +1,92 @@
+package select_tests
+
+import (
+	"testing"
+
+	"github.com/antibomberman/querycraft"
+	"github.com/antibomberman/querycraft/dialect"
+	"github.com/antibomberman/querycraft/tests/test_utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForUpdate(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+
+	sql, _ := querycraft.NewSelectBuilder(mockDB, &dialect.MySQLDialect{}, "*").
+		From("accounts").Where("id", "=", 1).Limit(1).ForUpdate().ToSQL()
+	assert.Equal(t, "SELECT * FROM `accounts` WHERE `id` = ? LIMIT 1 FOR UPDATE", sql)
+
+	sql, _ = querycraft.NewSelectBuilder(mockDB, &dialect.PostgresDialect{}, "*").
+		From("accounts").Where("id", "=", 1).ForUpdate().ToSQL()
+	assert.Equal(t, `SELECT * FROM "accounts" WHERE "id" = $1 FOR UPDATE`, sql)
+}
+
+func TestForShare(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+
+	sql, _ := querycraft.NewSelectBuilder(mockDB, &dialect.MySQLDialect{}, "*").
+		From("accounts").Where("id", "=", 1).ForShare().ToSQL()
+	assert.Equal(t, "SELECT * FROM `accounts` WHERE `id` = ? LOCK IN SHARE MODE", sql)
+
+	sql, _ = querycraft.NewSelectBuilder(mockDB, &dialect.PostgresDialect{}, "*").
+		From("accounts").Where("id", "=", 1).Limit(10).Offset(20).ForShare().ToSQL()
+	assert.Equal(t, `SELECT * FROM "accounts" WHERE "id" = $1 LIMIT 10 OFFSET 20 FOR SHARE`, sql)
+}
+
+func TestLockInShareMode(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+
+	sql, _ := querycraft.NewSelectBuilder(mockDB, &dialect.MySQLDialect{}, "*").
+		From("accounts").LockInShareMode().ToSQL()
+	assert.Equal(t, "SELECT * FROM `accounts` LOCK IN SHARE MODE", sql)
+
+	sql, _ = querycraft.NewSelectBuilder(mockDB, &dialect.PostgresDialect{}, "*").
+		From("accounts").LockInShareMode().ToSQL()
+	assert.Equal(t, `SELECT * FROM "accounts" FOR SHARE`, sql)
+}
+
+func TestLockIgnoredBySQLite(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+
+	sql, _ := querycraft.NewSelectBuilder(mockDB, &dialect.SQLiteDialect{}, "*").
+		From("accounts").ForUpdate().ToSQL()
+	assert.Equal(t, `SELECT * FROM "accounts"`, sql)
+}
+
+func TestIndexHints(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+
+	sql, _ := querycraft.NewSelectBuilder(mockDB, &dialect.MySQLDialect{}, "*").
+		From("orders o").
+		UseIndex("idx_status", "idx_created").
+		IgnoreIndex("idx_legacy").
+		Join("users u", "u.id = o.user_id").
+		Where("o.status", "=", "paid").
+		ToSQL()
+	assert.Equal(t, "SELECT * FROM `orders` as o USE INDEX (`idx_status`, `idx_created`) IGNORE INDEX (`idx_legacy`) "+
+		"INNER JOIN `users` as u ON `u`.`id` = `o`.`user_id` WHERE `o`.`status` = ?", sql)
+
+	sql, _ = querycraft.NewSelectBuilder(mockDB, &dialect.MySQLDialect{}, "*").
+		From("orders").ForceIndex("PRIMARY").ToSQL()
+	assert.Equal(t, "SELECT * FROM `orders` FORCE INDEX (`PRIMARY`)", sql)
+}
+
+func TestIndexHintsIgnoredByPostgres(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+
+	sql, _ := querycraft.NewSelectBuilder(mockDB, &dialect.PostgresDialect{}, "*").
+		From("orders").ForceIndex("idx_status").Where("status", "=", "paid").ToSQL()
+	assert.Equal(t, `SELECT * FROM "orders" WHERE "status" = $1`, sql)
+}
+
+func TestPartition(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+
+	sql, _ := querycraft.NewSelectBuilder(mockDB, &dialect.MySQLDialect{}, "*").
+		From("logs l").Partition("p2023", "p2024").UseIndex("idx_created").Where("l.level", "=", "error").ToSQL()
+	assert.Equal(t, "SELECT * FROM `logs` PARTITION (`p2023`, `p2024`) as l USE INDEX (`idx_created`) WHERE `l`.`level` = ?", sql)
+
+	sql, _ = querycraft.NewSelectBuilder(mockDB, &dialect.PostgresDialect{}, "*").
+		From("logs").Partition("p2024").ToSQL()
+	assert.Equal(t, `SELECT * FROM "logs"`, sql)
+}
@@ -78,3 +78,61 @@ func TestOrWhereRaw(t *testing.T) {
 	assert.Equal(t, expectedSQL, sql)
 	assert.Equal(t, expectedArgs, args)
 }
+
+func TestOrWhereNotIn(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+	builder := NewSelectBuilder(mockDB, &dialect.MySQLDialect{}, "*")
+
+	result := builder.From("users").Where("status", "=", "active").OrWhereNotIn("role", "guest", "banned")
+	sql, args := result.ToSQL()
+
+	expectedSQL := "SELECT * FROM `users` WHERE `status` = ? OR `role` NOT IN (?, ?)"
+	expectedArgs := []any{"active", "guest", "banned"}
+
+	assert.Equal(t, expectedSQL, sql)
+	assert.Equal(t, expectedArgs, args)
+}
+
+func TestOrWhereBetween(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+	builder := NewSelectBuilder(mockDB, &dialect.MySQLDialect{}, "*")
+
+	result := builder.From("users").Where("status", "=", "active").OrWhereBetween("age", 18, 30)
+	sql, args := result.ToSQL()
+
+	expectedSQL := "SELECT * FROM `users` WHERE `status` = ? OR `age` BETWEEN ? AND ?"
+	expectedArgs := []any{"active", 18, 30}
+
+	assert.Equal(t, expectedSQL, sql)
+	assert.Equal(t, expectedArgs, args)
+}
+
+func TestOrWhereNotBetween(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+	builder := NewSelectBuilder(mockDB, &dialect.MySQLDialect{}, "*")
+
+	result := builder.From("users").Where("status", "=", "active").OrWhereNotBetween("age", 18, 30)
+	sql, args := result.ToSQL()
+
+	expectedSQL := "SELECT * FROM `users` WHERE `status` = ? OR `age` NOT BETWEEN ? AND ?"
+	expectedArgs := []any{"active", 18, 30}
+
+	assert.Equal(t, expectedSQL, sql)
+	assert.Equal(t, expectedArgs, args)
+}
+
+func TestOrWhereVariantsInsideGroup(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+	builder := NewSelectBuilder(mockDB, &dialect.MySQLDialect{}, "*")
+
+	result := builder.From("users").Where("status", "=", "active").WhereGroup(func(q SelectBuilder) SelectBuilder {
+		return q.OrWhereBetween("age", 18, 30).OrWhereNotIn("role", "guest").OrWhereNotNull("verified_at")
+	})
+	sql, args := result.ToSQL()
+
+	expectedSQL := "SELECT * FROM `users` WHERE `status` = ? AND (`age` BETWEEN ? AND ? OR `role` NOT IN (?) OR `verified_at` IS NOT NULL)"
+	expectedArgs := []any{"active", 18, 30, "guest"}
+
+	assert.Equal(t, expectedSQL, sql)
+	assert.Equal(t, expectedArgs, args)
+}
@@ -51,6 +51,25 @@ func TestOrderByRaw(t *testing.T) {
 	assert.Equal(t, expectedArgs, args)
 }
 
+func TestOrderByChained(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+	builder := querycraft.NewSelectBuilder(mockDB, &dialect.MySQLDialect{}, "*")
+
+	sql, _ := builder.From("users").OrderBy("name").OrderByDesc("created_at").ToSQL()
+
+	assert.Equal(t, "SELECT * FROM `users` ORDER BY `name`, `created_at` DESC", sql)
+}
+
+func TestOrderByRawThenOrderBy(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+	builder := querycraft.NewSelectBuilder(mockDB, &dialect.MySQLDialect{}, "*")
+
+	// Префикс ORDER BY в сыром выражении убирается
+	sql, _ := builder.From("users").OrderByRaw("order by FIELD(`status`, 'new', 'done')").OrderBy("id").ToSQL()
+
+	assert.Equal(t, "SELECT * FROM `users` ORDER BY FIELD(`status`, 'new', 'done'), `id`", sql)
+}
+
 func TestGroupBy(t *testing.T) {
 	mockDB := &test_utils.MockSQLXExecutor{}
 	builder := querycraft.NewSelectBuilder(mockDB, &dialect.MySQLDialect{}, "category", "COUNT(*) as count")
@@ -78,3 +97,34 @@ func TestHaving(t *testing.T) {
 	assert.Equal(t, expectedSQL, sql)
 	assert.Equal(t, expectedArgs, args)
 }
+
+func TestHavingJoinedWithAnd(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+	builder := querycraft.NewSelectBuilder(mockDB, &dialect.MySQLDialect{}, "category")
+
+	sql, args := builder.From("products").
+		GroupBy("category").
+		Having("COUNT(*) > ?", 5).
+		HavingRaw("SUM(price) > ?", 100).
+		ToSQL()
+
+	assert.Equal(t, "SELECT `category` FROM `products` GROUP BY `category` HAVING COUNT(*) > ? AND SUM(price) > ?", sql)
+	assert.Equal(t, []any{5, 100}, args)
+}
+
+func TestHavingGroupAndOrHaving(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+	builder := querycraft.NewSelectBuilder(mockDB, &dialect.MySQLDialect{}, "user_id")
+
+	sql, args := builder.From("orders").
+		Where("created_at", ">", "2024-01-01").
+		GroupBy("user_id").
+		HavingGroup(func(b querycraft.SelectBuilder) querycraft.SelectBuilder {
+			return b.Having("COUNT(*) > ?", 5).Having("SUM(amount) > ?", 100)
+		}).
+		OrHaving("MAX(status) = ?", "vip").
+		ToSQL()
+
+	assert.Equal(t, "SELECT `user_id` FROM `orders` WHERE `created_at` > ? GROUP BY `user_id` HAVING (COUNT(*) > ? AND SUM(amount) > ?) OR MAX(status) = ?", sql)
+	assert.Equal(t, []any{"2024-01-01", 5, 100, "vip"}, args)
+}
@@ -1,9 +1,15 @@
 package select_tests
 
 import (
+	"regexp"
 	"testing"
 
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/antibomberman/querycraft"
+	"github.com/antibomberman/querycraft/dialect"
 )
 
 func TestPaginateMethodsExist(t *testing.T) {
@@ -12,6 +18,73 @@ func TestPaginateMethodsExist(t *testing.T) {
 	assert.True(t, true)
 }
 
-// Note: We're not actually testing the Paginate and KeysetPaginate methods
-// because they require a real database connection to work properly.
-// These methods are tested in integration tests with a real database.
+func TestPaginateGroupedUsesSubqueryCount(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	builder := querycraft.NewSelectBuilder(sqlxDB, &dialect.MySQLDialect{}, "user_id", "COUNT(*) as orders").
+		From("orders").
+		GroupBy("user_id").
+		OrderBy("user_id")
+
+	countSQL := "SELECT COUNT(*) as count FROM (SELECT `user_id`, COUNT(*) as orders FROM `orders` GROUP BY `user_id` LIMIT 2147483647) AS _count"
+	mock.ExpectQuery(regexp.QuoteMeta(countSQL)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	dataSQL := "SELECT `user_id`, COUNT(*) as orders FROM `orders` GROUP BY `user_id` ORDER BY `user_id` LIMIT 2 OFFSET 0"
+	mock.ExpectQuery(regexp.QuoteMeta(dataSQL)).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "orders"}).AddRow(1, 5).AddRow(2, 7))
+
+	result, err := builder.Paginate(1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), result.Total)
+	assert.Equal(t, 2, result.LastPage)
+	assert.Len(t, result.Data, 2)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSimplePaginateHasMore(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	builder := querycraft.NewSelectBuilder(sqlx.NewDb(db, "sqlmock"), &dialect.MySQLDialect{}, "id").
+		From("posts").
+		OrderBy("id")
+
+	// Запроса COUNT(*) нет, выбирается perPage+1 строк
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT `id` FROM `posts` ORDER BY `id` LIMIT 3 OFFSET 2")).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3).AddRow(4).AddRow(5))
+
+	result, err := builder.SimplePaginate(2, 2)
+	assert.NoError(t, err)
+	assert.Len(t, result.Data, 2)
+	assert.True(t, result.HasMore)
+	assert.Equal(t, 2, result.CurrentPage)
+	assert.Equal(t, 2, result.PerPage)
+	assert.Equal(t, 3, result.From)
+	assert.Equal(t, 4, result.To)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSimplePaginateLastPage(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	builder := querycraft.NewSelectBuilder(sqlx.NewDb(db, "sqlmock"), &dialect.MySQLDialect{}, "id").
+		From("posts")
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT `id` FROM `posts` LIMIT 11 OFFSET 0")).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+
+	result, err := builder.SimplePaginate(1, 10)
+	assert.NoError(t, err)
+	assert.Len(t, result.Data, 2)
+	assert.False(t, result.HasMore)
+	assert.Equal(t, 1, result.From)
+	assert.Equal(t, 2, result.To)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,75 @@
+package select_tests
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPluckInt64(t *testing.T) {
+	builder, mock, closeDB := newExecSelect(t)
+	defer closeDB()
+
+	// MySQL отдает числа как []byte
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT `id` FROM `users`")).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow([]byte("1")).AddRow(int64(2)).AddRow(nil))
+
+	ids, err := builder.From("users").PluckInt64("id")
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1, 2, 0}, ids)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPluckString(t *testing.T) {
+	builder, mock, closeDB := newExecSelect(t)
+	defer closeDB()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT `name` FROM `users`")).
+		WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow([]byte("John")).AddRow("Jane").AddRow(int64(7)))
+
+	names, err := builder.From("users").PluckString("name")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"John", "Jane", "7"}, names)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPluckFloat64(t *testing.T) {
+	builder, mock, closeDB := newExecSelect(t)
+	defer closeDB()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT `price` FROM `products`")).
+		WillReturnRows(sqlmock.NewRows([]string{"price"}).AddRow([]byte("9.99")).AddRow(float64(1.5)).AddRow(int64(3)))
+
+	prices, err := builder.From("products").PluckFloat64("price")
+	assert.NoError(t, err)
+	assert.Equal(t, []float64{9.99, 1.5, 3}, prices)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPluckBool(t *testing.T) {
+	builder, mock, closeDB := newExecSelect(t)
+	defer closeDB()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT `active` FROM `users`")).
+		WillReturnRows(sqlmock.NewRows([]string{"active"}).AddRow([]byte("1")).AddRow(int64(0)).AddRow(true))
+
+	flags, err := builder.From("users").PluckBool("active")
+	assert.NoError(t, err)
+	assert.Equal(t, []bool{true, false, true}, flags)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPluckInt64ConversionError(t *testing.T) {
+	builder, mock, closeDB := newExecSelect(t)
+	defer closeDB()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT `name` FROM `users`")).
+		WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("John"))
+
+	ids, err := builder.From("users").PluckInt64("name")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "column name, row 0")
+	assert.Nil(t, ids)
+}
@@ -0,0 +1,286 @@
+package select_tests
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/antibomberman/querycraft"
+	"github.com/antibomberman/querycraft/dialect"
+	"github.com/antibomberman/querycraft/tests/test_utils"
+)
+
+func newPostgresSelect(columns ...string) querycraft.SelectBuilder {
+	return querycraft.NewSelectBuilder(&test_utils.MockSQLXExecutor{}, &dialect.PostgresDialect{}, columns...)
+}
+
+func TestWherePostgres(t *testing.T) {
+	tests := []struct {
+		name         string
+		builder      querycraft.SelectBuilder
+		expectedSQL  string
+		expectedArgs []any
+	}{
+		{
+			name:         "where",
+			builder:      newPostgresSelect("*").From("users").Where("id", "=", 1),
+			expectedSQL:  `SELECT * FROM "users" WHERE "id" = $1`,
+			expectedArgs: []any{1},
+		},
+		{
+			name:         "where eq",
+			builder:      newPostgresSelect("*").From("users").WhereEq("id", 1),
+			expectedSQL:  `SELECT * FROM "users" WHERE "id" = $1`,
+			expectedArgs: []any{1},
+		},
+		{
+			name:         "where in",
+			builder:      newPostgresSelect("*").From("users").WhereIn("id", 1, 2, 3),
+			expectedSQL:  `SELECT * FROM "users" WHERE "id" IN ($1, $2, $3)`,
+			expectedArgs: []any{1, 2, 3},
+		},
+		{
+			name:         "where not in",
+			builder:      newPostgresSelect("*").From("users").WhereNotIn("id", 1, 2, 3),
+			expectedSQL:  `SELECT * FROM "users" WHERE "id" NOT IN ($1, $2, $3)`,
+			expectedArgs: []any{1, 2, 3},
+		},
+		{
+			name:        "where null",
+			builder:     newPostgresSelect("*").From("users").WhereNull("name").WhereNotNull("email"),
+			expectedSQL: `SELECT * FROM "users" WHERE "name" IS NULL AND "email" IS NOT NULL`,
+		},
+		{
+			name:         "where between",
+			builder:      newPostgresSelect("*").From("users").WhereBetween("age", 18, 65).WhereNotBetween("score", 0, 10),
+			expectedSQL:  `SELECT * FROM "users" WHERE "age" BETWEEN $1 AND $2 AND "score" NOT BETWEEN $3 AND $4`,
+			expectedArgs: []any{18, 65, 0, 10},
+		},
+		{
+			name:         "where raw",
+			builder:      newPostgresSelect("*").From("users").Where("active", "=", true).WhereRaw(`"name" = 'John' AND "age" > ?`, 18),
+			expectedSQL:  `SELECT * FROM "users" WHERE "active" = $1 AND "name" = 'John' AND "age" > $2`,
+			expectedArgs: []any{true, 18},
+		},
+		{
+			name: "where like",
+			builder: newPostgresSelect("*").From("users").
+				WhereLike("name", "Jo%").
+				WhereNotLike("email", "%@spam.com").
+				OrWhereLike("nick", "%jo%"),
+			expectedSQL:  `SELECT * FROM "users" WHERE "name" LIKE $1 AND "email" NOT LIKE $2 OR "nick" LIKE $3`,
+			expectedArgs: []any{"Jo%", "%@spam.com", "%jo%"},
+		},
+		{
+			name: "where column",
+			builder: newPostgresSelect("*").From("users").
+				WhereColumn("updated_at", ">", "created_at").
+				OrWhereColumn("users.id", "=", "users.parent_id"),
+			expectedSQL: `SELECT * FROM "users" WHERE "updated_at" > "created_at" OR "users"."id" = "users"."parent_id"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, args := tt.builder.ToSQL()
+			assert.Equal(t, tt.expectedSQL, sql)
+			assert.Equal(t, tt.expectedArgs, args)
+		})
+	}
+}
+
+func TestJoinPostgres(t *testing.T) {
+	tests := []struct {
+		name        string
+		builder     querycraft.SelectBuilder
+		expectedSQL string
+	}{
+		{
+			name:        "join",
+			builder:     newPostgresSelect("*").From("users").Join("orders", "users.id = orders.user_id"),
+			expectedSQL: `SELECT * FROM "users" INNER JOIN "orders" ON "users"."id" = "orders"."user_id"`,
+		},
+		{
+			name:        "left join",
+			builder:     newPostgresSelect("*").From("users").LeftJoin("orders", "users.id = orders.user_id"),
+			expectedSQL: `SELECT * FROM "users" LEFT JOIN "orders" ON "users"."id" = "orders"."user_id"`,
+		},
+		{
+			name:        "right join",
+			builder:     newPostgresSelect("*").From("users").RightJoin("orders", "users.id = orders.user_id"),
+			expectedSQL: `SELECT * FROM "users" RIGHT JOIN "orders" ON "users"."id" = "orders"."user_id"`,
+		},
+		{
+			name:        "cross join",
+			builder:     newPostgresSelect("*").From("users").CrossJoin("orders"),
+			expectedSQL: `SELECT * FROM "users" CROSS JOIN "orders"`,
+		},
+		{
+			name: "join with alias",
+			builder: newPostgresSelect("order.*").
+				From("user as u").
+				Join("order", "order.user_id = u.id").
+				Limit(500),
+			expectedSQL: `SELECT "order".* FROM "user" as u INNER JOIN "order" ON "order"."user_id" = "u"."id" LIMIT 500`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, args := tt.builder.ToSQL()
+			assert.Equal(t, tt.expectedSQL, sql)
+			assert.Empty(t, args)
+		})
+	}
+}
+
+func TestOrderGroupPostgres(t *testing.T) {
+	sql, _ := newPostgresSelect("*").From("users").OrderBy("name").OrderByDesc("created_at").ToSQL()
+	assert.Equal(t, `SELECT * FROM "users" ORDER BY "name", "created_at" DESC`, sql)
+
+	sql, args := newPostgresSelect("category", "COUNT(*) as count").
+		From("products").
+		GroupBy("category").
+		Having("COUNT(*) > ?", 5).
+		HavingRaw("SUM(price) > ?", 100).
+		ToSQL()
+	assert.Equal(t, `SELECT "category", COUNT(*) as count FROM "products" GROUP BY "category" HAVING COUNT(*) > $1 AND SUM(price) > $2`, sql)
+	assert.Equal(t, []any{5, 100}, args)
+}
+
+func TestPlaceholdersSharedAcrossClausesPostgres(t *testing.T) {
+	// Нумерация $N сквозная: WHERE, затем HAVING
+	sql, args := newPostgresSelect("user_id").
+		From("orders").
+		Where("created_at", ">", "2024-01-01").
+		WhereIn("status", "paid", "shipped").
+		GroupBy("user_id").
+		HavingGroup(func(b querycraft.SelectBuilder) querycraft.SelectBuilder {
+			return b.Having("COUNT(*) > ?", 5).Having("SUM(amount) > ?", 100)
+		}).
+		OrHaving("MAX(status) = ?", "vip").
+		ToSQL()
+
+	assert.Equal(t, `SELECT "user_id" FROM "orders" WHERE "created_at" > $1 AND "status" IN ($2, $3) `+
+		`GROUP BY "user_id" HAVING (COUNT(*) > $4 AND SUM(amount) > $5) OR MAX(status) = $6`, sql)
+	assert.Equal(t, []any{"2024-01-01", "paid", "shipped", 5, 100, "vip"}, args)
+}
+
+func TestSelectLimitWherePostgres(t *testing.T) {
+	sql, args := newPostgresSelect("order.limit as _lim").Where("_lim", "<>", "0").ToSQL()
+
+	assert.Equal(t, `SELECT "order"."limit" AS "_lim" WHERE "_lim" <> $1`, sql)
+	assert.Equal(t, []any{"0"}, args)
+}
+
+func TestPaginatePostgres(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	builder := querycraft.NewSelectBuilder(sqlx.NewDb(db, "sqlmock"), &dialect.PostgresDialect{}, "id").
+		From("posts").
+		Where("published", "=", true).
+		OrderBy("id")
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) as count FROM "posts" WHERE "published" = $1`)).
+		WithArgs(true).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id" FROM "posts" WHERE "published" = $1 ORDER BY "id" LIMIT 2 OFFSET 2`)).
+		WithArgs(true).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+
+	result, err := builder.Paginate(2, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), result.Total)
+	assert.Equal(t, 2, result.LastPage)
+	assert.Len(t, result.Data, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSimplePaginatePostgres(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	builder := querycraft.NewSelectBuilder(sqlx.NewDb(db, "sqlmock"), &dialect.PostgresDialect{}, "id").
+		From("posts").
+		Where("published", "=", true).
+		OrderBy("id")
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id" FROM "posts" WHERE "published" = $1 ORDER BY "id" LIMIT 3 OFFSET 2`)).
+		WithArgs(true).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3).AddRow(4).AddRow(5))
+
+	result, err := builder.SimplePaginate(2, 2)
+	assert.NoError(t, err)
+	assert.Len(t, result.Data, 2)
+	assert.True(t, result.HasMore)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWhereGroupPostgres(t *testing.T) {
+	sql, args := newPostgresSelect("*").
+		From("users").
+		Where("status", "=", "active").
+		WhereGroup(func(b querycraft.SelectBuilder) querycraft.SelectBuilder {
+			return b.Where("age", ">", 18).OrWhere("verified", "=", true)
+		}).
+		OrWhereGroup(func(b querycraft.SelectBuilder) querycraft.SelectBuilder {
+			return b.Where("role", "=", "admin").WhereNotNull("email")
+		}).
+		ToSQL()
+
+	assert.Equal(t, `SELECT * FROM "users" WHERE "status" = $1 AND ("age" > $2 OR "verified" = $3) OR ("role" = $4 AND "email" IS NOT NULL)`, sql)
+	assert.Equal(t, []any{"active", 18, true, "admin"}, args)
+}
+
+func TestWhenPostgres(t *testing.T) {
+	sql, args := newPostgresSelect("*").
+		From("users").
+		When(true, "status", "=", "active").
+		When(false, "role", "=", "admin").
+		WhenFunc(true, func(b querycraft.SelectBuilder) querycraft.SelectBuilder {
+			return b.WhereIn("id", 1, 2)
+		}).
+		ToSQL()
+
+	assert.Equal(t, `SELECT * FROM "users" WHERE "status" = $1 AND "id" IN ($2, $3)`, sql)
+	assert.Equal(t, []any{"active", 1, 2}, args)
+}
+
+func TestWhereExistsPostgres(t *testing.T) {
+	subQuery := newPostgresSelect("1").
+		From("orders").
+		WhereRaw(`"orders"."user_id" = "users"."id"`).
+		Where("amount", ">", 100)
+
+	sql, args := newPostgresSelect("*").From("users").Where("active", "=", true).WhereExists(subQuery).ToSQL()
+	assert.Equal(t, `SELECT * FROM "users" WHERE "active" = $1 AND EXISTS (SELECT 1 FROM "orders" WHERE "orders"."user_id" = "users"."id" AND "amount" > $2)`, sql)
+	assert.Equal(t, []any{true, 100}, args)
+
+	sql, _ = newPostgresSelect("*").From("users").WhereNotExists(subQuery).ToSQL()
+	assert.Equal(t, `SELECT * FROM "users" WHERE NOT EXISTS (SELECT 1 FROM "orders" WHERE "orders"."user_id" = "users"."id" AND "amount" > $1)`, sql)
+}
+
+func TestCountPostgres(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	builder := querycraft.NewSelectBuilder(sqlx.NewDb(db, "sqlmock"), &dialect.PostgresDialect{}, "*").
+		From("users").
+		Where("active", "=", true)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) as count FROM "users" WHERE "active" = $1`)).
+		WithArgs(true).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(7))
+
+	count, err := builder.Count()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -46,3 +46,151 @@ func TestSelectOrderLimitWhere(t *testing.T) {
 	assert.Equal(t, expectedSQL, sql)
 	assert.Equal(t, expectedArgs, args)
 }
+
+func TestSelectToInsertSQL(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+
+	sql, args := NewSelectBuilder(mockDB, &dialect.MySQLDialect{}, "name", "email").
+		From("temp_users").
+		Where("active", "=", true).
+		ToInsertSQL("users", "name", "email")
+
+	expectedSQL := "INSERT INTO `users` (`name`, `email`) SELECT `name`, `email` FROM `temp_users` WHERE `active` = ?"
+	expectedArgs := []any{true}
+
+	assert.Equal(t, expectedSQL, sql)
+	assert.Equal(t, expectedArgs, args)
+}
+
+func TestSelectReplacesColumns(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+
+	sql, args := NewSelectBuilder(mockDB, &dialect.MySQLDialect{}, "*").
+		From("users").
+		Select("id", "name").
+		Where("active", "=", 1).
+		ToSQL()
+
+	assert.Equal(t, "SELECT `id`, `name` FROM `users` WHERE `active` = ?", sql)
+	assert.Equal(t, []any{1}, args)
+
+	// Пустой список возвращает SELECT *
+	sql, _ = NewSelectBuilder(mockDB, &dialect.MySQLDialect{}, "id").From("users").Select().ToSQL()
+	assert.Equal(t, "SELECT * FROM `users`", sql)
+}
+
+func TestAddColumnIf(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+
+	build := func(analytics bool) (string, []any) {
+		return NewSelectBuilder(mockDB, &dialect.MySQLDialect{}, "id").
+			From("products").
+			AddColumnIf(analytics, "cost").
+			AddColumnsIf(analytics, "price", "stock").
+			AddExprColumnIf(analytics, "(price - cost) / price * ? AS profit_margin", 100).
+			Where("active", "=", 1).
+			ToSQL()
+	}
+
+	sql, args := build(true)
+	assert.Equal(t, "SELECT `id`, `cost`, `price`, `stock`, (price - cost) / price * ? AS profit_margin FROM `products` WHERE `active` = ?", sql)
+	assert.Equal(t, []any{100, 1}, args)
+
+	sql, args = build(false)
+	assert.Equal(t, "SELECT `id` FROM `products` WHERE `active` = ?", sql)
+	assert.Equal(t, []any{1}, args)
+}
+
+func TestSelectInspection(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+
+	builder := NewSelectBuilder(mockDB, &dialect.MySQLDialect{}, "id", "name").
+		From("users").
+		Where("age", ">", 18).
+		WhereIn("role", "admin", "editor")
+
+	assert.Equal(t, []string{"id", "name"}, builder.Columns())
+	assert.Equal(t, "users", builder.Table())
+	assert.Equal(t, []any{18, "admin", "editor"}, builder.WhereArgs())
+
+	// Возвращаются копии, изменение не влияет на билдер
+	builder.Columns()[0] = "changed"
+	builder.WhereArgs()[0] = 0
+	sql, args := builder.ToSQL()
+	assert.Equal(t, "SELECT `id`, `name` FROM `users` WHERE `age` > ? AND `role` IN (?, ?)", sql)
+	assert.Equal(t, []any{18, "admin", "editor"}, args)
+}
+
+func TestSelectWithHint(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+
+	sql, _ := NewSelectBuilder(mockDB, &dialect.MySQLDialect{}, "id").
+		From("orders").
+		WithHint(HintMaxExecutionTime(1000)).
+		WithHint(HintHashJoin("orders", "users")).
+		WithHint(HintNoIndexMerge("orders")).
+		ToSQL()
+	assert.Equal(t, "SELECT /*+ MAX_EXECUTION_TIME(1000) HASH_JOIN(orders, users) NO_INDEX_MERGE(orders) */ `id` FROM `orders`", sql)
+
+	// */ внутри подсказки удаляется
+	sql, _ = NewSelectBuilder(mockDB, &dialect.MySQLDialect{}).
+		From("t3").
+		WithHint("NO_RANGE_OPTIMIZATION(t3 PRIMARY) */").
+		ToSQL()
+	assert.Equal(t, "SELECT /*+ NO_RANGE_OPTIMIZATION(t3 PRIMARY) */ * FROM `t3`", sql)
+
+	// Диалект без подсказок их отбрасывает
+	sql, _ = NewSelectBuilder(mockDB, &dialect.PostgresDialect{}, "id").
+		From("orders").
+		WithHint(HintMaxExecutionTime(1000)).
+		ToSQL()
+	assert.Equal(t, `SELECT "id" FROM "orders"`, sql)
+}
+
+func TestSelectReset(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+
+	template := NewSelectBuilder(mockDB, &dialect.MySQLDialect{}, "u.id").
+		From("users as u").
+		Join("orders as o", "o.user_id = u.id").
+		Where("u.active", "=", 1).
+		GroupBy("u.id").
+		Having("COUNT(*) > ?", 2).
+		OrderBy("u.id").
+		Limit(10).
+		Offset(20)
+
+	sql, args := template.Clone().
+		ResetWhere().
+		ResetJoins().
+		ResetGroup().
+		ResetHaving().
+		ResetOrder().
+		ResetLimit().
+		ResetOffset().
+		Where("u.role", "=", "admin").
+		ToSQL()
+
+	assert.Equal(t, "SELECT `u`.`id` FROM `users` as u WHERE `u`.`role` = ?", sql)
+	assert.Equal(t, []any{"admin"}, args)
+
+	// Шаблон не изменился
+	_, args = template.ToSQL()
+	assert.Equal(t, []any{1, 2}, args)
+}
+
+func TestSelectRawAndAddColumn(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+	builder := NewSelectBuilder(mockDB, &dialect.MySQLDialect{})
+
+	sql, args := builder.From("orders").
+		AddColumn("user_id").
+		SelectRaw("SUM(total * ?) AS gross", 1.2).
+		SelectRaw("COUNT(*) AS total").
+		Where("status", "=", "paid").
+		GroupBy("user_id").
+		ToSQL()
+
+	assert.Equal(t, "SELECT `user_id`, SUM(total * ?) AS gross, COUNT(*) AS total FROM `orders` WHERE `status` = ? GROUP BY `user_id`", sql)
+	assert.Equal(t, []any{1.2, "paid"}, args)
+}
@@ -0,0 +1,142 @@
+package select_tests
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/antibomberman/querycraft"
+	"github.com/antibomberman/querycraft/dialect"
+)
+
+func TestIntersectExceptPostgres(t *testing.T) {
+	d := &dialect.PostgresDialect{}
+	active := querycraft.NewSelectBuilder(nil, d, "user_id").From("orders").Where("status", "=", "paid")
+	banned := querycraft.NewSelectBuilder(nil, d, "user_id").From("bans").Where("reason", "=", "fraud")
+
+	query, args := querycraft.NewSelectBuilder(nil, d, "id").
+		From("users").
+		Where("active", "=", true).
+		Intersect(active).
+		Except(banned).
+		ToSQL()
+
+	assert.Equal(t, `(SELECT "id" FROM "users" WHERE "active" = $1) INTERSECT (SELECT "user_id" FROM "orders" WHERE "status" = $2) EXCEPT (SELECT "user_id" FROM "bans" WHERE "reason" = $3)`, query)
+	assert.Equal(t, []any{true, "paid", "fraud"}, args)
+}
+
+func TestIntersectExceptAll(t *testing.T) {
+	d := &dialect.PostgresDialect{}
+	other := querycraft.NewSelectBuilder(nil, d, "id").From("archived")
+
+	query, _ := querycraft.NewSelectBuilder(nil, d, "id").From("users").IntersectAll(other).ToSQL()
+	assert.Equal(t, `(SELECT "id" FROM "users") INTERSECT ALL (SELECT "id" FROM "archived")`, query)
+
+	query, _ = querycraft.NewSelectBuilder(nil, d, "id").From("users").Except(other).AllVariant().AllVariant().ToSQL()
+	assert.Equal(t, `(SELECT "id" FROM "users") EXCEPT ALL (SELECT "id" FROM "archived")`, query)
+}
+
+func TestIntersectNotSupportedMySQL(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	d := &dialect.MySQLDialect{}
+	other := querycraft.NewSelectBuilder(nil, d, "id").From("archived")
+	builder := querycraft.NewSelectBuilder(sqlx.NewDb(db, "sqlmock"), d, "id").From("users").Intersect(other)
+
+	assert.ErrorIs(t, builder.Err(), querycraft.ErrNotSupported)
+
+	_, err = builder.Rows()
+	assert.ErrorIs(t, err, querycraft.ErrNotSupported)
+	_, err = builder.Clone().Count()
+	assert.ErrorIs(t, err, querycraft.ErrNotSupported)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUnion(t *testing.T) {
+	d := &dialect.MySQLDialect{}
+	admins := querycraft.NewSelectBuilder(nil, d, "id", "name").From("admins").Where("active", "=", true)
+
+	query, args := querycraft.NewSelectBuilder(nil, d, "id", "name").
+		From("users").
+		Where("role", "=", "editor").
+		Union(admins).
+		OrderBy("name").
+		Limit(10).
+		Offset(20).
+		ToSQL()
+
+	// ORDER BY, LIMIT и OFFSET внешнего запроса применяются ко всему UNION
+	assert.Equal(t, "SELECT `id`, `name` FROM `users` WHERE `role` = ? UNION SELECT `id`, `name` FROM `admins` WHERE `active` = ? ORDER BY `name` LIMIT 10 OFFSET 20", query)
+	assert.Equal(t, []any{"editor", true}, args)
+}
+
+func TestUnionAllWrapsOrderedOperand(t *testing.T) {
+	d := &dialect.PostgresDialect{}
+	latest := querycraft.NewSelectBuilder(nil, d, "id").
+		From("archived").
+		Where("year", "=", 2023).
+		OrderByDesc("id").
+		Limit(5)
+
+	query, args := querycraft.NewSelectBuilder(nil, d, "id").
+		From("users").
+		Where("active", "=", true).
+		UnionAll(latest).
+		ToSQL()
+
+	assert.Equal(t, `(SELECT "id" FROM "users" WHERE "active" = $1) UNION ALL (SELECT "id" FROM "archived" WHERE "year" = $2 ORDER BY "id" DESC LIMIT 5)`, query)
+	assert.Equal(t, []any{true, 2023}, args)
+}
+
+func TestUnionAllExec(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	d := &dialect.MySQLDialect{}
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT `email` FROM `users` WHERE `active` = ? UNION ALL SELECT `email` FROM `subscribers` WHERE `confirmed` = ? ORDER BY `email`")).
+		WithArgs(true, true).
+		WillReturnRows(sqlmock.NewRows([]string{"email"}).AddRow("a@example.com").AddRow("b@example.com"))
+
+	rows, err := querycraft.NewSelectBuilder(sqlxDB, d, "email").
+		From("users").
+		Where("active", "=", true).
+		UnionAll(querycraft.NewSelectBuilder(sqlxDB, d, "email").From("subscribers").Where("confirmed", "=", true)).
+		OrderBy("email").
+		Rows()
+
+	assert.NoError(t, err)
+	assert.Len(t, rows, 2)
+	assert.Equal(t, "a@example.com", rows[0]["email"])
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUnionCount(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	d := &dialect.MySQLDialect{}
+	admins := querycraft.NewSelectBuilder(nil, d, "id").From("admins").Where("active", "=", true)
+	builder := querycraft.NewSelectBuilder(sqlx.NewDb(db, "sqlmock"), d, "id").
+		From("users").
+		Where("role", "=", "editor").
+		Union(admins)
+
+	// Считается весь UNION, а не только левая часть
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) as count FROM (SELECT `id` FROM `users` WHERE `role` = ? UNION SELECT `id` FROM `admins` WHERE `active` = ? LIMIT 2147483647) AS _count")).
+		WithArgs("editor", true).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(9))
+
+	count, err := builder.Count()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(9), count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,53 @@
+package select_tests
+
+import (
+	"testing"
+
+	"github.com/antibomberman/querycraft"
+	"github.com/antibomberman/querycraft/dialect"
+	"github.com/antibomberman/querycraft/tests/test_utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWhereDatePartsMySQL(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+	builder := querycraft.NewSelectBuilder(mockDB, &dialect.MySQLDialect{}, "*")
+
+	sql, args := builder.From("orders").
+		WhereDate("created_at", "=", "2024-05-01").
+		WhereYear("created_at", "=", 2024).
+		WhereMonth("created_at", ">=", 3).
+		WhereDay("created_at", "<", 15).
+		WhereHour("created_at", "=", 9).
+		WhereTime("created_at", ">", "08:00:00").
+		ToSQL()
+
+	assert.Equal(t, "SELECT * FROM `orders` WHERE DATE(`created_at`) = ? AND YEAR(`created_at`) = ? AND MONTH(`created_at`) >= ? "+
+		"AND DAY(`created_at`) < ? AND HOUR(`created_at`) = ? AND TIME(`created_at`) > ?", sql)
+	assert.Equal(t, []any{"2024-05-01", 2024, 3, 15, 9, "08:00:00"}, args)
+}
+
+func TestWhereDatePartsPostgres(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+	builder := querycraft.NewSelectBuilder(mockDB, &dialect.PostgresDialect{}, "*")
+
+	sql, args := builder.From("orders").
+		WhereDate("created_at", "=", "2024-05-01").
+		WhereYear("created_at", "=", 2024).
+		ToSQL()
+
+	assert.Equal(t, `SELECT * FROM "orders" WHERE "created_at"::date = $1 AND EXTRACT(YEAR FROM "created_at") = $2`, sql)
+	assert.Equal(t, []any{"2024-05-01", 2024}, args)
+}
+
+func TestWhereDatePartsSQLite(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+	builder := querycraft.NewSelectBuilder(mockDB, &dialect.SQLiteDialect{}, "*")
+
+	sql, _ := builder.From("orders").
+		WhereDate("created_at", "=", "2024-05-01").
+		WhereMonth("created_at", "=", 5).
+		ToSQL()
+
+	assert.Equal(t, `SELECT * FROM "orders" WHERE DATE("created_at") = ? AND CAST(strftime('%m', "created_at") AS INTEGER) = ?`, sql)
+}
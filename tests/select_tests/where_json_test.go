@@ -0,0 +1,58 @@
+package select_tests
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/antibomberman/querycraft"
+	"github.com/antibomberman/querycraft/dialect"
+	"github.com/antibomberman/querycraft/tests/test_utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWhereJsonContainsMySQL(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+	builder := querycraft.NewSelectBuilder(mockDB, &dialect.MySQLDialect{}, "*")
+
+	sql, args := builder.From("posts").WhereJsonContains("meta", "$.tags", "go").ToSQL()
+
+	assert.Equal(t, "SELECT * FROM `posts` WHERE JSON_CONTAINS(`meta`, ?, ?)", sql)
+	assert.Equal(t, []any{`"go"`, "$.tags"}, args)
+}
+
+func TestWhereJsonContainsPostgres(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+
+	sql, args := querycraft.NewSelectBuilder(mockDB, &dialect.PostgresDialect{}, "*").
+		From("posts").WhereJsonContains("meta", "", map[string]any{"draft": false}).ToSQL()
+	assert.Equal(t, `SELECT * FROM "posts" WHERE "meta" @> $1::jsonb`, sql)
+	assert.Equal(t, []any{`{"draft":false}`}, args)
+
+	sql, args = querycraft.NewSelectBuilder(mockDB, &dialect.PostgresDialect{}, "*").
+		From("posts").WhereJsonContains("meta", "{tags}", json.RawMessage(`["go"]`)).ToSQL()
+	assert.Equal(t, `SELECT * FROM "posts" WHERE "meta" #> $1::text[] @> $2::jsonb`, sql)
+	assert.Equal(t, []any{"{tags}", `["go"]`}, args)
+}
+
+func TestWhereJsonContainsInvalidValue(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+	builder := querycraft.NewSelectBuilder(mockDB, &dialect.MySQLDialect{}, "*")
+
+	builder.From("posts").WhereJsonContains("meta", "$", make(chan int))
+
+	assert.Error(t, builder.Err())
+}
+
+func TestWhereJsonPath(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+
+	sql, args := querycraft.NewSelectBuilder(mockDB, &dialect.MySQLDialect{}, "*").
+		From("users").WhereJsonPath("settings", "$.theme", "=", "dark").ToSQL()
+	assert.Equal(t, "SELECT * FROM `users` WHERE JSON_EXTRACT(`settings`, ?) = ?", sql)
+	assert.Equal(t, []any{"$.theme", "dark"}, args)
+
+	sql, args = querycraft.NewSelectBuilder(mockDB, &dialect.PostgresDialect{}, "*").
+		From("users").WhereJsonPath("settings", "{ui,theme}", "=", "dark").Where("id", ">", 10).ToSQL()
+	assert.Equal(t, `SELECT * FROM "users" WHERE "settings" #>> $1::text[] = $2 AND "id" > $3`, sql)
+	assert.Equal(t, []any{"{ui,theme}", "dark", 10}, args)
+}
@@ -136,3 +136,43 @@ func TestWhereRaw(t *testing.T) {
 	assert.Equal(t, expectedSQL, sql)
 	assert.Equal(t, expectedArgs, args)
 }
+
+func TestWhereLike(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+	builder := querycraft.NewSelectBuilder(mockDB, &dialect.MySQLDialect{}, "*")
+
+	sql, args := builder.From("users").
+		WhereLike("name", "Jo%").
+		WhereNotLike("email", "%@spam.com").
+		OrWhereLike("nick", "%jo%").
+		ToSQL()
+
+	assert.Equal(t, "SELECT * FROM `users` WHERE `name` LIKE ? AND `email` NOT LIKE ? OR `nick` LIKE ?", sql)
+	assert.Equal(t, []any{"Jo%", "%@spam.com", "%jo%"}, args)
+}
+
+func TestWhereILike(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+
+	sql, _ := querycraft.NewSelectBuilder(mockDB, &dialect.MySQLDialect{}, "*").
+		From("users").WhereILike("name", "jo%").ToSQL()
+	assert.Equal(t, "SELECT * FROM `users` WHERE `name` LIKE ?", sql)
+
+	sql, args := querycraft.NewSelectBuilder(mockDB, &dialect.PostgresDialect{}, "*").
+		From("users").WhereILike("name", "jo%").ToSQL()
+	assert.Equal(t, `SELECT * FROM "users" WHERE "name" ILIKE $1`, sql)
+	assert.Equal(t, []any{"jo%"}, args)
+}
+
+func TestWhereColumn(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+	builder := querycraft.NewSelectBuilder(mockDB, &dialect.MySQLDialect{}, "*")
+
+	sql, args := builder.From("users").
+		WhereColumn("updated_at", ">", "created_at").
+		OrWhereColumn("users.id", "=", "users.parent_id").
+		ToSQL()
+
+	assert.Equal(t, "SELECT * FROM `users` WHERE `updated_at` > `created_at` OR `users`.`id` = `users`.`parent_id`", sql)
+	assert.Empty(t, args)
+}
@@ -0,0 +1,54 @@
+package select_tests
+
+import (
+	"testing"
+
+	"github.com/antibomberman/querycraft"
+	"github.com/antibomberman/querycraft/dialect"
+	"github.com/antibomberman/querycraft/tests/test_utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddWindowFunc(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+	builder := querycraft.NewSelectBuilder(mockDB, &dialect.MySQLDialect{}, "id", "user_id").
+		From("orders").
+		AddWindowFunc("rn", "ROW_NUMBER", []string{"user_id"}, []string{"created_at DESC", "id"}).
+		Where("status", "=", "paid")
+
+	sql, args := builder.ToSQL()
+
+	expectedSQL := "SELECT `id`, `user_id`, ROW_NUMBER() OVER (PARTITION BY `user_id` ORDER BY `created_at` DESC, `id`) AS `rn` FROM `orders` WHERE `status` = ?"
+	assert.Equal(t, expectedSQL, sql)
+	assert.Equal(t, []any{"paid"}, args)
+}
+
+func TestAddWindowFuncPostgres(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+	builder := querycraft.NewSelectBuilder(mockDB, &dialect.PostgresDialect{}, "id").
+		From("prices").
+		AddWindowFunc("prev_price", `LAG("price", 1)`, nil, []string{"day"}).
+		AddWindowFunc("price_rank", "RANK", []string{"product_id"}, []string{"price DESC"})
+
+	sql, _ := builder.ToSQL()
+
+	expectedSQL := `SELECT "id", LAG("price", 1) OVER (ORDER BY "day") AS "prev_price", ` +
+		`RANK() OVER (PARTITION BY "product_id" ORDER BY "price" DESC) AS "price_rank" FROM "prices"`
+	assert.Equal(t, expectedSQL, sql)
+}
+
+func TestNamedWindow(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+	builder := querycraft.NewSelectBuilder(mockDB, &dialect.PostgresDialect{}, "user_id").
+		From("orders").
+		AddExprColumn(`SUM("total") OVER w AS running_total`).
+		GroupBy("user_id", "total", "created_at").
+		Window("w", `PARTITION BY "user_id" ORDER BY "created_at"`).
+		OrderBy("user_id")
+
+	sql, _ := builder.ToSQL()
+
+	expectedSQL := `SELECT "user_id", SUM("total") OVER w AS running_total FROM "orders" ` +
+		`GROUP BY "user_id", "total", "created_at" WINDOW "w" AS (PARTITION BY "user_id" ORDER BY "created_at") ORDER BY "user_id"`
+	assert.Equal(t, expectedSQL, sql)
+}
@@ -1,8 +1,12 @@
 package transaction_tests
 
 import (
+	"errors"
 	"testing"
 
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+
 	"github.com/antibomberman/querycraft"
 	"github.com/antibomberman/querycraft/dialect"
 	"github.com/stretchr/testify/assert"
@@ -61,3 +65,84 @@ func TestTransactionUpsert(t *testing.T) {
 	builder := tx.Upsert("users")
 	assert.NotNil(t, builder)
 }
+
+func TestTransactionAutoSavepoint(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	mock.ExpectBegin()
+	mock.ExpectExec("SAVEPOINT sp_[0-9a-f]{32}").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("SAVEPOINT sp_[0-9a-f]{32}").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("ROLLBACK TO SAVEPOINT sp_[0-9a-f]{32}").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("RELEASE SAVEPOINT sp_[0-9a-f]{32}").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	tx, err := sqlxDB.Beginx()
+	assert.NoError(t, err)
+	transaction := querycraft.NewTransaction(tx, sqlxDB, &dialect.MySQLDialect{})
+
+	first := transaction.AutoSavepoint()
+	second := transaction.AutoSavepoint()
+	assert.NoError(t, first.Err())
+	assert.NoError(t, second.Err())
+	assert.NotEqual(t, first.Name(), second.Name())
+
+	assert.NoError(t, second.Rollback())
+	assert.NoError(t, first.Release())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTransactionNamedSavepoints(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	mock.ExpectBegin()
+	mock.ExpectExec("SAVEPOINT `before_items`").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("ROLLBACK TO SAVEPOINT `before_items`").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("RELEASE SAVEPOINT `before_items`").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	tx, err := sqlxDB.Beginx()
+	assert.NoError(t, err)
+	transaction := querycraft.NewTransaction(tx, sqlxDB, &dialect.MySQLDialect{})
+
+	assert.NoError(t, transaction.Savepoint("before_items"))
+	assert.NoError(t, transaction.RollbackToSavepoint("before_items"))
+	assert.NoError(t, transaction.ReleaseSavepoint("before_items"))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTransactionNested(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	mock.ExpectBegin()
+	// Успешный fn - savepoint освобождается
+	mock.ExpectExec("SAVEPOINT sp_[0-9a-f]{32}").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("INSERT INTO `logs`").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("RELEASE SAVEPOINT sp_[0-9a-f]{32}").WillReturnResult(sqlmock.NewResult(0, 0))
+	// Ошибка fn - откат до savepoint
+	mock.ExpectExec("SAVEPOINT sp_[0-9a-f]{32}").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("ROLLBACK TO SAVEPOINT sp_[0-9a-f]{32}").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	tx, err := sqlxDB.Beginx()
+	assert.NoError(t, err)
+	transaction := querycraft.NewTransaction(tx, sqlxDB, &dialect.MySQLDialect{})
+
+	err = transaction.Nested(func(tx querycraft.Transaction) error {
+		_, err := tx.Insert("logs").Columns("message", "level").Values("ok", "info").Exec()
+		return err
+	})
+	assert.NoError(t, err)
+
+	failure := errors.New("validation failed")
+	err = transaction.Nested(func(tx querycraft.Transaction) error {
+		return failure
+	})
+	assert.ErrorIs(t, err, failure)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,57 @@
+package update_tests
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/antibomberman/querycraft"
+	"github.com/antibomberman/querycraft/dialect"
+)
+
+func TestUpdateExec(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `users` SET `name` = ? WHERE `id` = ?")).
+		WithArgs("Jane", 3).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	result, err := querycraft.NewUpdateBuilder(sqlx.NewDb(db, "sqlmock"), &dialect.MySQLDialect{}, "users").
+		Set("name", "Jane").
+		Where("id", "=", 3).
+		Exec()
+	assert.NoError(t, err)
+
+	affected, err := result.RowsAffected()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), affected)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateExecReturning(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`UPDATE "users" SET "active" = $1 WHERE "role" = $2 RETURNING "id"`)).
+		WithArgs(false, "guest").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3).AddRow(8))
+
+	var rows []struct {
+		ID int64 `db:"id"`
+	}
+	err = querycraft.NewUpdateBuilder(sqlx.NewDb(db, "sqlmock"), &dialect.PostgresDialect{}, "users").
+		Set("active", false).
+		Where("role", "=", "guest").
+		Returning("id").
+		ExecReturning(&rows)
+	assert.NoError(t, err)
+	assert.Len(t, rows, 2)
+	assert.Equal(t, int64(8), rows[1].ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,154 @@
+package update_tests
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/antibomberman/querycraft"
+	"github.com/antibomberman/querycraft/dialect"
+	"github.com/antibomberman/querycraft/tests/test_utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateSetPostgres(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+	builder := querycraft.NewUpdateBuilder(mockDB, &dialect.PostgresDialect{}, "users")
+
+	sql, args := builder.Set("name", "John").Set("email", "john@example.com").Where("id", "=", 1).ToSQL()
+
+	// Нумерация плейсхолдеров общая для SET и WHERE
+	assert.Equal(t, `UPDATE "users" SET "name" = $1, "email" = $2 WHERE "id" = $3`, sql)
+	assert.Equal(t, []any{"John", "john@example.com", 1}, args)
+}
+
+func TestUpdateWhereInPostgres(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+	builder := querycraft.NewUpdateBuilder(mockDB, &dialect.PostgresDialect{}, "users")
+
+	sql, args := builder.Set("active", false).WhereIn("id", 1, 2, 3).ToSQL()
+
+	assert.Equal(t, `UPDATE "users" SET "active" = $1 WHERE "id" IN ($2, $3, $4)`, sql)
+	assert.Equal(t, []any{false, 1, 2, 3}, args)
+}
+
+func TestUpdateWhereILikePostgres(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+	builder := querycraft.NewUpdateBuilder(mockDB, &dialect.PostgresDialect{}, "users")
+
+	sql, args := builder.Set("active", false).WhereILike("email", "%@spam.com").OrWhereLike("name", "bot%").ToSQL()
+
+	assert.Equal(t, `UPDATE "users" SET "active" = $1 WHERE "email" ILIKE $2 OR "name" LIKE $3`, sql)
+	assert.Equal(t, []any{false, "%@spam.com", "bot%"}, args)
+}
+
+func TestUpdateWhereColumnPostgres(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+	builder := querycraft.NewUpdateBuilder(mockDB, &dialect.PostgresDialect{}, "users")
+
+	sql, args := builder.Set("synced", true).WhereColumn("updated_at", ">", "synced_at").Where("id", "=", 1).ToSQL()
+
+	assert.Equal(t, `UPDATE "users" SET "synced" = $1 WHERE "updated_at" > "synced_at" AND "id" = $2`, sql)
+	assert.Equal(t, []any{true, 1}, args)
+}
+
+func TestUpdateReturningPostgres(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+	builder := querycraft.NewUpdateBuilder(mockDB, &dialect.PostgresDialect{}, "users")
+
+	sql, args := builder.Set("active", false).Where("id", "=", 1).Returning("id", "updated_at").ToSQL()
+
+	assert.Equal(t, `UPDATE "users" SET "active" = $1 WHERE "id" = $2 RETURNING "id", "updated_at"`, sql)
+	assert.Equal(t, []any{false, 1}, args)
+}
+
+func TestUpdateLimitPostgres(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	// В PostgreSQL нет UPDATE ... LIMIT: без лимита обновилась бы вся выборка,
+	// поэтому запрос не выполняется
+	_, err = querycraft.NewUpdateBuilder(sqlx.NewDb(db, "sqlmock"), &dialect.PostgresDialect{}, "jobs").
+		Set("status", "queued").Where("status", "=", "new").Limit(100).Exec()
+	assert.ErrorIs(t, err, querycraft.ErrNotSupported)
+
+	_, err = querycraft.NewUpdateBuilder(sqlx.NewDb(db, "sqlmock"), &dialect.PostgresDialect{}, "jobs").
+		Set("status", "queued").Limit(100).ExecRowsAffected()
+	assert.ErrorIs(t, err, querycraft.ErrNotSupported)
+
+	var ids []int64
+	err = querycraft.NewUpdateBuilder(sqlx.NewDb(db, "sqlmock"), &dialect.PostgresDialect{}, "jobs").
+		Set("status", "queued").Limit(100).ExecReturning(&ids)
+	assert.ErrorIs(t, err, querycraft.ErrNotSupported)
+
+	// ORDER BY без LIMIT тоже выбросился бы из запроса
+	_, err = querycraft.NewUpdateBuilder(sqlx.NewDb(db, "sqlmock"), &dialect.PostgresDialect{}, "jobs").
+		Set("status", "queued").OrderBy("created_at").Exec()
+	assert.ErrorIs(t, err, querycraft.ErrNotSupported)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateWhereVariantsPostgres(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+	builder := querycraft.NewUpdateBuilder(mockDB, &dialect.PostgresDialect{}, "users")
+
+	sql, args := builder.Set("status", "archived").
+		WhereNull("deleted_at").
+		WhereNotNull("email").
+		WhereBetween("age", 18, 30).
+		WhereNotBetween("score", 0, 10).
+		WhereNotIn("role", "admin", "owner").
+		ToSQL()
+
+	assert.Equal(t, `UPDATE "users" SET "status" = $1 WHERE "deleted_at" IS NULL AND "email" IS NOT NULL AND "age" BETWEEN $2 AND $3 `+
+		`AND "score" NOT BETWEEN $4 AND $5 AND "role" NOT IN ($6, $7)`, sql)
+	assert.Equal(t, []any{"archived", 18, 30, 0, 10, "admin", "owner"}, args)
+}
+
+func TestUpdateOrWherePostgres(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+	builder := querycraft.NewUpdateBuilder(mockDB, &dialect.PostgresDialect{}, "users")
+
+	sql, args := builder.Set("active", false).
+		Where("status", "=", "banned").
+		OrWhere("attempts", ">", 5).
+		OrWhereEq("role", "bot").
+		OrWhereIn("id", 1, 2).
+		OrWhereNull("email").
+		OrWhereRaw(`"score" < ?`, 0).
+		ToSQL()
+
+	assert.Equal(t, `UPDATE "users" SET "active" = $1 WHERE "status" = $2 OR "attempts" > $3 OR "role" = $4 OR "id" IN ($5, $6) OR "email" IS NULL OR "score" < $7`, sql)
+	assert.Equal(t, []any{false, "banned", 5, "bot", 1, 2, 0}, args)
+}
+
+func TestUpdateWhereGroupPostgres(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+	builder := querycraft.NewUpdateBuilder(mockDB, &dialect.PostgresDialect{}, "users")
+
+	sql, args := builder.Set("active", false).
+		Where("status", "=", "pending").
+		WhereGroup(func(q querycraft.UpdateBuilder) querycraft.UpdateBuilder {
+			return q.Where("created_at", "<", "2024-01-01").OrWhereNull("email")
+		}).
+		OrWhereGroup(func(q querycraft.UpdateBuilder) querycraft.UpdateBuilder {
+			return q.OrWhereEq("role", "bot").Where("verified", "=", false)
+		}).
+		ToSQL()
+
+	assert.Equal(t, `UPDATE "users" SET "active" = $1 WHERE "status" = $2 AND ("created_at" < $3 OR "email" IS NULL) OR ("role" = $4 AND "verified" = $5)`, sql)
+	assert.Equal(t, []any{false, "pending", "2024-01-01", "bot", false}, args)
+}
+
+func TestUpdateIncrementPostgres(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+	builder := querycraft.NewUpdateBuilder(mockDB, &dialect.PostgresDialect{}, "products")
+
+	sql, args := builder.Increment("views", 1).Decrement("stock", 2).Where("id", "=", 7).ToSQL()
+
+	assert.Equal(t, `UPDATE "products" SET "views" = "views" + $1, "stock" = "stock" - $2 WHERE "id" = $3`, sql)
+	assert.Equal(t, []any{1, 2, 7}, args)
+}
@@ -0,0 +1,51 @@
+package update_tests
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/antibomberman/querycraft"
+	"github.com/antibomberman/querycraft/dialect"
+)
+
+func TestExecRowsAffected(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	d := &dialect.MySQLDialect{}
+
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `users` (`name`, `email`) VALUES (?, ?), (?, ?)")).
+		WillReturnResult(sqlmock.NewResult(2, 2))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `users` SET `active` = ? WHERE `id` > ?")).
+		WillReturnResult(sqlmock.NewResult(0, 5))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `users` WHERE `active` = ?")).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	inserted, err := querycraft.NewInsertBuilder(sqlxDB, d, "users").
+		Columns("name", "email").
+		Values("John", "john@example.com").
+		Values("Jane", "jane@example.com").
+		ExecRowsAffected()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), inserted)
+
+	updated, err := querycraft.NewUpdateBuilder(sqlxDB, d, "users").
+		Set("active", false).Where("id", ">", 10).
+		ExecRowsAffected()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), updated)
+
+	deleted, err := querycraft.NewDeleteBuilder(sqlxDB, d, "users").
+		Where("active", "=", false).
+		ExecRowsAffected()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), deleted)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
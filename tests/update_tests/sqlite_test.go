@@ -0,0 +1,29 @@
+package update_tests
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/antibomberman/querycraft"
+	"github.com/antibomberman/querycraft/dialect"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateLimitSQLite(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	// Без SQLITE_ENABLE_UPDATE_DELETE_LIMIT нет UPDATE ... LIMIT: запрос не выполняется
+	_, err = querycraft.NewUpdateBuilder(sqlx.NewDb(db, "sqlmock"), &dialect.SQLiteDialect{}, "jobs").
+		Set("status", "queued").Where("status", "=", "new").OrderBy("id").Limit(100).Exec()
+	assert.ErrorIs(t, err, querycraft.ErrNotSupported)
+
+	_, err = querycraft.NewUpdateBuilder(sqlx.NewDb(db, "sqlmock"), &dialect.SQLiteDialect{}, "jobs").
+		Set("status", "queued").Limit(100).ExecRowsAffected()
+	assert.ErrorIs(t, err, querycraft.ErrNotSupported)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -116,4 +116,121 @@ func TestUpdateJoin(t *testing.T) {
 
 	assert.Equal(t, expectedSQL, sql)
 	assert.Equal(t, expectedArgs, args)
-}
\ No newline at end of file
+}
+
+func TestUpdateSetJson(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+	builder := querycraft.NewUpdateBuilder(mockDB, &dialect.MySQLDialect{}, "users")
+
+	sql, args := builder.
+		SetJson("data", "$.name", "John").
+		SetJsonInsert("data", "$.age", 30).
+		SetJsonReplace("data", "$.city", "Paris").
+		SetJsonRemove("data", "$.tmp").
+		Where("id", "=", 1).
+		ToSQL()
+
+	expectedSQL := "UPDATE `users` SET `data` = JSON_SET(`data`, ?, ?), `data` = JSON_INSERT(`data`, ?, ?), " +
+		"`data` = JSON_REPLACE(`data`, ?, ?), `data` = JSON_REMOVE(`data`, ?) WHERE `id` = ?"
+	expectedArgs := []any{"$.name", "John", "$.age", 30, "$.city", "Paris", "$.tmp", 1}
+
+	assert.Equal(t, expectedSQL, sql)
+	assert.Equal(t, expectedArgs, args)
+}
+
+func TestUpdateSetFromSubquery(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+
+	maxScore := querycraft.NewSelectBuilder(mockDB, &dialect.MySQLDialect{}, "MAX(score)").
+		From("results").
+		WhereRaw("results.user_id = users.id").
+		Where("season", "=", 2024)
+	hasOrders := querycraft.NewSelectBuilder(mockDB, &dialect.MySQLDialect{}, "1").
+		From("orders").
+		WhereRaw("orders.user_id = users.id").
+		Where("status", "=", "paid")
+	avgRating := querycraft.NewSelectBuilder(mockDB, &dialect.MySQLDialect{}, "AVG(rating)").
+		From("users")
+
+	sql, args := querycraft.NewUpdateBuilder(mockDB, &dialect.MySQLDialect{}, "users").
+		Set("updated", true).
+		SetFromSelect("best_score", maxScore).
+		Where("active", "=", 1).
+		WhereExistsSubquery(hasOrders).
+		WhereFromSubquery("rating", ">", avgRating).
+		ToSQL()
+
+	// Аргументы подзапроса в SET идут перед аргументами WHERE
+	expectedSQL := "UPDATE `users` SET `updated` = ?, `best_score` = (SELECT MAX(score) FROM `results` WHERE results.user_id = users.id AND `season` = ?) " +
+		"WHERE `active` = ? AND EXISTS (SELECT 1 FROM `orders` WHERE orders.user_id = users.id AND `status` = ?) " +
+		"AND `rating` > (SELECT AVG(rating) FROM `users`)"
+	assert.Equal(t, expectedSQL, sql)
+	assert.Equal(t, []any{true, 2024, 1, "paid"}, args)
+}
+
+func TestUpdateOrderByLimit(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+	builder := querycraft.NewUpdateBuilder(mockDB, &dialect.MySQLDialect{}, "jobs")
+
+	sql, args := builder.Set("status", "queued").
+		Where("status", "=", "new").
+		OrderBy("priority").
+		OrderByDesc("created_at").
+		Limit(100).
+		ToSQL()
+
+	assert.Equal(t, "UPDATE `jobs` SET `status` = ? WHERE `status` = ? ORDER BY `priority`, `created_at` DESC LIMIT 100", sql)
+	assert.Equal(t, []any{"queued", "new"}, args)
+}
+
+func TestUpdateWhereVariants(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+	builder := querycraft.NewUpdateBuilder(mockDB, &dialect.MySQLDialect{}, "users")
+
+	sql, args := builder.Set("status", "archived").
+		WhereNull("deleted_at").
+		WhereNotNull("email").
+		WhereBetween("age", 18, 30).
+		WhereNotBetween("score", 0, 10).
+		WhereNotIn("role", "admin", "owner").
+		ToSQL()
+
+	assert.Equal(t, "UPDATE `users` SET `status` = ? WHERE `deleted_at` IS NULL AND `email` IS NOT NULL AND `age` BETWEEN ? AND ? "+
+		"AND `score` NOT BETWEEN ? AND ? AND `role` NOT IN (?, ?)", sql)
+	assert.Equal(t, []any{"archived", 18, 30, 0, 10, "admin", "owner"}, args)
+}
+
+func TestUpdateOrWhere(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+	builder := querycraft.NewUpdateBuilder(mockDB, &dialect.MySQLDialect{}, "users")
+
+	sql, args := builder.Set("active", false).
+		Where("status", "=", "banned").
+		OrWhere("attempts", ">", 5).
+		OrWhereEq("role", "bot").
+		OrWhereIn("id", 1, 2).
+		OrWhereNull("email").
+		OrWhereRaw("`score` < ?", 0).
+		ToSQL()
+
+	assert.Equal(t, "UPDATE `users` SET `active` = ? WHERE `status` = ? OR `attempts` > ? OR `role` = ? OR `id` IN (?, ?) OR `email` IS NULL OR `score` < ?", sql)
+	assert.Equal(t, []any{false, "banned", 5, "bot", 1, 2, 0}, args)
+}
+
+func TestUpdateWhereGroup(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+	builder := querycraft.NewUpdateBuilder(mockDB, &dialect.MySQLDialect{}, "users")
+
+	sql, args := builder.Set("active", false).
+		Where("status", "=", "pending").
+		WhereGroup(func(q querycraft.UpdateBuilder) querycraft.UpdateBuilder {
+			return q.Where("created_at", "<", "2024-01-01").OrWhereNull("email")
+		}).
+		OrWhereGroup(func(q querycraft.UpdateBuilder) querycraft.UpdateBuilder {
+			return q.OrWhereEq("role", "bot").Where("verified", "=", false)
+		}).
+		ToSQL()
+
+	assert.Equal(t, "UPDATE `users` SET `active` = ? WHERE `status` = ? AND (`created_at` < ? OR `email` IS NULL) OR (`role` = ? AND `verified` = ?)", sql)
+	assert.Equal(t, []any{false, "pending", "2024-01-01", "bot", false}, args)
+}
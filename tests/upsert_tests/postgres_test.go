@@ -0,0 +1,24 @@
+package upsert_tests
+
+import (
+	"testing"
+
+	"github.com/antibomberman/querycraft"
+	"github.com/antibomberman/querycraft/dialect"
+	"github.com/antibomberman/querycraft/tests/test_utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpsertOnConflictDoUpdatePostgres(t *testing.T) {
+	mockDB := &test_utils.MockSQLXExecutor{}
+	builder := querycraft.NewUpsertBuilder(mockDB, &dialect.PostgresDialect{}, "users")
+
+	sql, args := builder.Columns("id", "name", "email").
+		Values(User{ID: 1, Name: "John", Email: "john@example.com"}).
+		OnConflict("id").
+		DoUpdate("name", "email").
+		ToSQL()
+
+	assert.Equal(t, `INSERT INTO users ("id", "name", "email") VALUES ($1, $2, $3) ON CONFLICT ("id") DO UPDATE SET "name" = EXCLUDED."name", "email" = EXCLUDED."email"`, sql)
+	assert.Equal(t, []any{1, "John", "john@example.com"}, args)
+}
@@ -2,7 +2,11 @@ package querycraft
 
 import (
 	"context"
+	"crypto/rand"
 	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"time"
 
 	"github.com/antibomberman/querycraft/dialect"
 	"github.com/jmoiron/sqlx"
@@ -19,6 +23,7 @@ type Transaction interface {
 
 	// Raw queries
 	Raw(query string, args ...any) Raw
+	BatchExec(queries []RawQuery) error
 
 	// Bulk operations
 	Bulk() BulkBuilder
@@ -31,6 +36,13 @@ type Transaction interface {
 	Rollback() error
 	GetTx() *sqlx.Tx
 
+	// Savepoints
+	Savepoint(name string) error
+	ReleaseSavepoint(name string) error
+	RollbackToSavepoint(name string) error
+	AutoSavepoint() SavepointHandle
+	Nested(fn func(Transaction) error) error
+
 	// Context
 	WithContext(ctx context.Context) Transaction
 
@@ -139,6 +151,10 @@ func (t *transaction) Raw(query string, args ...any) Raw {
 	return builder
 }
 
+func (t *transaction) BatchExec(queries []RawQuery) error {
+	return batchExec(t.ctx, t.tx, t.logger, queries)
+}
+
 func (t *transaction) Begin() (Transaction, error) {
 	// Nested transactions are not supported in most databases
 	return nil, sql.ErrTxDone
@@ -181,3 +197,128 @@ func (t *transaction) SetLogger(logger Logger) Transaction {
 	t.logger = logger
 	return t
 }
+
+// Savepoint - SAVEPOINT name
+func (t *transaction) Savepoint(name string) error {
+	return t.execSavepoint("SAVEPOINT " + t.dialect.QuoteIdentifier(name))
+}
+
+// ReleaseSavepoint - RELEASE SAVEPOINT name
+func (t *transaction) ReleaseSavepoint(name string) error {
+	return t.execSavepoint("RELEASE SAVEPOINT " + t.dialect.QuoteIdentifier(name))
+}
+
+// RollbackToSavepoint - ROLLBACK TO SAVEPOINT name, транзакция остается открытой
+func (t *transaction) RollbackToSavepoint(name string) error {
+	return t.execSavepoint("ROLLBACK TO SAVEPOINT " + t.dialect.QuoteIdentifier(name))
+}
+
+// Nested выполняет fn внутри savepoint: при ошибке изменения fn откатываются
+// до savepoint, а внешняя транзакция продолжает работу. Ошибка fn возвращается
+func (t *transaction) Nested(fn func(Transaction) error) error {
+	sp := t.AutoSavepoint()
+	if err := sp.Err(); err != nil {
+		return err
+	}
+
+	if err := fn(t); err != nil {
+		if rbErr := sp.Rollback(); rbErr != nil {
+			return fmt.Errorf("%w (rollback to savepoint: %v)", err, rbErr)
+		}
+		return err
+	}
+
+	return sp.Release()
+}
+
+// SavepointHandle - savepoint с автоматически сгенерированным именем.
+// Реализует error: если создать savepoint не удалось, Err() вернет причину,
+// а Release/Rollback вернут ту же ошибку.
+type SavepointHandle interface {
+	error
+	Name() string
+	Err() error
+	Release() error
+	Rollback() error
+}
+
+type savepointHandle struct {
+	tx   *transaction
+	name string
+	err  error
+}
+
+// AutoSavepoint создает savepoint с уникальным именем
+func (t *transaction) AutoSavepoint() SavepointHandle {
+	handle := &savepointHandle{tx: t}
+
+	name, err := newSavepointName()
+	if err != nil {
+		handle.err = err
+		return handle
+	}
+	handle.name = name
+	handle.err = t.execSavepoint("SAVEPOINT " + name)
+
+	return handle
+}
+
+func (h *savepointHandle) Name() string {
+	return h.name
+}
+
+func (h *savepointHandle) Err() error {
+	return h.err
+}
+
+func (h *savepointHandle) Error() string {
+	if h.err != nil {
+		return fmt.Sprintf("savepoint %s: %v", h.name, h.err)
+	}
+	return fmt.Sprintf("savepoint %s", h.name)
+}
+
+func (h *savepointHandle) Release() error {
+	if h.err != nil {
+		return h.err
+	}
+	return h.tx.execSavepoint("RELEASE SAVEPOINT " + h.name)
+}
+
+func (h *savepointHandle) Rollback() error {
+	if h.err != nil {
+		return h.err
+	}
+	return h.tx.execSavepoint("ROLLBACK TO SAVEPOINT " + h.name)
+}
+
+func (t *transaction) execSavepoint(query string) error {
+	// Log query if logger is set
+	var start time.Time
+	if t.logger != nil {
+		logQueryStart(t.logger, t.ctx, query, nil)
+		start = time.Now()
+	}
+
+	_, err := t.tx.ExecContext(t.ctx, query)
+
+	// Log query execution
+	if t.logger != nil {
+		duration := time.Since(start)
+		logQuery(t.logger, t.ctx, query, nil, duration, err)
+	}
+
+	return err
+}
+
+// newSavepointName генерирует имя вида sp_<uuid без дефисов>
+func newSavepointName() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	// UUID v4
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return "sp_" + hex.EncodeToString(b), nil
+}
@@ -21,6 +21,14 @@ type UpdateBuilder interface {
 	SetMap(values map[string]any) UpdateBuilder
 	SetStruct(data any) UpdateBuilder
 	Columns(columns ...string) UpdateBuilder
+	SetFromSubquery(column string, subquery SelectBuilder) UpdateBuilder
+	SetFromSelect(column string, subquery SelectBuilder) UpdateBuilder
+
+	// JSON колонки
+	SetJson(column, path string, value any) UpdateBuilder
+	SetJsonInsert(column, path string, value any) UpdateBuilder
+	SetJsonReplace(column, path string, value any) UpdateBuilder
+	SetJsonRemove(column, path string) UpdateBuilder
 
 	// Инкремент/декремент
 	Increment(column string, value ...int) UpdateBuilder
@@ -30,7 +38,31 @@ type UpdateBuilder interface {
 	Where(column, operator string, value any) UpdateBuilder
 	WhereEq(column string, value any) UpdateBuilder
 	WhereIn(column string, values ...any) UpdateBuilder
+	WhereNotIn(column string, values ...any) UpdateBuilder
+	WhereNull(column ...string) UpdateBuilder
+	WhereNotNull(column ...string) UpdateBuilder
+	WhereBetween(column string, from, to any) UpdateBuilder
+	WhereNotBetween(column string, from, to any) UpdateBuilder
 	WhereRaw(condition string, args ...any) UpdateBuilder
+	WhereLike(column, pattern string) UpdateBuilder
+	WhereNotLike(column, pattern string) UpdateBuilder
+	WhereILike(column, pattern string) UpdateBuilder
+	OrWhereLike(column, pattern string) UpdateBuilder
+	WhereColumn(first, operator, second string) UpdateBuilder
+	OrWhereColumn(first, operator, second string) UpdateBuilder
+	WhereExistsSubquery(subquery SelectBuilder) UpdateBuilder
+	WhereFromSubquery(column, operator string, subquery SelectBuilder) UpdateBuilder
+
+	// OR WHERE условия
+	OrWhere(column, operator string, value any) UpdateBuilder
+	OrWhereEq(column string, value any) UpdateBuilder
+	OrWhereIn(column string, values ...any) UpdateBuilder
+	OrWhereNull(column ...string) UpdateBuilder
+	OrWhereRaw(condition string, args ...any) UpdateBuilder
+
+	// WHERE группировка
+	WhereGroup(fn func(UpdateBuilder) UpdateBuilder) UpdateBuilder
+	OrWhereGroup(fn func(UpdateBuilder) UpdateBuilder) UpdateBuilder
 
 	// Условное обновление
 	When(condition bool, column string, value any) UpdateBuilder
@@ -40,13 +72,24 @@ type UpdateBuilder interface {
 	Join(table, condition string) UpdateBuilder
 	LeftJoin(table, condition string) UpdateBuilder
 
+	// Ограничения (MySQL: UPDATE ... ORDER BY ... LIMIT n)
+	Limit(limit int) UpdateBuilder
+	OrderBy(column string) UpdateBuilder
+	OrderByDesc(column string) UpdateBuilder
+
+	// RETURNING (PostgreSQL), в MySQL не добавляется
+	Returning(columns ...string) UpdateBuilder
+
 	// Выполнение
 	Exec() (sql.Result, error)
+	ExecRowsAffected() (int64, error)
+	ExecReturning(dest any) error
 
 	// Утилиты
 	WithContext(ctx context.Context) UpdateBuilder
 	ToSQL() (string, []any)
 	PrintSQL() UpdateBuilder
+	PrettyPrint() UpdateBuilder
 	Clone() UpdateBuilder
 }
 
@@ -62,11 +105,14 @@ type updateBuilder struct {
 	joins     []string
 	wheres    []string
 	whereArgs []any
+	orders    []string
 	limit     *int
 	columns   []string
+	returning []string
 
 	// Print SQL flag
-	printSQL bool
+	printSQL  bool
+	prettySQL bool
 }
 
 func NewUpdateBuilder(db SQLXExecutor, dialect dialect.Dialect, table string) UpdateBuilder {
@@ -90,6 +136,20 @@ func (u *updateBuilder) SetRaw(expression string, args ...any) UpdateBuilder {
 	return u
 }
 
+// SetFromSubquery устанавливает значение из скалярного подзапроса:
+// SET column = (SELECT MAX(...) FROM t2 WHERE t2.id = t1.id)
+func (u *updateBuilder) SetFromSubquery(column string, subquery SelectBuilder) UpdateBuilder {
+	sql, args := subquery.ToSQL()
+	u.sets = append(u.sets, fmt.Sprintf("%s = (%s)", u.dialect.QuoteIdentifier(column), sql))
+	u.setArgs = append(u.setArgs, args...)
+	return u
+}
+
+// SetFromSelect - синоним SetFromSubquery
+func (u *updateBuilder) SetFromSelect(column string, subquery SelectBuilder) UpdateBuilder {
+	return u.SetFromSubquery(column, subquery)
+}
+
 func (u *updateBuilder) SetMap(values map[string]any) UpdateBuilder {
 	for col, val := range values {
 		u.Set(col, val)
@@ -168,6 +228,38 @@ func (u *updateBuilder) Columns(columns ...string) UpdateBuilder {
 	return u
 }
 
+// SetJson - column = JSON_SET(column, path, value)
+func (u *updateBuilder) SetJson(column, path string, value any) UpdateBuilder {
+	quoted := u.dialect.QuoteIdentifier(column)
+	u.sets = append(u.sets, fmt.Sprintf("%s = %s", quoted, u.dialect.JsonSet(quoted, path)))
+	u.setArgs = append(u.setArgs, path, value)
+	return u
+}
+
+// SetJsonInsert - добавляет значение, только если пути еще нет
+func (u *updateBuilder) SetJsonInsert(column, path string, value any) UpdateBuilder {
+	quoted := u.dialect.QuoteIdentifier(column)
+	u.sets = append(u.sets, fmt.Sprintf("%s = %s", quoted, u.dialect.JsonInsert(quoted, path)))
+	u.setArgs = append(u.setArgs, path, value)
+	return u
+}
+
+// SetJsonReplace - заменяет значение, только если путь уже существует
+func (u *updateBuilder) SetJsonReplace(column, path string, value any) UpdateBuilder {
+	quoted := u.dialect.QuoteIdentifier(column)
+	u.sets = append(u.sets, fmt.Sprintf("%s = %s", quoted, u.dialect.JsonReplace(quoted, path)))
+	u.setArgs = append(u.setArgs, path, value)
+	return u
+}
+
+// SetJsonRemove - удаляет значение по пути
+func (u *updateBuilder) SetJsonRemove(column, path string) UpdateBuilder {
+	quoted := u.dialect.QuoteIdentifier(column)
+	u.sets = append(u.sets, fmt.Sprintf("%s = %s", quoted, u.dialect.JsonRemove(quoted, path)))
+	u.setArgs = append(u.setArgs, path)
+	return u
+}
+
 func (u *updateBuilder) Increment(column string, value ...int) UpdateBuilder {
 	inc := 1
 	if len(value) > 0 {
@@ -216,12 +308,177 @@ func (u *updateBuilder) WhereIn(column string, values ...any) UpdateBuilder {
 	return u
 }
 
+func (u *updateBuilder) WhereNotIn(column string, values ...any) UpdateBuilder {
+	placeholders := make([]string, len(values))
+	for i := range values {
+		placeholders[i] = u.dialect.PlaceholderFormat()
+	}
+	u.wheres = append(u.wheres, fmt.Sprintf("%s NOT IN (%s)", u.dialect.QuoteIdentifier(column), strings.Join(placeholders, ", ")))
+	u.whereArgs = append(u.whereArgs, values...)
+	return u
+}
+
+func (u *updateBuilder) WhereNull(columns ...string) UpdateBuilder {
+	for _, column := range columns {
+		u.wheres = append(u.wheres, fmt.Sprintf("%s IS NULL", u.dialect.QuoteIdentifier(column)))
+	}
+	return u
+}
+
+func (u *updateBuilder) WhereNotNull(columns ...string) UpdateBuilder {
+	for _, column := range columns {
+		u.wheres = append(u.wheres, fmt.Sprintf("%s IS NOT NULL", u.dialect.QuoteIdentifier(column)))
+	}
+	return u
+}
+
+func (u *updateBuilder) WhereBetween(column string, from, to any) UpdateBuilder {
+	u.wheres = append(u.wheres, fmt.Sprintf("%s BETWEEN %s AND %s",
+		u.dialect.QuoteIdentifier(column),
+		u.dialect.PlaceholderFormat(),
+		u.dialect.PlaceholderFormat()))
+	u.whereArgs = append(u.whereArgs, from, to)
+	return u
+}
+
+func (u *updateBuilder) WhereNotBetween(column string, from, to any) UpdateBuilder {
+	u.wheres = append(u.wheres, fmt.Sprintf("%s NOT BETWEEN %s AND %s",
+		u.dialect.QuoteIdentifier(column),
+		u.dialect.PlaceholderFormat(),
+		u.dialect.PlaceholderFormat()))
+	u.whereArgs = append(u.whereArgs, from, to)
+	return u
+}
+
 func (u *updateBuilder) WhereRaw(condition string, args ...any) UpdateBuilder {
 	u.wheres = append(u.wheres, condition)
 	u.whereArgs = append(u.whereArgs, args...)
 	return u
 }
 
+func (u *updateBuilder) OrWhere(column, operator string, value any) UpdateBuilder {
+	u.wheres = append(u.wheres, fmt.Sprintf("OR %s %s %s", u.dialect.QuoteIdentifier(column), operator, u.dialect.PlaceholderFormat()))
+	u.whereArgs = append(u.whereArgs, value)
+	return u
+}
+
+func (u *updateBuilder) OrWhereEq(column string, value any) UpdateBuilder {
+	return u.OrWhere(column, "=", value)
+}
+
+func (u *updateBuilder) OrWhereIn(column string, values ...any) UpdateBuilder {
+	placeholders := make([]string, len(values))
+	for i := range values {
+		placeholders[i] = u.dialect.PlaceholderFormat()
+	}
+	u.wheres = append(u.wheres, fmt.Sprintf("OR %s IN (%s)", u.dialect.QuoteIdentifier(column), strings.Join(placeholders, ", ")))
+	u.whereArgs = append(u.whereArgs, values...)
+	return u
+}
+
+func (u *updateBuilder) OrWhereNull(columns ...string) UpdateBuilder {
+	for _, column := range columns {
+		u.wheres = append(u.wheres, fmt.Sprintf("OR %s IS NULL", u.dialect.QuoteIdentifier(column)))
+	}
+	return u
+}
+
+func (u *updateBuilder) OrWhereRaw(condition string, args ...any) UpdateBuilder {
+	u.wheres = append(u.wheres, "OR "+condition)
+	u.whereArgs = append(u.whereArgs, args...)
+	return u
+}
+
+func (u *updateBuilder) WhereGroup(fn func(UpdateBuilder) UpdateBuilder) UpdateBuilder {
+	return u.whereGroup(fn, "AND")
+}
+
+func (u *updateBuilder) OrWhereGroup(fn func(UpdateBuilder) UpdateBuilder) UpdateBuilder {
+	return u.whereGroup(fn, "OR")
+}
+
+// whereGroup собирает условия fn во временном билдере и добавляет их в скобках
+func (u *updateBuilder) whereGroup(fn func(UpdateBuilder) UpdateBuilder, prefix string) UpdateBuilder {
+	// Временный билдер без таблицы и SET - только для WHERE
+	groupBuilder := &updateBuilder{
+		db:        u.db,
+		dialect:   u.dialect,
+		ctx:       u.ctx,
+		wheres:    make([]string, 0),
+		whereArgs: make([]any, 0),
+	}
+
+	builder := fn(groupBuilder)
+
+	if ub, ok := builder.(*updateBuilder); ok && len(ub.wheres) > 0 {
+		var whereParts []string
+		for i, where := range ub.wheres {
+			if i == 0 {
+				// Первое условие группы может прийти из OrWhere*, префикс внутри скобок не нужен
+				whereParts = append(whereParts, strings.TrimPrefix(strings.TrimPrefix(where, "AND "), "OR "))
+			} else if strings.HasPrefix(where, "AND ") || strings.HasPrefix(where, "OR ") || strings.HasPrefix(where, "(") {
+				whereParts = append(whereParts, where)
+			} else {
+				whereParts = append(whereParts, "AND "+where)
+			}
+		}
+
+		// Префикс AND/OR только если у нас уже есть условия
+		if len(u.wheres) > 0 {
+			u.wheres = append(u.wheres, fmt.Sprintf("%s (%s)", prefix, strings.Join(whereParts, " ")))
+		} else {
+			u.wheres = append(u.wheres, fmt.Sprintf("(%s)", strings.Join(whereParts, " ")))
+		}
+		u.whereArgs = append(u.whereArgs, ub.whereArgs...)
+	}
+
+	return u
+}
+
+func (u *updateBuilder) WhereLike(column, pattern string) UpdateBuilder {
+	return u.Where(column, "LIKE", pattern)
+}
+
+func (u *updateBuilder) WhereNotLike(column, pattern string) UpdateBuilder {
+	return u.Where(column, "NOT LIKE", pattern)
+}
+
+// WhereILike - регистронезависимый LIKE (ILIKE в PostgreSQL)
+func (u *updateBuilder) WhereILike(column, pattern string) UpdateBuilder {
+	return u.Where(column, u.dialect.ILikeOperator(), pattern)
+}
+
+func (u *updateBuilder) OrWhereLike(column, pattern string) UpdateBuilder {
+	return u.OrWhere(column, "LIKE", pattern)
+}
+
+// WhereColumn сравнивает две колонки, например `users`.`id` = `orders`.`user_id`
+func (u *updateBuilder) WhereColumn(first, operator, second string) UpdateBuilder {
+	u.wheres = append(u.wheres, fmt.Sprintf("%s %s %s", u.dialect.QuoteIdentifier(first), operator, u.dialect.QuoteIdentifier(second)))
+	return u
+}
+
+func (u *updateBuilder) OrWhereColumn(first, operator, second string) UpdateBuilder {
+	u.wheres = append(u.wheres, fmt.Sprintf("OR %s %s %s", u.dialect.QuoteIdentifier(first), operator, u.dialect.QuoteIdentifier(second)))
+	return u
+}
+
+// WhereExistsSubquery добавляет условие EXISTS (подзапрос), обычно коррелированный
+func (u *updateBuilder) WhereExistsSubquery(subquery SelectBuilder) UpdateBuilder {
+	sql, args := subquery.ToSQL()
+	u.wheres = append(u.wheres, fmt.Sprintf("EXISTS (%s)", sql))
+	u.whereArgs = append(u.whereArgs, args...)
+	return u
+}
+
+// WhereFromSubquery сравнивает колонку с результатом подзапроса: column > (SELECT AVG(...) ...)
+func (u *updateBuilder) WhereFromSubquery(column, operator string, subquery SelectBuilder) UpdateBuilder {
+	sql, args := subquery.ToSQL()
+	u.wheres = append(u.wheres, fmt.Sprintf("%s %s (%s)", u.dialect.QuoteIdentifier(column), operator, sql))
+	u.whereArgs = append(u.whereArgs, args...)
+	return u
+}
+
 func (u *updateBuilder) When(condition bool, column string, value any) UpdateBuilder {
 	if condition {
 		return u.Where(column, "=", value)
@@ -270,6 +527,21 @@ func (u *updateBuilder) LeftJoin(table, condition string) UpdateBuilder {
 	return u
 }
 
+func (u *updateBuilder) Limit(limit int) UpdateBuilder {
+	u.limit = &limit
+	return u
+}
+
+func (u *updateBuilder) OrderBy(column string) UpdateBuilder {
+	u.orders = append(u.orders, orderExpression(u.dialect.SelectOrderBy(column, false)))
+	return u
+}
+
+func (u *updateBuilder) OrderByDesc(column string) UpdateBuilder {
+	u.orders = append(u.orders, orderExpression(u.dialect.SelectOrderBy(column, true)))
+	return u
+}
+
 func (u *updateBuilder) buildSQL() (string, []any) {
 	var queryParts []string
 	var args []any
@@ -309,12 +581,24 @@ func (u *updateBuilder) buildSQL() (string, []any) {
 		args = append(args, u.whereArgs...)
 	}
 
-	// LIMIT
+	// ORDER BY имеет смысл только там, где есть UPDATE ... LIMIT (MySQL)
+	if len(u.orders) > 0 && u.dialect.UpdateLimit(1) != "" {
+		queryParts = append(queryParts, "ORDER BY "+strings.Join(u.orders, ", "))
+	}
+
+	// LIMIT (PostgreSQL не поддерживает, диалект возвращает пустую строку, а Exec - ErrNotSupported)
 	if u.limit != nil {
-		queryParts = append(queryParts, u.dialect.UpdateLimit(*u.limit))
+		if limitClause := u.dialect.UpdateLimit(*u.limit); limitClause != "" {
+			queryParts = append(queryParts, limitClause)
+		}
 	}
 
-	return strings.Join(queryParts, " "), args
+	if returning := returningClause(u.dialect, u.returning); returning != "" {
+		queryParts = append(queryParts, returning)
+	}
+
+	// Плейсхолдеры в формате драйвера, нумерация общая для SET и WHERE
+	return u.dialect.Rebind(strings.Join(queryParts, " ")), args
 }
 
 func (u *updateBuilder) ToSQL() (string, []any) {
@@ -326,11 +610,49 @@ func (u *updateBuilder) PrintSQL() UpdateBuilder {
 	return u
 }
 
+// PrettyPrint печатает SQL в многострочном виде (см. FormatSQL)
+func (u *updateBuilder) PrettyPrint() UpdateBuilder {
+	u.printSQL = true
+	u.prettySQL = true
+	return u
+}
+
 func (u *updateBuilder) setLogger(logger Logger) {
 	u.logger = logger
 }
 
+// Returning задает колонки RETURNING, "*" - все колонки
+func (u *updateBuilder) Returning(columns ...string) UpdateBuilder {
+	u.returning = columns
+	return u
+}
+
+// ExecReturning выполняет UPDATE ... RETURNING и сканирует строки в dest
+func (u *updateBuilder) ExecReturning(dest any) error {
+	if err := u.validate(); err != nil {
+		return err
+	}
+	if len(u.returning) == 0 {
+		u.returning = []string{"*"}
+	}
+	sql, args := u.buildSQL()
+	return queryReturning(u.ctx, u.db, u.logger, u.dialect, dest, sql, args)
+}
+
+// validate не дает выполнить UPDATE, из которого диалект выбросил бы ограничение
+func (u *updateBuilder) validate() error {
+	// Без UPDATE ... LIMIT (PostgreSQL, SQLite) обновилась бы вся выборка, а не limit строк,
+	// а ORDER BY без LIMIT в UPDATE не имеет смысла и тоже выбрасывается
+	if (u.limit != nil || len(u.orders) > 0) && u.dialect.UpdateLimit(1) == "" {
+		return fmt.Errorf("UPDATE with Limit/OrderBy: %w", ErrNotSupported)
+	}
+	return nil
+}
+
 func (u *updateBuilder) Exec() (sql.Result, error) {
+	if err := u.validate(); err != nil {
+		return nil, err
+	}
 	sql, args := u.buildSQL()
 
 	// Print SQL if needed
@@ -340,12 +662,16 @@ func (u *updateBuilder) Exec() (sql.Result, error) {
 		for _, arg := range args {
 			formattedSQL = strings.Replace(formattedSQL, u.dialect.PlaceholderFormat(), fmt.Sprintf("'%v'", arg), 1)
 		}
+		if u.prettySQL {
+			formattedSQL = FormatSQL(formattedSQL)
+		}
 		fmt.Println(formattedSQL)
 	}
 
 	// Log query if logger is set
 	var start time.Time
 	if u.logger != nil {
+		logQueryStart(u.logger, u.ctx, sql, args)
 		start = time.Now()
 	}
 
@@ -354,12 +680,22 @@ func (u *updateBuilder) Exec() (sql.Result, error) {
 	// Log query execution
 	if u.logger != nil {
 		duration := time.Since(start)
-		u.logger.LogQuery(u.ctx, sql, args, duration, err)
+		logQuery(u.logger, u.ctx, sql, args, duration, err)
 	}
 
 	return result, err
 }
 
+// ExecRowsAffected выполняет запрос и возвращает количество затронутых строк
+func (u *updateBuilder) ExecRowsAffected() (int64, error) {
+	result, err := u.Exec()
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
 func (u *updateBuilder) WithContext(ctx context.Context) UpdateBuilder {
 	u.ctx = ctx
 	return u
@@ -383,6 +719,8 @@ func (u *updateBuilder) Clone() UpdateBuilder {
 	copy(clone.wheres, u.wheres)
 	copy(clone.joins, u.joins)
 	copy(clone.columns, u.columns)
+	clone.orders = append([]string(nil), u.orders...)
+	clone.returning = append([]string(nil), u.returning...)
 
 	// Copy args slices
 	clone.setArgs = make([]any, len(u.setArgs))
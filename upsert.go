@@ -34,6 +34,7 @@ type UpsertBuilder interface {
 	WithContext(ctx context.Context) UpsertBuilder
 	ToSQL() (string, []any)
 	PrintSQL() UpsertBuilder
+	PrettyPrint() UpsertBuilder
 }
 type UpsertAction int
 
@@ -60,7 +61,8 @@ type upsertBuilder struct {
 	action          UpsertAction
 
 	// Print SQL flag
-	printSQL bool
+	printSQL  bool
+	prettySQL bool
 }
 
 func NewUpsertBuilder(db SQLXExecutor, dialect dialect.Dialect, table string) UpsertBuilder {
@@ -249,7 +251,8 @@ func (u *upsertBuilder) buildSQL() (string, []any) {
 		queryParts = append(queryParts, u.dialect.InsertOnConflict(u.conflictColumns, u.updateColumns, u.updateExcluded))
 	}
 
-	return strings.Join(queryParts, " "), args
+	// Плейсхолдеры в формате драйвера ($1, $2... для PostgreSQL)
+	return u.dialect.Rebind(strings.Join(queryParts, " ")), args
 }
 
 func (u *upsertBuilder) ToSQL() (string, []any) {
@@ -261,6 +264,13 @@ func (u *upsertBuilder) PrintSQL() UpsertBuilder {
 	return u
 }
 
+// PrettyPrint печатает SQL в многострочном виде (см. FormatSQL)
+func (u *upsertBuilder) PrettyPrint() UpsertBuilder {
+	u.printSQL = true
+	u.prettySQL = true
+	return u
+}
+
 func (u *upsertBuilder) setLogger(logger Logger) {
 	u.logger = logger
 }
@@ -275,12 +285,16 @@ func (u *upsertBuilder) Exec() (sql.Result, error) {
 		for _, arg := range args {
 			formattedSQL = strings.Replace(formattedSQL, u.dialect.PlaceholderFormat(), fmt.Sprintf("'%v'", arg), 1)
 		}
+		if u.prettySQL {
+			formattedSQL = FormatSQL(formattedSQL)
+		}
 		fmt.Println(formattedSQL)
 	}
 
 	// Log query if logger is set
 	var start time.Time
 	if u.logger != nil {
+		logQueryStart(u.logger, u.ctx, sql, args)
 		start = time.Now()
 	}
 
@@ -289,7 +303,7 @@ func (u *upsertBuilder) Exec() (sql.Result, error) {
 	// Log query execution
 	if u.logger != nil {
 		duration := time.Since(start)
-		u.logger.LogQuery(u.ctx, sql, args, duration, err)
+		logQuery(u.logger, u.ctx, sql, args, duration, err)
 	}
 
 	return result, err